@@ -0,0 +1,442 @@
+package assemblyai
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ContentSafetyAggregatorOptions configures when a [ContentSafetyAggregator]
+// flags a transcript. Zero values disable the corresponding check.
+type ContentSafetyAggregatorOptions struct {
+	// Flag transcripts with a section whose severity for any content safety
+	// label is at least this value, from 0 to 1.
+	SeverityThreshold float64
+
+	// Flag transcripts with at least this many content safety sections per hour
+	// of audio.
+	RateThreshold float64
+
+	// Flag transcripts whose overall confidence for a content safety label is
+	// at least the given value, from 0 to 1.
+	LabelThresholds map[string]float64
+
+	// Flag transcripts whose overall relevance for an IAB topic is at least the
+	// given value, from 0 to 1.
+	TopicThresholds map[string]float64
+}
+
+// ContentSafetyAggregator computes statistics about content safety labels and
+// topics across many transcripts. It's safe for concurrent use.
+type ContentSafetyAggregator struct {
+	opts ContentSafetyAggregatorOptions
+
+	mu          sync.Mutex
+	transcripts int
+	audioHours  float64
+	labels      map[string]*labelAccumulator
+	topics      map[string]*topicAccumulator
+	flagged     []FlaggedTranscript
+}
+
+type labelAccumulator struct {
+	stats      ContentSafetyStats
+	confidence float64
+	summaries  int
+	scores     SeverityScores
+	scored     int
+}
+
+type topicAccumulator struct {
+	stats     TopicStats
+	relevance float64
+}
+
+// NewContentSafetyAggregator returns an empty aggregator.
+func NewContentSafetyAggregator(opts *ContentSafetyAggregatorOptions) *ContentSafetyAggregator {
+	a := &ContentSafetyAggregator{
+		labels: make(map[string]*labelAccumulator),
+		topics: make(map[string]*topicAccumulator),
+	}
+
+	if opts != nil {
+		a.opts = *opts
+	}
+
+	return a
+}
+
+// ContentSafetyReport summarizes the transcripts added to a
+// [ContentSafetyAggregator].
+type ContentSafetyReport struct {
+	// The number of transcripts.
+	Transcripts int `json:"transcripts"`
+
+	// The total duration of the audio, in hours.
+	AudioHours float64 `json:"audio_hours"`
+
+	// Content safety labels, from most to least frequent.
+	ContentSafety []ContentSafetyStats `json:"content_safety"`
+
+	// IAB topics, from most to least frequent.
+	Topics []TopicStats `json:"topics"`
+
+	// Transcripts that exceeded a threshold, in the order they were added.
+	Flagged []FlaggedTranscript `json:"flagged"`
+}
+
+// ContentSafetyStats holds the statistics of a single content safety label.
+type ContentSafetyStats struct {
+	Label string `json:"label"`
+
+	// The number of transcripts in which the label was detected.
+	Transcripts int `json:"transcripts"`
+
+	// The number of sections in which the label was detected.
+	Sections int `json:"sections"`
+
+	// The number of sections per hour of audio, across all transcripts.
+	RatePerHour float64 `json:"rate_per_hour"`
+
+	// The mean overall confidence of the transcripts in which the label was
+	// detected.
+	MeanConfidence float64 `json:"mean_confidence"`
+
+	// The highest severity of any section.
+	MaxSeverity float64 `json:"max_severity"`
+
+	// The distribution of section severities.
+	Severity SeverityDistribution `json:"severity"`
+
+	// The mean of the severity score summaries of the transcripts in which the
+	// label was detected.
+	SeverityScores SeverityScores `json:"severity_scores"`
+}
+
+// SeverityScores holds the proportions of low, medium and high severity of a
+// label, as in [SeverityScoreSummary].
+type SeverityScores struct {
+	Low    float64 `json:"low"`
+	Medium float64 `json:"medium"`
+	High   float64 `json:"high"`
+}
+
+// SeverityDistribution counts sections by severity. Low is below 0.4, medium
+// below 0.7 and high from 0.7 up.
+type SeverityDistribution struct {
+	Low    int `json:"low"`
+	Medium int `json:"medium"`
+	High   int `json:"high"`
+}
+
+// TopicStats holds the statistics of a single IAB topic.
+type TopicStats struct {
+	Label string `json:"label"`
+
+	// The number of transcripts in which the topic was detected.
+	Transcripts int `json:"transcripts"`
+
+	// The mean overall relevance of the transcripts in which the topic was
+	// detected.
+	MeanRelevance float64 `json:"mean_relevance"`
+
+	// The highest overall relevance of any transcript.
+	MaxRelevance float64 `json:"max_relevance"`
+}
+
+// FlaggedTranscript is a transcript that exceeded one or more thresholds.
+type FlaggedTranscript struct {
+	TranscriptID string   `json:"transcript_id"`
+	Reasons      []string `json:"reasons"`
+}
+
+// Add adds the content safety labels and topics of a transcript to the
+// aggregate.
+func (a *ContentSafetyAggregator) Add(t Transcript) {
+	hours := ToFloat64(t.AudioDuration) / 3600
+
+	safety := t.ContentSafetyLabels
+
+	var (
+		reasons     []string
+		sections    int
+		maxSeverity float64
+	)
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.transcripts++
+	a.audioHours += hours
+
+	seen := make(map[string]bool)
+
+	for _, result := range safety.Results {
+		if len(result.Labels) > 0 {
+			sections++
+		}
+
+		for _, l := range result.Labels {
+			label := ToString(l.Label)
+			severity := ToFloat64(l.Severity)
+
+			acc := a.label(label)
+			acc.stats.Sections++
+
+			if !seen[label] {
+				seen[label] = true
+				acc.stats.Transcripts++
+			}
+
+			if severity > acc.stats.MaxSeverity {
+				acc.stats.MaxSeverity = severity
+			}
+
+			switch {
+			case severity < 0.4:
+				acc.stats.Severity.Low++
+			case severity < 0.7:
+				acc.stats.Severity.Medium++
+			default:
+				acc.stats.Severity.High++
+			}
+
+			if severity > maxSeverity {
+				maxSeverity = severity
+			}
+		}
+	}
+
+	for label, confidence := range safety.Summary {
+		acc := a.label(label)
+		acc.confidence += confidence
+		acc.summaries++
+
+		if !seen[label] {
+			seen[label] = true
+			acc.stats.Transcripts++
+		}
+	}
+
+	for label, summary := range safety.SeverityScoreSummary {
+		acc := a.label(label)
+		acc.scores.Low += ToFloat64(summary.Low)
+		acc.scores.Medium += ToFloat64(summary.Medium)
+		acc.scores.High += ToFloat64(summary.High)
+		acc.scored++
+	}
+
+	for label, relevance := range t.IABCategoriesResult.Summary {
+		acc, ok := a.topics[label]
+		if !ok {
+			acc = &topicAccumulator{stats: TopicStats{Label: label}}
+			a.topics[label] = acc
+		}
+
+		acc.stats.Transcripts++
+		acc.relevance += relevance
+
+		if relevance > acc.stats.MaxRelevance {
+			acc.stats.MaxRelevance = relevance
+		}
+	}
+
+	if th := a.opts.SeverityThreshold; th > 0 && maxSeverity >= th {
+		reasons = append(reasons, fmt.Sprintf("severity %.2f >= %.2f", maxSeverity, th))
+	}
+
+	if th := a.opts.RateThreshold; th > 0 && hours > 0 {
+		if rate := float64(sections) / hours; rate >= th {
+			reasons = append(reasons, fmt.Sprintf("%.1f sections per hour >= %.1f", rate, th))
+		}
+	}
+
+	for _, label := range sortedThresholdKeys(a.opts.LabelThresholds) {
+		th := a.opts.LabelThresholds[label]
+
+		if confidence, ok := safety.Summary[label]; ok && confidence >= th {
+			reasons = append(reasons, fmt.Sprintf("%s confidence %.2f >= %.2f", label, confidence, th))
+		}
+	}
+
+	for _, label := range sortedThresholdKeys(a.opts.TopicThresholds) {
+		th := a.opts.TopicThresholds[label]
+
+		if relevance, ok := t.IABCategoriesResult.Summary[label]; ok && relevance >= th {
+			reasons = append(reasons, fmt.Sprintf("%s relevance %.2f >= %.2f", label, relevance, th))
+		}
+	}
+
+	if len(reasons) > 0 {
+		a.flagged = append(a.flagged, FlaggedTranscript{
+			TranscriptID: ToString(t.ID),
+			Reasons:      reasons,
+		})
+	}
+}
+
+// AddAll adds transcripts from a channel until it's closed or the context is
+// canceled.
+func (a *ContentSafetyAggregator) AddAll(ctx context.Context, transcripts <-chan Transcript) error {
+	for {
+		select {
+		case t, ok := <-transcripts:
+			if !ok {
+				return nil
+			}
+			a.Add(t)
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// Report returns the statistics of the transcripts added so far.
+func (a *ContentSafetyAggregator) Report() ContentSafetyReport {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	report := ContentSafetyReport{
+		Transcripts:   a.transcripts,
+		AudioHours:    a.audioHours,
+		ContentSafety: []ContentSafetyStats{},
+		Topics:        []TopicStats{},
+		Flagged:       append([]FlaggedTranscript{}, a.flagged...),
+	}
+
+	for _, acc := range a.labels {
+		stats := acc.stats
+
+		if a.audioHours > 0 {
+			stats.RatePerHour = float64(stats.Sections) / a.audioHours
+		}
+
+		if acc.summaries > 0 {
+			stats.MeanConfidence = acc.confidence / float64(acc.summaries)
+		}
+
+		if acc.scored > 0 {
+			stats.SeverityScores = SeverityScores{
+				Low:    acc.scores.Low / float64(acc.scored),
+				Medium: acc.scores.Medium / float64(acc.scored),
+				High:   acc.scores.High / float64(acc.scored),
+			}
+		}
+
+		report.ContentSafety = append(report.ContentSafety, stats)
+	}
+
+	for _, acc := range a.topics {
+		stats := acc.stats
+		stats.MeanRelevance = acc.relevance / float64(stats.Transcripts)
+
+		report.Topics = append(report.Topics, stats)
+	}
+
+	sort.Slice(report.ContentSafety, func(i, j int) bool {
+		si, sj := report.ContentSafety[i], report.ContentSafety[j]
+		if si.Transcripts != sj.Transcripts {
+			return si.Transcripts > sj.Transcripts
+		}
+		if si.Sections != sj.Sections {
+			return si.Sections > sj.Sections
+		}
+		return si.Label < sj.Label
+	})
+
+	sort.Slice(report.Topics, func(i, j int) bool {
+		ti, tj := report.Topics[i], report.Topics[j]
+		if ti.Transcripts != tj.Transcripts {
+			return ti.Transcripts > tj.Transcripts
+		}
+		return ti.Label < tj.Label
+	})
+
+	return report
+}
+
+func (a *ContentSafetyAggregator) label(label string) *labelAccumulator {
+	acc, ok := a.labels[label]
+	if !ok {
+		acc = &labelAccumulator{stats: ContentSafetyStats{Label: label}}
+		a.labels[label] = acc
+	}
+	return acc
+}
+
+// WriteJSON writes the report as indented JSON.
+func (r ContentSafetyReport) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+// WriteCSV writes one row per content safety label and topic. The kind column
+// is either "content_safety" or "topic", and columns that don't apply to a kind
+// are left empty.
+func (r ContentSafetyReport) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+
+	_ = cw.Write([]string{
+		"kind", "label", "transcripts", "sections", "rate_per_hour",
+		"mean_confidence", "max_severity", "severity_low", "severity_medium",
+		"severity_high", "score_low", "score_medium", "score_high",
+		"mean_relevance", "max_relevance",
+	})
+
+	for _, s := range r.ContentSafety {
+		_ = cw.Write([]string{
+			"content_safety", s.Label, strconv.Itoa(s.Transcripts), strconv.Itoa(s.Sections),
+			formatReportFloat(s.RatePerHour), formatReportFloat(s.MeanConfidence), formatReportFloat(s.MaxSeverity),
+			strconv.Itoa(s.Severity.Low), strconv.Itoa(s.Severity.Medium), strconv.Itoa(s.Severity.High),
+			formatReportFloat(s.SeverityScores.Low), formatReportFloat(s.SeverityScores.Medium), formatReportFloat(s.SeverityScores.High),
+			"", "",
+		})
+	}
+
+	for _, t := range r.Topics {
+		_ = cw.Write([]string{
+			"topic", t.Label, strconv.Itoa(t.Transcripts), "", "", "", "", "", "", "", "", "", "",
+			formatReportFloat(t.MeanRelevance), formatReportFloat(t.MaxRelevance),
+		})
+	}
+
+	cw.Flush()
+
+	return cw.Error()
+}
+
+// WriteFlaggedCSV writes one row per flagged transcript, with its reasons
+// separated by semicolons.
+func (r ContentSafetyReport) WriteFlaggedCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+
+	_ = cw.Write([]string{"transcript_id", "reasons"})
+
+	for _, f := range r.Flagged {
+		_ = cw.Write([]string{f.TranscriptID, strings.Join(f.Reasons, "; ")})
+	}
+
+	cw.Flush()
+
+	return cw.Error()
+}
+
+func formatReportFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+func sortedThresholdKeys(m map[string]float64) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
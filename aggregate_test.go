@@ -0,0 +1,81 @@
+package assemblyai
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestContentSafetyAggregator(t *testing.T) {
+	t.Parallel()
+
+	agg := NewContentSafetyAggregator(&ContentSafetyAggregatorOptions{
+		SeverityThreshold: 0.9,
+		RateThreshold:     10,
+		TopicThresholds:   map[string]float64{"Sports": 0.5},
+	})
+
+	transcripts := make(chan Transcript, 3)
+
+	for _, name := range []string{"a", "b", "c"} {
+		transcripts <- readTranscript(t, "testdata/transcript/content-safety/"+name+".json")
+	}
+
+	close(transcripts)
+
+	require.NoError(t, agg.AddAll(context.Background(), transcripts))
+
+	report := agg.Report()
+
+	require.Equal(t, 3, report.Transcripts)
+	require.Equal(t, 2.0, report.AudioHours)
+
+	require.Len(t, report.ContentSafety, 2)
+
+	profanity := report.ContentSafety[0]
+	require.Equal(t, "profanity", profanity.Label)
+	require.Equal(t, 2, profanity.Transcripts)
+	require.Equal(t, 8, profanity.Sections)
+	require.Equal(t, 4.0, profanity.RatePerHour)
+	require.Equal(t, 0.8, profanity.MaxSeverity)
+	require.Equal(t, SeverityDistribution{Low: 6, Medium: 1, High: 1}, profanity.Severity)
+	require.InDelta(t, 0.8, profanity.MeanConfidence, 1e-9)
+	require.InDelta(t, 0.5, profanity.SeverityScores.Medium, 1e-9)
+
+	require.Equal(t, "violence", report.ContentSafety[1].Label)
+
+	require.Equal(t, []TopicStats{
+		{Label: "Sports", Transcripts: 2, MeanRelevance: 0.5, MaxRelevance: 0.75},
+		{Label: "News", Transcripts: 1, MeanRelevance: 0.4, MaxRelevance: 0.4},
+	}, report.Topics)
+
+	require.Equal(t, []FlaggedTranscript{
+		{TranscriptID: "A", Reasons: []string{"severity 0.95 >= 0.90"}},
+		{TranscriptID: "B", Reasons: []string{"12.0 sections per hour >= 10.0", "Sports relevance 0.75 >= 0.50"}},
+	}, report.Flagged)
+
+	var buf bytes.Buffer
+
+	require.NoError(t, report.WriteJSON(&buf))
+
+	var decoded ContentSafetyReport
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	require.Equal(t, report, decoded)
+
+	buf.Reset()
+	require.NoError(t, report.WriteCSV(&buf))
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 5)
+	require.Equal(t, []string{"content_safety", "profanity", "2", "8", "4", "0.8", "0.8", "6", "1", "1", "0.2", "0.5", "0.3", "", ""}, records[1])
+	require.Equal(t, []string{"topic", "News", "1", "", "", "", "", "", "", "", "", "", "", "0.4", "0.4"}, records[4])
+
+	buf.Reset()
+	require.NoError(t, report.WriteFlaggedCSV(&buf))
+	require.Equal(t, "transcript_id,reasons\nA,severity 0.95 >= 0.90\nB,12.0 sections per hour >= 10.0; Sports relevance 0.75 >= 0.50\n", buf.String())
+}
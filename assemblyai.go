@@ -26,6 +26,11 @@ type Client struct {
 
 	httpClient *http.Client
 
+	defaultParams    *TranscriptOptionalParams
+	skipValidation   bool
+	strictValidation bool
+	usageTracker     *LeMURUsageTracker
+
 	Transcripts *TranscriptService
 	LeMUR       *LeMURService
 	RealTime    *RealTimeService
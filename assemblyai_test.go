@@ -1,6 +1,7 @@
 package assemblyai
 
 import (
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"os"
@@ -33,3 +34,15 @@ func writeFileResponse(t *testing.T, w http.ResponseWriter, filename string) {
 	_, err = w.Write(b)
 	require.NoError(t, err)
 }
+
+func readTranscript(t *testing.T, filename string) Transcript {
+	t.Helper()
+
+	b, err := os.ReadFile(filename)
+	require.NoError(t, err)
+
+	var transcript Transcript
+	require.NoError(t, json.Unmarshal(b, &transcript))
+
+	return transcript
+}
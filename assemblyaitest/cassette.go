@@ -0,0 +1,371 @@
+package assemblyaitest
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sync"
+	"unicode/utf8"
+)
+
+// ErrUnmatchedRequest is returned by a [Cassette] in replay mode when a
+// request doesn't match any of the remaining recorded interactions.
+var ErrUnmatchedRequest = errors.New("no recorded interaction matches request")
+
+// CassetteMode controls whether a [Cassette] records or replays interactions.
+type CassetteMode int
+
+const (
+	// Serve recorded responses without sending any requests.
+	CassetteModeReplay CassetteMode = iota
+
+	// Send requests and record the interactions, replacing the cassette.
+	CassetteModeRecord
+
+	// Replay if the cassette file exists, and record it otherwise.
+	CassetteModeRecordOnce
+)
+
+// CassetteOptions configures a [Cassette].
+type CassetteOptions struct {
+	// Transport used to send requests in record mode. Defaults to
+	// [http.DefaultTransport].
+	Transport http.RoundTripper
+
+	// Request headers that are removed before recording, in addition to
+	// Authorization.
+	ScrubHeaders []string
+}
+
+// CassetteRequest is a recorded request.
+type CassetteRequest struct {
+	Method       string      `json:"method"`
+	URL          string      `json:"url"`
+	Header       http.Header `json:"header,omitempty"`
+	Body         string      `json:"body,omitempty"`
+	BodyEncoding string      `json:"body_encoding,omitempty"`
+}
+
+// CassetteResponse is a recorded response.
+type CassetteResponse struct {
+	StatusCode   int         `json:"status_code"`
+	Header       http.Header `json:"header,omitempty"`
+	Body         string      `json:"body,omitempty"`
+	BodyEncoding string      `json:"body_encoding,omitempty"`
+}
+
+// Interaction is a recorded request and its response. Cassette files contain
+// one JSON-encoded interaction per line.
+type Interaction struct {
+	Request  CassetteRequest  `json:"request"`
+	Response CassetteResponse `json:"response"`
+}
+
+// Cassette is an [http.RoundTripper] that records HTTP interactions to a file,
+// or replays them from it, so that tests against the real API can run offline:
+//
+//	cassette, err := assemblyaitest.NewCassette("testdata/transcribe.jsonl", assemblyaitest.CassetteModeRecordOnce, nil)
+//	if err != nil {
+//		t.Fatal(err)
+//	}
+//	defer cassette.Close()
+//
+//	client := assemblyai.NewClientWithOptions(
+//		assemblyai.WithAPIKey(os.Getenv("ASSEMBLYAI_API_KEY")),
+//		assemblyai.WithHTTPClient(cassette.Client()),
+//	)
+//
+// In replay mode, requests are matched against the recorded interactions by
+// method, path, query and body, ignoring the host. Identical requests, such as
+// the ones sent while polling a transcript, are served the recorded responses
+// in order. Requests that don't match any remaining interaction fail with an
+// error wrapping [ErrUnmatchedRequest].
+type Cassette struct {
+	mode  CassetteMode
+	opts  CassetteOptions
+	scrub map[string]bool
+
+	mu           sync.Mutex
+	file         *os.File
+	interactions []Interaction
+	played       []bool
+}
+
+// NewCassette opens a cassette file in the given mode. Call [Cassette.Close]
+// when done.
+func NewCassette(path string, mode CassetteMode, opts *CassetteOptions) (*Cassette, error) {
+	c := &Cassette{
+		mode:  mode,
+		scrub: map[string]bool{"Authorization": true},
+	}
+
+	if opts != nil {
+		c.opts = *opts
+	}
+
+	if c.opts.Transport == nil {
+		c.opts.Transport = http.DefaultTransport
+	}
+
+	for _, name := range c.opts.ScrubHeaders {
+		c.scrub[http.CanonicalHeaderKey(name)] = true
+	}
+
+	if c.mode == CassetteModeRecordOnce {
+		c.mode = CassetteModeReplay
+
+		if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+			c.mode = CassetteModeRecord
+		}
+	}
+
+	if c.mode == CassetteModeRecord {
+		f, err := os.Create(path)
+		if err != nil {
+			return nil, err
+		}
+
+		c.file = f
+
+		return c, nil
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	dec := json.NewDecoder(f)
+
+	for dec.More() {
+		var interaction Interaction
+
+		if err := dec.Decode(&interaction); err != nil {
+			return nil, fmt.Errorf("reading cassette %s: %w", path, err)
+		}
+
+		c.interactions = append(c.interactions, interaction)
+	}
+
+	c.played = make([]bool, len(c.interactions))
+
+	return c, nil
+}
+
+// Mode returns whether the cassette is recording or replaying.
+func (c *Cassette) Mode() CassetteMode {
+	return c.mode
+}
+
+// Client returns an HTTP client that uses the cassette, for use with
+// [assemblyai.WithHTTPClient].
+func (c *Cassette) Client() *http.Client {
+	return &http.Client{Transport: c}
+}
+
+// Close closes the cassette file.
+func (c *Cassette) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.file == nil {
+		return nil
+	}
+
+	err := c.file.Close()
+	c.file = nil
+
+	return err
+}
+
+// Unplayed returns the recorded interactions that haven't been replayed.
+func (c *Cassette) Unplayed() []Interaction {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var unplayed []Interaction
+
+	for i, played := range c.played {
+		if !played {
+			unplayed = append(unplayed, c.interactions[i])
+		}
+	}
+
+	return unplayed
+}
+
+// RoundTrip implements [http.RoundTripper].
+func (c *Cassette) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+
+	if req.Body != nil {
+		b, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		body = b
+	}
+
+	if c.mode == CassetteModeReplay {
+		return c.replay(req, body)
+	}
+
+	return c.record(req, body)
+}
+
+func (c *Cassette) record(req *http.Request, body []byte) (*http.Response, error) {
+	out := req.Clone(req.Context())
+	out.Body = io.NopCloser(bytes.NewReader(body))
+	out.ContentLength = int64(len(body))
+
+	resp, err := c.opts.Transport.RoundTrip(out)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	header := req.Header.Clone()
+	for name := range header {
+		if c.scrub[http.CanonicalHeaderKey(name)] {
+			header.Del(name)
+		}
+	}
+
+	interaction := Interaction{
+		Request: CassetteRequest{
+			Method: req.Method,
+			URL:    req.URL.String(),
+			Header: header,
+		},
+		Response: CassetteResponse{
+			StatusCode: resp.StatusCode,
+			Header:     resp.Header.Clone(),
+		},
+	}
+
+	interaction.Request.Body, interaction.Request.BodyEncoding = encodeBody(body)
+	interaction.Response.Body, interaction.Response.BodyEncoding = encodeBody(respBody)
+
+	line, err := json.Marshal(interaction)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.file == nil {
+		return nil, errors.New("cassette is closed")
+	}
+
+	if _, err := c.file.Write(append(line, '\n')); err != nil {
+		return nil, err
+	}
+
+	c.interactions = append(c.interactions, interaction)
+
+	return resp, nil
+}
+
+func (c *Cassette) replay(req *http.Request, body []byte) (*http.Response, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i, interaction := range c.interactions {
+		if c.played[i] || !matchRequest(interaction.Request, req, body) {
+			continue
+		}
+
+		respBody, err := decodeBody(interaction.Response.Body, interaction.Response.BodyEncoding)
+		if err != nil {
+			return nil, err
+		}
+
+		c.played[i] = true
+
+		status := interaction.Response.StatusCode
+
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+			StatusCode:    status,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        interaction.Response.Header.Clone(),
+			Body:          io.NopCloser(bytes.NewReader(respBody)),
+			ContentLength: int64(len(respBody)),
+			Request:       req,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("%w: %s %s", ErrUnmatchedRequest, req.Method, req.URL.RequestURI())
+}
+
+func matchRequest(recorded CassetteRequest, req *http.Request, body []byte) bool {
+	if recorded.Method != req.Method {
+		return false
+	}
+
+	u, err := url.Parse(recorded.URL)
+	if err != nil || u.Path != req.URL.Path || u.Query().Encode() != req.URL.Query().Encode() {
+		return false
+	}
+
+	recordedBody, err := decodeBody(recorded.Body, recorded.BodyEncoding)
+	if err != nil {
+		return false
+	}
+
+	return equalBodies(recordedBody, body)
+}
+
+// equalBodies compares JSON bodies ignoring insignificant whitespace, and
+// other bodies byte by byte.
+func equalBodies(a, b []byte) bool {
+	if bytes.Equal(a, b) {
+		return true
+	}
+
+	var ca, cb bytes.Buffer
+
+	if json.Compact(&ca, a) != nil || json.Compact(&cb, b) != nil {
+		return false
+	}
+
+	return bytes.Equal(ca.Bytes(), cb.Bytes())
+}
+
+// encodeBody stores text bodies as is, and other bodies as base64.
+func encodeBody(body []byte) (string, string) {
+	if utf8.Valid(body) {
+		return string(body), ""
+	}
+
+	return base64.StdEncoding.EncodeToString(body), "base64"
+}
+
+func decodeBody(body, encoding string) ([]byte, error) {
+	switch encoding {
+	case "":
+		return []byte(body), nil
+	case "base64":
+		return base64.StdEncoding.DecodeString(body)
+	}
+
+	return nil, fmt.Errorf("unknown body encoding %q", encoding)
+}
@@ -0,0 +1,78 @@
+package assemblyaitest
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/AssemblyAI/assemblyai-go-sdk"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCassette(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "cassette.jsonl")
+
+	srv := NewServer(&Options{APIKey: "secret-api-key"})
+
+	srv.SetTranscript("", assemblyai.Transcript{Text: assemblyai.String("Hello from the cassette.")})
+
+	// transcribe uploads audio, transcribes it and summarizes the transcript.
+	transcribe := func(cassette *Cassette) (assemblyai.Transcript, assemblyai.LeMURSummaryResponse) {
+		client := assemblyai.NewClientWithOptions(
+			assemblyai.WithBaseURL(srv.URL),
+			assemblyai.WithAPIKey("secret-api-key"),
+			assemblyai.WithHTTPClient(cassette.Client()),
+		)
+
+		ctx := context.Background()
+
+		transcript, err := client.Transcripts.TranscribeFromReader(ctx, bytes.NewReader([]byte{0xff, 0xfe, 0x00}), nil)
+		require.NoError(t, err)
+
+		summary, err := client.LeMUR.Summarize(ctx, assemblyai.LeMURSummaryParams{
+			LeMURBaseParams: assemblyai.LeMURBaseParams{TranscriptIDs: []string{assemblyai.ToString(transcript.ID)}},
+		})
+		require.NoError(t, err)
+
+		return transcript, summary
+	}
+
+	recorder, err := NewCassette(path, CassetteModeRecordOnce, nil)
+	require.NoError(t, err)
+	require.Equal(t, CassetteModeRecord, recorder.Mode())
+
+	transcript, summary := transcribe(recorder)
+	require.NoError(t, recorder.Close())
+
+	srv.Close()
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, 4, bytes.Count(data, []byte("\n")))
+	require.NotContains(t, string(data), "secret-api-key")
+	require.Contains(t, string(data), `"body_encoding":"base64"`)
+
+	player, err := NewCassette(path, CassetteModeRecordOnce, nil)
+	require.NoError(t, err)
+	require.Equal(t, CassetteModeReplay, player.Mode())
+	defer player.Close()
+
+	replayedTranscript, replayedSummary := transcribe(player)
+	require.Equal(t, transcript, replayedTranscript)
+	require.Equal(t, summary, replayedSummary)
+	require.Empty(t, player.Unplayed())
+
+	// Every interaction is only replayed once.
+	client := assemblyai.NewClientWithOptions(
+		assemblyai.WithBaseURL(srv.URL),
+		assemblyai.WithHTTPClient(player.Client()),
+	)
+
+	_, err = client.Transcripts.Get(context.Background(), assemblyai.ToString(transcript.ID))
+	require.True(t, errors.Is(err, ErrUnmatchedRequest))
+}
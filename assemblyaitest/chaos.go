@@ -0,0 +1,378 @@
+package assemblyaitest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/coder/websocket"
+)
+
+// FaultKind is a kind of failure injected by a [ChaosTransport].
+type FaultKind string
+
+const (
+	// The request is sent and the response is returned as is.
+	FaultNone FaultKind = ""
+
+	// The request isn't sent and a 429 response is returned.
+	FaultRateLimit FaultKind = "rate_limit"
+
+	// The request isn't sent and a 5xx response is returned.
+	FaultServerError FaultKind = "server_error"
+
+	// The request is sent and the body of the response is cut short.
+	FaultTruncate FaultKind = "truncate"
+
+	// The request isn't sent and the connection is reset.
+	FaultReset FaultKind = "reset"
+)
+
+// Fault is a failure injected into a request.
+type Fault struct {
+	// Delay before the request is handled.
+	Latency time.Duration
+
+	Kind FaultKind
+
+	// Status code of a server error. Defaults to a random choice of 500, 502,
+	// 503 and 504.
+	StatusCode int
+}
+
+// ChaosOptions configures a [ChaosTransport].
+type ChaosOptions struct {
+	// Seed of the random faults. Transports with the same seed inject the
+	// same faults into the same sequence of requests.
+	Seed int64
+
+	// Transport used to send requests. Defaults to [http.DefaultTransport].
+	Transport http.RoundTripper
+
+	// Probability that a request is delayed, and the maximum delay. Delays
+	// are uniformly distributed.
+	LatencyRate float64
+	MaxLatency  time.Duration
+
+	// Probabilities of each kind of failure per request. They must add up to
+	// at most 1.
+	RateLimitRate   float64
+	ServerErrorRate float64
+	TruncateRate    float64
+	ResetRate       float64
+
+	// Value of the Retry-After header of 429 and 503 responses. Defaults to
+	// one second.
+	RetryAfter time.Duration
+
+	// Faults to inject into specific requests instead of random ones, by the
+	// number of the request, starting at 1.
+	Script map[int]Fault
+
+	// Only inject faults into requests for which Match returns true. Other
+	// requests aren't counted. Defaults to all requests.
+	Match func(req *http.Request) bool
+}
+
+// InjectedFault is a fault injected by a [ChaosTransport].
+type InjectedFault struct {
+	// Number of the request, starting at 1.
+	Request int
+
+	Method string
+	Path   string
+
+	Fault
+}
+
+// ChaosTransport is an [http.RoundTripper] that injects latency and failures
+// into requests, to test how code copes with a slow or flaky API:
+//
+//	chaos := assemblyaitest.NewChaosTransport(&assemblyaitest.ChaosOptions{
+//		Seed:            42,
+//		ServerErrorRate: 0.2,
+//	})
+//
+//	client := assemblyai.NewClientWithOptions(assemblyai.WithHTTPClient(chaos.Client()))
+//
+// Faults are drawn from a random source seeded with [ChaosOptions.Seed], so a
+// failing test can be reproduced by sending the same requests in the same
+// order.
+type ChaosTransport struct {
+	opts ChaosOptions
+
+	mu       sync.Mutex
+	rng      *rand.Rand
+	requests int
+	injected []InjectedFault
+}
+
+// NewChaosTransport returns a transport that injects faults.
+func NewChaosTransport(opts *ChaosOptions) *ChaosTransport {
+	t := &ChaosTransport{}
+
+	if opts != nil {
+		t.opts = *opts
+	}
+
+	if t.opts.Transport == nil {
+		t.opts.Transport = http.DefaultTransport
+	}
+
+	if t.opts.RetryAfter == 0 {
+		t.opts.RetryAfter = time.Second
+	}
+
+	t.rng = rand.New(rand.NewSource(t.opts.Seed))
+
+	return t
+}
+
+// Client returns an HTTP client that uses the transport, for use with
+// [assemblyai.WithHTTPClient].
+func (t *ChaosTransport) Client() *http.Client {
+	return &http.Client{Transport: t}
+}
+
+// Injected returns the faults injected so far, in order. Requests without
+// faults are left out.
+func (t *ChaosTransport) Injected() []InjectedFault {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return append([]InjectedFault(nil), t.injected...)
+}
+
+// RoundTrip implements [http.RoundTripper].
+func (t *ChaosTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.opts.Match != nil && !t.opts.Match(req) {
+		return t.opts.Transport.RoundTrip(req)
+	}
+
+	fault, cut := t.next(req)
+
+	if fault.Latency > 0 {
+		timer := time.NewTimer(fault.Latency)
+
+		select {
+		case <-timer.C:
+		case <-req.Context().Done():
+			timer.Stop()
+			closeBody(req)
+			return nil, req.Context().Err()
+		}
+	}
+
+	switch fault.Kind {
+	case FaultRateLimit:
+		closeBody(req)
+		return t.errorResponse(req, http.StatusTooManyRequests, "Too many requests"), nil
+	case FaultServerError:
+		closeBody(req)
+		return t.errorResponse(req, fault.StatusCode, http.StatusText(fault.StatusCode)), nil
+	case FaultReset:
+		closeBody(req)
+		return nil, &net.OpError{Op: "read", Net: "tcp", Err: os.NewSyscallError("read", syscall.ECONNRESET)}
+	}
+
+	resp, err := t.opts.Transport.RoundTrip(req)
+	if err != nil || fault.Kind != FaultTruncate {
+		return resp, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	body = body[:int(cut*float64(len(body)))]
+
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	resp.ContentLength = int64(len(body))
+	resp.Header.Del("Content-Length")
+
+	return resp, nil
+}
+
+// next draws the fault for the next request, and the fraction of the body to
+// keep if it's truncated.
+func (t *ChaosTransport) next(req *http.Request) (Fault, float64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.requests++
+
+	// Always draw the same random values, so that the faults of a request
+	// don't depend on the faults of the previous ones.
+	latencyRoll, latency := t.rng.Float64(), t.rng.Float64()
+	faultRoll := t.rng.Float64()
+	status := []int{500, 502, 503, 504}[t.rng.Intn(4)]
+	cut := t.rng.Float64()
+
+	var fault Fault
+
+	if latencyRoll < t.opts.LatencyRate {
+		fault.Latency = time.Duration(latency * float64(t.opts.MaxLatency))
+	}
+
+	for _, f := range []struct {
+		kind FaultKind
+		rate float64
+	}{
+		{FaultRateLimit, t.opts.RateLimitRate},
+		{FaultServerError, t.opts.ServerErrorRate},
+		{FaultTruncate, t.opts.TruncateRate},
+		{FaultReset, t.opts.ResetRate},
+	} {
+		if faultRoll < f.rate {
+			fault.Kind = f.kind
+			break
+		}
+		faultRoll -= f.rate
+	}
+
+	if scripted, ok := t.opts.Script[t.requests]; ok {
+		fault = scripted
+	}
+
+	if fault.Kind == FaultServerError && fault.StatusCode == 0 {
+		fault.StatusCode = status
+	}
+
+	if fault != (Fault{}) {
+		t.injected = append(t.injected, InjectedFault{
+			Request: t.requests,
+			Method:  req.Method,
+			Path:    req.URL.Path,
+			Fault:   fault,
+		})
+	}
+
+	return fault, cut
+}
+
+func (t *ChaosTransport) errorResponse(req *http.Request, status int, message string) *http.Response {
+	body, _ := json.Marshal(errorResponse{Message: message})
+
+	header := make(http.Header)
+	header.Set("Content-Type", "application/json")
+
+	if status == http.StatusTooManyRequests || status == http.StatusServiceUnavailable {
+		header.Set("Retry-After", strconv.Itoa(int(t.opts.RetryAfter.Round(time.Second).Seconds())))
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
+
+// closeBody closes the body of a request that isn't sent, as required of a
+// RoundTripper.
+func closeBody(req *http.Request) {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+}
+
+// RealTimeFaults configures the faults that a [RealTimeServer] injects into
+// sessions. See [RealTimeServer.SetFaults].
+type RealTimeFaults struct {
+	// Seed of the random faults. Servers with the same seed inject the same
+	// faults into the same sequence of messages.
+	Seed int64
+
+	// Probability that the connection drops when the server receives an
+	// audio message.
+	DropRate float64
+
+	// Numbers of the audio messages of each session, starting at 1, after
+	// which the connection drops.
+	DropAt []int
+
+	// Close code and error message sent when the connection drops. If the
+	// code is zero, the connection is closed without a close message, as if
+	// the network failed.
+	CloseCode   websocket.StatusCode
+	CloseReason string
+
+	// Probability that a transcript is delayed, and the maximum delay. Delays
+	// are uniformly distributed.
+	LatencyRate float64
+	MaxLatency  time.Duration
+}
+
+// SetFaults makes the server inject faults into sessions, or stops injecting
+// them if faults is nil. Faults are drawn from a random source seeded with
+// [RealTimeFaults.Seed], so a failing test can be reproduced by sending the
+// same audio in the same order.
+func (s *RealTimeServer) SetFaults(faults *RealTimeFaults) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.faults = nil
+	s.faultRng = nil
+
+	if faults != nil {
+		f := *faults
+		s.faults = &f
+		s.faultRng = rand.New(rand.NewSource(f.Seed))
+	}
+}
+
+// dropFaultLocked returns how to close the connection of a session if it
+// drops after its last audio message, or nil if it doesn't.
+func (s *RealTimeServer) dropFaultLocked(session *realtimeSession) *closeRequest {
+	if s.faults == nil {
+		return nil
+	}
+
+	drop := s.faultRng.Float64() < s.faults.DropRate
+
+	for _, n := range s.faults.DropAt {
+		drop = drop || n == session.messages
+	}
+
+	if !drop {
+		return nil
+	}
+
+	session.info.Dropped = true
+
+	return &closeRequest{code: s.faults.CloseCode, reason: s.faults.CloseReason}
+}
+
+// delay returns how long to wait before sending the next transcript.
+func (s *RealTimeServer) delay() time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.faults == nil {
+		return 0
+	}
+
+	roll, latency := s.faultRng.Float64(), s.faultRng.Float64()
+
+	if roll >= s.faults.LatencyRate {
+		return 0
+	}
+
+	return time.Duration(latency * float64(s.faults.MaxLatency))
+}
@@ -0,0 +1,140 @@
+package assemblyaitest
+
+import (
+	"context"
+	"errors"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/AssemblyAI/assemblyai-go-sdk"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChaosTransport_Script(t *testing.T) {
+	t.Parallel()
+
+	srv := NewServer(nil)
+	defer srv.Close()
+
+	id := srv.AddTranscript(assemblyai.Transcript{})
+
+	chaos := NewChaosTransport(&ChaosOptions{
+		RetryAfter: 5 * time.Second,
+		Script: map[int]Fault{
+			1: {Kind: FaultRateLimit},
+			2: {Kind: FaultServerError, StatusCode: 503},
+			3: {Kind: FaultTruncate},
+			4: {Kind: FaultReset},
+			5: {Latency: 10 * time.Millisecond},
+		},
+	})
+
+	client := srv.Client(assemblyai.WithHTTPClient(chaos.Client()))
+
+	ctx := context.Background()
+
+	var apierr assemblyai.APIError
+
+	_, err := client.Transcripts.Get(ctx, id)
+	require.True(t, errors.As(err, &apierr))
+	require.Equal(t, 429, apierr.Status)
+	require.Equal(t, "5", apierr.Response.Header.Get("Retry-After"))
+
+	_, err = client.Transcripts.Get(ctx, id)
+	require.True(t, errors.As(err, &apierr))
+	require.Equal(t, 503, apierr.Status)
+	require.Equal(t, "5", apierr.Response.Header.Get("Retry-After"))
+
+	_, err = client.Transcripts.Get(ctx, id)
+	require.Error(t, err)
+
+	_, err = client.Transcripts.Get(ctx, id)
+	require.True(t, errors.Is(err, syscall.ECONNRESET))
+
+	transcript, err := client.Transcripts.Get(ctx, id)
+	require.NoError(t, err)
+	require.Equal(t, id, assemblyai.ToString(transcript.ID))
+
+	injected := chaos.Injected()
+	require.Len(t, injected, 5)
+	require.Equal(t, InjectedFault{Request: 5, Method: "GET", Path: "/v2/transcript/" + id, Fault: Fault{Latency: 10 * time.Millisecond}}, injected[4])
+
+	// Only the truncated request reached the server.
+	require.Len(t, srv.Requests(), 2)
+}
+
+func TestChaosTransport_Seed(t *testing.T) {
+	t.Parallel()
+
+	srv := NewServer(nil)
+	defer srv.Close()
+
+	id := srv.AddTranscript(assemblyai.Transcript{})
+
+	run := func(seed int64) []InjectedFault {
+		chaos := NewChaosTransport(&ChaosOptions{
+			Seed:            seed,
+			RateLimitRate:   0.2,
+			ServerErrorRate: 0.2,
+			TruncateRate:    0.2,
+			ResetRate:       0.2,
+		})
+
+		client := srv.Client(assemblyai.WithHTTPClient(chaos.Client()))
+
+		for i := 0; i < 20; i++ {
+			_, _ = client.Transcripts.Get(context.Background(), id)
+		}
+
+		return chaos.Injected()
+	}
+
+	injected := run(1)
+	require.NotEmpty(t, injected)
+	require.Less(t, len(injected), 20)
+	require.Equal(t, injected, run(1))
+	require.NotEqual(t, injected, run(2))
+}
+
+func TestRealTimeServer_Faults(t *testing.T) {
+	t.Parallel()
+
+	srv := NewRealTimeServer(&RealTimeOptions{
+		Utterances: []RealTimeUtterance{{Text: "Hello, world."}},
+	})
+	defer srv.Close()
+
+	srv.SetFaults(&RealTimeFaults{
+		DropAt:      []int{3},
+		CloseCode:   RealTimeStatusSessionExpired,
+		CloseReason: "Session expired",
+		LatencyRate: 1,
+		MaxLatency:  10 * time.Millisecond,
+	})
+
+	ev, transcriber := newRealtimeEvents()
+
+	client := srv.Client(assemblyai.WithRealTimeTranscriber(transcriber))
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	require.NoError(t, client.Connect(ctx))
+
+	require.NoError(t, client.Send(ctx, audio(400*time.Millisecond)))
+	require.Equal(t, "hello", ev.next(t).(assemblyai.PartialTranscript).Text)
+
+	require.NoError(t, client.Send(ctx, audio(400*time.Millisecond)))
+	require.Equal(t, "hello world", ev.next(t).(assemblyai.PartialTranscript).Text)
+
+	require.NoError(t, client.Send(ctx, audio(400*time.Millisecond)))
+
+	err := ev.next(t).(error)
+	require.Contains(t, err.Error(), "Session expired")
+
+	sessions := srv.Sessions()
+	require.Len(t, sessions, 1)
+	require.True(t, sessions[0].Closed)
+	require.True(t, sessions[0].Dropped)
+}
@@ -0,0 +1,221 @@
+package assemblyaitest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/AssemblyAI/assemblyai-go-sdk"
+)
+
+// LeMURRequest is a LeMUR request received by a [Server].
+type LeMURRequest struct {
+	// The endpoint that was called: task, summary, question-answer or
+	// action-items.
+	Endpoint string
+
+	// The prompt of a task.
+	Prompt string
+
+	// The question to answer. Question and answer requests call the handler
+	// once per question.
+	Question assemblyai.LeMURQuestion
+
+	// The answer format of a summary or action items request.
+	AnswerFormat string
+
+	Params assemblyai.LeMURBaseParams
+
+	// The transcripts that the request refers to.
+	Transcripts []assemblyai.Transcript
+}
+
+// LeMURHandler returns the response of a LeMUR request.
+type LeMURHandler func(req LeMURRequest) string
+
+// DefaultLeMURHandler answers questions with their first answer option, if
+// any, and responds to other requests with a fixed text.
+func DefaultLeMURHandler(req LeMURRequest) string {
+	if req.Endpoint == "question-answer" {
+		if len(req.Question.AnswerOptions) > 0 {
+			return req.Question.AnswerOptions[0]
+		}
+		return "Fake answer."
+	}
+
+	return fmt.Sprintf("Fake %s response.", req.Endpoint)
+}
+
+// SetLeMURHandler sets the handler that generates LeMUR responses. The handler
+// is called without holding any locks, so it may call methods of the server.
+func (s *Server) SetLeMURHandler(handler LeMURHandler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if handler == nil {
+		handler = DefaultLeMURHandler
+	}
+
+	s.lemur = handler
+}
+
+// generate handles a request to one of the LeMUR generate endpoints.
+func (s *Server) generate(endpoint string, body []byte) (int, interface{}) {
+	switch endpoint {
+	case "task", "summary", "question-answer", "action-items":
+	default:
+		return notFound()
+	}
+
+	var params struct {
+		assemblyai.LeMURBaseParams
+
+		Prompt       *string                    `json:"prompt"`
+		AnswerFormat *string                    `json:"answer_format"`
+		Questions    []assemblyai.LeMURQuestion `json:"questions"`
+	}
+
+	if err := json.Unmarshal(body, &params); err != nil {
+		return badRequest("Invalid request body: %s", err)
+	}
+
+	switch {
+	case len(params.TranscriptIDs) == 0 && params.InputText == nil:
+		return badRequest("Either transcript_ids or input_text must be provided")
+	case len(params.TranscriptIDs) > 0 && params.InputText != nil:
+		return badRequest("Only one of transcript_ids or input_text can be provided")
+	case endpoint == "task" && assemblyai.ToString(params.Prompt) == "":
+		return badRequest("prompt is required")
+	case endpoint == "question-answer" && len(params.Questions) == 0:
+		return badRequest("questions is required")
+	}
+
+	s.mu.Lock()
+
+	handler := s.lemur
+
+	req := LeMURRequest{
+		Endpoint:     endpoint,
+		Prompt:       assemblyai.ToString(params.Prompt),
+		AnswerFormat: assemblyai.ToString(params.AnswerFormat),
+		Params:       params.LeMURBaseParams,
+	}
+
+	input := []string{assemblyai.ToString(params.InputText), req.Prompt}
+
+	for _, id := range params.TranscriptIDs {
+		t := s.findLocked(id)
+		if t == nil || t.deleted {
+			s.mu.Unlock()
+			return badRequest("Transcript %s not found", id)
+		}
+
+		if t.status != assemblyai.TranscriptStatusCompleted {
+			s.mu.Unlock()
+			return badRequest("Transcript %s has a status of '%s'. Transcripts must have a status of 'completed' to be used with LeMUR.", id, t.status)
+		}
+
+		req.Transcripts = append(req.Transcripts, copyTranscript(t.result))
+		input = append(input, assemblyai.ToString(t.result.Text))
+	}
+
+	s.seq++
+
+	requestID := fmt.Sprintf("fake-lemur-%d", s.seq)
+
+	s.mu.Unlock()
+
+	var (
+		output   []string
+		response interface{}
+	)
+
+	base := assemblyai.LeMURBaseResponse{RequestID: assemblyai.String(requestID)}
+
+	if endpoint == "question-answer" {
+		var answers []assemblyai.LeMURQuestionAnswer
+
+		for _, q := range params.Questions {
+			req := req
+			req.Question = q
+
+			answer := handler(req)
+
+			input = append(input, assemblyai.ToString(q.Question))
+			output = append(output, answer)
+
+			answers = append(answers, assemblyai.LeMURQuestionAnswer{
+				Question: q.Question,
+				Answer:   assemblyai.String(answer),
+			})
+		}
+
+		response = &assemblyai.LeMURQuestionAnswerResponse{LeMURBaseResponse: base, Response: answers}
+	} else {
+		text := handler(req)
+
+		output = append(output, text)
+
+		switch endpoint {
+		case "task":
+			response = &assemblyai.LeMURTaskResponse{LeMURBaseResponse: base, Response: assemblyai.String(text)}
+		case "summary":
+			response = &assemblyai.LeMURSummaryResponse{LeMURBaseResponse: base, Response: assemblyai.String(text)}
+		case "action-items":
+			response = &assemblyai.LeMURActionItemsResponse{LeMURBaseResponse: base, Response: assemblyai.String(text)}
+		}
+	}
+
+	usage := assemblyai.LeMURUsage{
+		InputTokens:  assemblyai.Int64(assemblyai.EstimateLeMURTokens(strings.Join(input, ""))),
+		OutputTokens: assemblyai.Int64(assemblyai.EstimateLeMURTokens(strings.Join(output, ""))),
+	}
+
+	switch r := response.(type) {
+	case *assemblyai.LeMURTaskResponse:
+		r.Usage = usage
+	case *assemblyai.LeMURSummaryResponse:
+		r.Usage = usage
+	case *assemblyai.LeMURActionItemsResponse:
+		r.Usage = usage
+	case *assemblyai.LeMURQuestionAnswerResponse:
+		r.Usage = usage
+	}
+
+	b, err := json.Marshal(response)
+	if err != nil {
+		return http.StatusInternalServerError, errorResponse{Message: err.Error()}
+	}
+
+	s.mu.Lock()
+	s.lemurData[requestID] = b
+	s.mu.Unlock()
+
+	return http.StatusOK, json.RawMessage(b)
+}
+
+// lemurResponseLocked returns or purges a previously generated response.
+func (s *Server) lemurResponseLocked(method, requestID string) (int, interface{}) {
+	data, ok := s.lemurData[requestID]
+	if !ok {
+		return badRequest("LeMUR request %s not found", requestID)
+	}
+
+	switch method {
+	case "GET":
+		return http.StatusOK, data
+	case "DELETE":
+		delete(s.lemurData, requestID)
+
+		s.seq++
+
+		return http.StatusOK, assemblyai.PurgeLeMURRequestDataResponse{
+			Deleted:          assemblyai.Bool(true),
+			RequestID:        assemblyai.String(fmt.Sprintf("fake-lemur-purge-%d", s.seq)),
+			RequestIDToPurge: assemblyai.String(requestID),
+		}
+	}
+
+	return notFound()
+}
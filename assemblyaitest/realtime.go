@@ -0,0 +1,701 @@
+package assemblyaitest
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/AssemblyAI/assemblyai-go-sdk"
+	"github.com/coder/websocket"
+	"github.com/coder/websocket/wsjson"
+)
+
+// Close codes used by the real-time API.
+const (
+	RealTimeStatusBadSampleRate  websocket.StatusCode = 4000
+	RealTimeStatusNotAuthorized  websocket.StatusCode = 4001
+	RealTimeStatusSessionExpired websocket.StatusCode = 4008
+	RealTimeStatusSessionTimeout websocket.StatusCode = 4031
+	RealTimeStatusInvalidJSON    websocket.StatusCode = 4100
+	RealTimeStatusInvalidSchema  websocket.StatusCode = 4101
+)
+
+// DefaultEndUtteranceSilenceThreshold is how much audio must follow the last
+// word of an utterance before it's finalized, unless the client configures
+// another threshold.
+var DefaultEndUtteranceSilenceThreshold = 700 * time.Millisecond
+
+// DefaultRealTimeWordDuration is how long each word lasts when a
+// [RealTimeUtterance] doesn't have any words.
+var DefaultRealTimeWordDuration = 300 * time.Millisecond
+
+// RealTimeUtterance is a scripted utterance of a real-time session.
+type RealTimeUtterance struct {
+	// Punctuated and formatted text of the final transcript.
+	Text string
+
+	// Time in the audio stream when the utterance starts. Only used to
+	// generate the words from the text.
+	Start time.Duration
+
+	// Words of the utterance, with times relative to the start of the
+	// session. Generated from the text if empty, each lasting
+	// [DefaultRealTimeWordDuration].
+	Words []assemblyai.Word
+}
+
+// RealTimeOptions configures a [RealTimeServer].
+type RealTimeOptions struct {
+	// API key that sessions must be authorized with. Defaults to accepting
+	// any non-empty key.
+	APIKey string
+
+	// Temporary tokens that sessions can be authorized with. Defaults to
+	// accepting any non-empty token.
+	Tokens []string
+
+	// Utterances to transcribe in each session. See
+	// [RealTimeServer.SetUtterances].
+	Utterances []RealTimeUtterance
+}
+
+// RealTimeSession is a real-time session received by a [RealTimeServer].
+type RealTimeSession struct {
+	ID string
+
+	// Query parameters of the session.
+	SampleRate                int
+	Encoding                  assemblyai.RealTimeEncoding
+	WordBoost                 []string
+	Token                     string
+	DisablePartialTranscripts bool
+	ExtraSessionInformation   bool
+
+	// Audio received so far.
+	Audio []byte
+
+	// Last threshold set with end_utterance_silence_threshold, in
+	// milliseconds, or nil if it wasn't set.
+	EndUtteranceSilenceThreshold *int64
+
+	// Number of force_end_utterance messages received.
+	ForcedEndUtterances int
+
+	// Whether the client terminated the session.
+	Terminated bool
+
+	// Whether the connection is closed.
+	Closed bool
+
+	// Whether the server dropped the connection to inject a fault. See
+	// [RealTimeServer.SetFaults].
+	Dropped bool
+}
+
+// AudioDuration returns the duration of the audio received so far, or zero if
+// the sample rate isn't known.
+func (s RealTimeSession) AudioDuration() time.Duration {
+	if s.SampleRate <= 0 {
+		return 0
+	}
+
+	bytesPerSample := 2
+	if s.Encoding == assemblyai.RealTimeEncodingPCMMulaw {
+		bytesPerSample = 1
+	}
+
+	return time.Duration(len(s.Audio)) * time.Second / time.Duration(s.SampleRate*bytesPerSample)
+}
+
+type closeRequest struct {
+	code   websocket.StatusCode
+	reason string
+}
+
+type dropRule struct {
+	after time.Duration
+	closeRequest
+}
+
+// RealTimeServer is a fake of the real-time transcription API. It's safe for
+// concurrent use.
+//
+// As audio arrives, the server sends a partial transcript each time it has
+// received the end of another word of the current utterance, and a final
+// transcript once it has received the end of the last word followed by the
+// end of utterance silence threshold.
+type RealTimeServer struct {
+	// WebSocket URL of the server, for use with
+	// [assemblyai.WithRealTimeBaseURL].
+	URL string
+
+	opts RealTimeOptions
+	srv  *httptest.Server
+
+	mu         sync.Mutex
+	seq        int
+	utterances []RealTimeUtterance
+	sessions   []*realtimeSession
+	rejections []closeRequest
+	drop       *dropRule
+	faults     *RealTimeFaults
+	faultRng   *rand.Rand
+}
+
+type realtimeSession struct {
+	info RealTimeSession
+	conn *websocket.Conn
+
+	// Number of audio messages received.
+	messages int
+}
+
+// NewRealTimeServer starts a fake real-time server. Call
+// [RealTimeServer.Close] when done.
+func NewRealTimeServer(opts *RealTimeOptions) *RealTimeServer {
+	s := &RealTimeServer{}
+
+	if opts != nil {
+		s.opts = *opts
+	}
+
+	s.utterances = s.opts.Utterances
+
+	s.srv = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	s.URL = "ws" + strings.TrimPrefix(s.srv.URL, "http") + "/v2/realtime/ws"
+
+	return s
+}
+
+// Close disconnects all sessions and shuts down the server.
+func (s *RealTimeServer) Close() {
+	s.Disconnect(websocket.StatusGoingAway, "server shutting down")
+	s.srv.Close()
+}
+
+// Client returns a real-time client that's configured to use the server.
+// Additional options are applied after the base URL and API key.
+func (s *RealTimeServer) Client(opts ...assemblyai.RealTimeClientOption) *assemblyai.RealTimeClient {
+	key := s.opts.APIKey
+	if key == "" {
+		key = FakeAPIKey
+	}
+
+	options := []assemblyai.RealTimeClientOption{
+		assemblyai.WithRealTimeBaseURL(s.URL),
+		assemblyai.WithRealTimeAPIKey(key),
+	}
+
+	return assemblyai.NewRealTimeClientWithOptions(append(options, opts...)...)
+}
+
+// SetUtterances sets the utterances to transcribe in sessions that begin
+// afterwards.
+func (s *RealTimeServer) SetUtterances(utterances ...RealTimeUtterance) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.utterances = utterances
+}
+
+// Sessions returns the sessions received by the server, in order.
+func (s *RealTimeServer) Sessions() []RealTimeSession {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sessions := make([]RealTimeSession, len(s.sessions))
+
+	for i, session := range s.sessions {
+		sessions[i] = session.info
+		sessions[i].Audio = append([]byte(nil), session.info.Audio...)
+	}
+
+	return sessions
+}
+
+// RejectNext makes the next session fail to begin with the given close code
+// and error message.
+func (s *RealTimeServer) RejectNext(code websocket.StatusCode, message string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.rejections = append(s.rejections, closeRequest{code: code, reason: message})
+}
+
+// DropAfter makes sessions disconnect once they've received the given
+// duration of audio. If code is zero, the connection is closed without a close
+// message, as if the network failed. Otherwise the server sends an error
+// message and closes the connection with the given code.
+func (s *RealTimeServer) DropAfter(audio time.Duration, code websocket.StatusCode, message string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.drop = &dropRule{after: audio, closeRequest: closeRequest{code: code, reason: message}}
+}
+
+// Disconnect disconnects all open sessions. If code is zero, the connections
+// are closed without a close message, as if the network failed.
+func (s *RealTimeServer) Disconnect(code websocket.StatusCode, message string) {
+	s.mu.Lock()
+
+	var conns []*websocket.Conn
+
+	for _, session := range s.sessions {
+		if !session.info.Closed {
+			session.info.Closed = true
+			conns = append(conns, session.conn)
+		}
+	}
+
+	s.mu.Unlock()
+
+	for _, conn := range conns {
+		closeConn(context.Background(), conn, closeRequest{code: code, reason: message})
+	}
+}
+
+func (s *RealTimeServer) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	conn, err := websocket.Accept(w, r, nil)
+	if err != nil {
+		return
+	}
+	defer conn.CloseNow()
+
+	ctx := r.Context()
+
+	s.mu.Lock()
+
+	s.seq++
+
+	session := &realtimeSession{
+		info: RealTimeSession{ID: fmt.Sprintf("fake-session-%d", s.seq)},
+		conn: conn,
+	}
+
+	var rejection *closeRequest
+
+	if len(s.rejections) > 0 {
+		rejection = &s.rejections[0]
+		s.rejections = s.rejections[1:]
+	}
+
+	utterances := s.utterances
+
+	s.mu.Unlock()
+
+	if rejection == nil {
+		rejection = s.parseSession(r, &session.info)
+	}
+
+	if rejection != nil {
+		closeConn(ctx, conn, *rejection)
+		return
+	}
+
+	s.mu.Lock()
+	s.sessions = append(s.sessions, session)
+	s.mu.Unlock()
+
+	defer func() {
+		s.mu.Lock()
+		session.info.Closed = true
+		s.mu.Unlock()
+	}()
+
+	st := &stream{
+		conn:       conn,
+		utterances: realtimeUtterances(utterances),
+		threshold:  DefaultEndUtteranceSilenceThreshold,
+		partials:   !session.info.DisablePartialTranscripts,
+		delay:      s.delay,
+	}
+
+	err = wsjson.Write(ctx, conn, assemblyai.SessionBegins{
+		MessageType: string(assemblyai.MessageTypeSessionBegins),
+		SessionID:   session.info.ID,
+		ExpiresAt:   time.Now().UTC().Add(time.Hour).Format(realtimeCreatedLayout),
+	})
+	if err != nil {
+		return
+	}
+
+	for {
+		typ, data, err := conn.Read(ctx)
+		if err != nil {
+			return
+		}
+
+		if typ == websocket.MessageText {
+			var msg struct {
+				AudioData                    *string `json:"audio_data"`
+				TerminateSession             bool    `json:"terminate_session"`
+				ForceEndUtterance            bool    `json:"force_end_utterance"`
+				EndUtteranceSilenceThreshold *int64  `json:"end_utterance_silence_threshold"`
+			}
+
+			if err := json.Unmarshal(data, &msg); err != nil {
+				s.closeSession(ctx, session, closeRequest{code: RealTimeStatusInvalidJSON, reason: "Endpoint received invalid JSON"})
+				return
+			}
+
+			if msg.AudioData == nil {
+				if !s.control(ctx, session, st, msg.TerminateSession, msg.ForceEndUtterance, msg.EndUtteranceSilenceThreshold) {
+					return
+				}
+				continue
+			}
+
+			if data, err = base64.StdEncoding.DecodeString(*msg.AudioData); err != nil {
+				s.closeSession(ctx, session, closeRequest{code: RealTimeStatusInvalidSchema, reason: "Endpoint received a message with an invalid schema"})
+				return
+			}
+		}
+
+		if !s.audio(ctx, session, st, data) {
+			return
+		}
+	}
+}
+
+// parseSession validates the query parameters and credentials of a session.
+func (s *RealTimeServer) parseSession(r *http.Request, info *RealTimeSession) *closeRequest {
+	q := r.URL.Query()
+
+	token := q.Get("token")
+	key := r.Header.Get("Authorization")
+
+	var authorized bool
+
+	switch {
+	case token != "":
+		authorized = len(s.opts.Tokens) == 0
+
+		for _, t := range s.opts.Tokens {
+			authorized = authorized || t == token
+		}
+	case key != "":
+		authorized = s.opts.APIKey == "" || key == s.opts.APIKey
+	}
+
+	if !authorized {
+		return &closeRequest{code: RealTimeStatusNotAuthorized, reason: "Not authorized"}
+	}
+
+	info.Token = token
+	info.SampleRate = assemblyai.DefaultSampleRate
+	info.Encoding = assemblyai.RealTimeEncodingPCMS16LE
+
+	if v := q.Get("sample_rate"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			return &closeRequest{code: RealTimeStatusBadSampleRate, reason: "Sample rate must be a positive integer"}
+		}
+		info.SampleRate = n
+	}
+
+	switch encoding := assemblyai.RealTimeEncoding(q.Get("encoding")); encoding {
+	case "":
+	case assemblyai.RealTimeEncodingPCMS16LE, assemblyai.RealTimeEncodingPCMMulaw:
+		info.Encoding = encoding
+	default:
+		return &closeRequest{code: RealTimeStatusInvalidSchema, reason: fmt.Sprintf("Invalid encoding %q", encoding)}
+	}
+
+	if v := q.Get("word_boost"); v != "" {
+		if err := json.Unmarshal([]byte(v), &info.WordBoost); err != nil {
+			return &closeRequest{code: RealTimeStatusInvalidSchema, reason: "word_boost must be a JSON array of strings"}
+		}
+	}
+
+	info.DisablePartialTranscripts = q.Get("disable_partial_transcripts") == "true"
+	info.ExtraSessionInformation = q.Get("enable_extra_session_information") == "true"
+
+	return nil
+}
+
+// audio handles audio data, and returns false if the session ended.
+func (s *RealTimeServer) audio(ctx context.Context, session *realtimeSession, st *stream, data []byte) bool {
+	s.mu.Lock()
+
+	if session.info.Terminated {
+		s.mu.Unlock()
+		return true
+	}
+
+	session.info.Audio = append(session.info.Audio, data...)
+	session.messages++
+
+	position := session.info.AudioDuration()
+	drop := s.drop
+	fault := s.dropFaultLocked(session)
+
+	s.mu.Unlock()
+
+	if drop != nil && position >= drop.after {
+		s.closeSession(ctx, session, drop.closeRequest)
+		return false
+	}
+
+	if fault != nil {
+		s.closeSession(ctx, session, *fault)
+		return false
+	}
+
+	return st.advance(ctx, position) == nil
+}
+
+// control handles a control message, and returns false if the session ended.
+func (s *RealTimeServer) control(ctx context.Context, session *realtimeSession, st *stream, terminate, forceEnd bool, threshold *int64) bool {
+	s.mu.Lock()
+
+	if threshold != nil {
+		session.info.EndUtteranceSilenceThreshold = assemblyai.Int64(*threshold)
+	}
+
+	if forceEnd {
+		session.info.ForcedEndUtterances++
+	}
+
+	if terminate {
+		session.info.Terminated = true
+	}
+
+	info := session.info
+
+	s.mu.Unlock()
+
+	if threshold != nil {
+		if *threshold < 0 || *threshold > 20000 {
+			s.closeSession(ctx, session, closeRequest{code: RealTimeStatusInvalidSchema, reason: "end_utterance_silence_threshold must be between 0 and 20000"})
+			return false
+		}
+
+		st.threshold = time.Duration(*threshold) * time.Millisecond
+	}
+
+	if forceEnd {
+		if err := st.endUtterance(ctx); err != nil {
+			return false
+		}
+	}
+
+	if !terminate {
+		return true
+	}
+
+	if err := st.endUtterance(ctx); err != nil {
+		return false
+	}
+
+	if info.ExtraSessionInformation {
+		err := wsjson.Write(ctx, st.conn, assemblyai.SessionInformation{
+			RealTimeBaseMessage:  assemblyai.RealTimeBaseMessage{MessageType: assemblyai.MessageTypeSessionInformation},
+			AudioDurationSeconds: info.AudioDuration().Seconds(),
+		})
+		if err != nil {
+			return false
+		}
+	}
+
+	err := wsjson.Write(ctx, st.conn, assemblyai.SessionTerminated{
+		MessageType: assemblyai.MessageTypeSessionTerminated,
+	})
+
+	// Keep reading until the client closes the connection.
+	return err == nil
+}
+
+// Layout of the created field of real-time messages.
+const realtimeCreatedLayout = "2006-01-02T15:04:05.000000"
+
+// stream emits the transcripts of a session as audio arrives.
+type stream struct {
+	conn       *websocket.Conn
+	utterances []RealTimeUtterance
+	threshold  time.Duration
+	partials   bool
+
+	// delay returns how long to wait before sending a message.
+	delay func() time.Duration
+
+	// Index of the current utterance, and the number of its words that have
+	// been sent in partial transcripts.
+	next  int
+	heard int
+}
+
+// realtimeUtterances generates the words of utterances without any, and
+// leaves out utterances without words.
+func realtimeUtterances(utterances []RealTimeUtterance) []RealTimeUtterance {
+	var result []RealTimeUtterance
+
+	for _, u := range utterances {
+		if len(u.Words) == 0 {
+			start := u.Start
+
+			for _, text := range strings.Fields(u.Text) {
+				end := start + DefaultRealTimeWordDuration
+
+				u.Words = append(u.Words, assemblyai.Word{
+					Text:       text,
+					Start:      start.Milliseconds(),
+					End:        end.Milliseconds(),
+					Confidence: 1,
+				})
+
+				start = end
+			}
+		}
+
+		if len(u.Words) > 0 {
+			result = append(result, u)
+		}
+	}
+
+	return result
+}
+
+// advance sends the transcripts for the audio received up to position.
+func (st *stream) advance(ctx context.Context, position time.Duration) error {
+	for st.next < len(st.utterances) {
+		u := st.utterances[st.next]
+
+		heard := 0
+		for heard < len(u.Words) && time.Duration(u.Words[heard].End)*time.Millisecond <= position {
+			heard++
+		}
+
+		last := time.Duration(u.Words[len(u.Words)-1].End) * time.Millisecond
+
+		if heard == len(u.Words) && last+st.threshold <= position {
+			if err := st.final(ctx, u.Words, u.Text); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if heard > st.heard {
+			st.heard = heard
+
+			if st.partials {
+				return st.send(ctx, assemblyai.PartialTranscript{
+					MessageType:            assemblyai.MessageTypePartialTranscript,
+					RealTimeBaseTranscript: baseTranscript(u.Words[:heard], partialText(u.Words[:heard])),
+				})
+			}
+		}
+
+		return nil
+	}
+
+	return nil
+}
+
+// endUtterance finalizes the current utterance with the words received so
+// far. The rest of its words are skipped.
+func (st *stream) endUtterance(ctx context.Context) error {
+	if st.next >= len(st.utterances) || st.heard == 0 {
+		return nil
+	}
+
+	u := st.utterances[st.next]
+
+	if st.heard == len(u.Words) {
+		return st.final(ctx, u.Words, u.Text)
+	}
+
+	words := u.Words[:st.heard]
+
+	texts := make([]string, len(words))
+	for i, w := range words {
+		texts[i] = w.Text
+	}
+
+	return st.final(ctx, words, strings.Join(texts, " "))
+}
+
+func (st *stream) final(ctx context.Context, words []assemblyai.Word, text string) error {
+	st.next++
+	st.heard = 0
+
+	return st.send(ctx, assemblyai.FinalTranscript{
+		MessageType:            assemblyai.MessageTypeFinalTranscript,
+		RealTimeBaseTranscript: baseTranscript(words, text),
+		Punctuated:             true,
+		TextFormatted:          true,
+	})
+}
+
+func (st *stream) send(ctx context.Context, v interface{}) error {
+	if d := st.delay(); d > 0 {
+		timer := time.NewTimer(d)
+
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+	}
+
+	return wsjson.Write(ctx, st.conn, v)
+}
+
+func baseTranscript(words []assemblyai.Word, text string) assemblyai.RealTimeBaseTranscript {
+	var confidence float64
+	for _, w := range words {
+		confidence += w.Confidence
+	}
+
+	return assemblyai.RealTimeBaseTranscript{
+		AudioStart: words[0].Start,
+		AudioEnd:   words[len(words)-1].End,
+		Confidence: confidence / float64(len(words)),
+		Created:    time.Now().UTC().Format(realtimeCreatedLayout),
+		Text:       text,
+		Words:      append([]assemblyai.Word(nil), words...),
+	}
+}
+
+// partialText returns the text of words as it appears in partial transcripts,
+// which are neither punctuated nor cased.
+func partialText(words []assemblyai.Word) string {
+	texts := make([]string, len(words))
+	for i, w := range words {
+		texts[i] = normalizeWord(w.Text)
+	}
+	return strings.Join(texts, " ")
+}
+
+// closeSession marks a session as closed before closing its connection, so
+// that it's reported as closed by the time the client notices.
+func (s *RealTimeServer) closeSession(ctx context.Context, session *realtimeSession, req closeRequest) {
+	s.mu.Lock()
+	session.info.Closed = true
+	s.mu.Unlock()
+
+	closeConn(ctx, session.conn, req)
+}
+
+// closeConn closes a connection with an error message and close code, or
+// without a close message if the code is zero.
+func closeConn(ctx context.Context, conn *websocket.Conn, req closeRequest) {
+	if req.code == 0 {
+		conn.CloseNow()
+		return
+	}
+
+	if req.reason != "" {
+		_ = wsjson.Write(ctx, conn, assemblyai.RealTimeError{Error: req.reason})
+	}
+
+	conn.Close(req.code, req.reason)
+}
@@ -0,0 +1,221 @@
+package assemblyaitest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/AssemblyAI/assemblyai-go-sdk"
+	"github.com/coder/websocket"
+	"github.com/stretchr/testify/require"
+)
+
+const testTimeout = 5 * time.Second
+
+// audio returns silent 16 kHz PCM audio of the given duration.
+func audio(d time.Duration) []byte {
+	return make([]byte, int(d.Seconds()*16000)*2)
+}
+
+// realtimeEvents collects the events of a real-time client in the order they
+// were received.
+type realtimeEvents chan interface{}
+
+func newRealtimeEvents() (realtimeEvents, *assemblyai.RealTimeTranscriber) {
+	ev := make(realtimeEvents, 16)
+
+	return ev, &assemblyai.RealTimeTranscriber{
+		OnPartialTranscript:  func(e assemblyai.PartialTranscript) { ev <- e },
+		OnFinalTranscript:    func(e assemblyai.FinalTranscript) { ev <- e },
+		OnSessionInformation: func(e assemblyai.SessionInformation) { ev <- e },
+		OnError:              func(err error) { ev <- err },
+	}
+}
+
+func (ev realtimeEvents) next(t *testing.T) interface{} {
+	t.Helper()
+
+	select {
+	case v := <-ev:
+		return v
+	case <-time.After(testTimeout):
+		t.Fatal("timed out waiting for event")
+		return nil
+	}
+}
+
+func TestRealTimeServer_Transcripts(t *testing.T) {
+	t.Parallel()
+
+	srv := NewRealTimeServer(&RealTimeOptions{
+		Utterances: []RealTimeUtterance{
+			{Text: "Hello, world."},
+			{Text: "How are you?", Start: 2 * time.Second},
+		},
+	})
+	defer srv.Close()
+
+	ev, transcriber := newRealtimeEvents()
+
+	client := srv.Client(
+		assemblyai.WithRealTimeTranscriber(transcriber),
+		assemblyai.WithRealTimeSampleRate(16000),
+		assemblyai.WithRealTimeWordBoost([]string{"world"}),
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	require.NoError(t, client.Connect(ctx))
+
+	// Not enough audio for the first word.
+	require.NoError(t, client.Send(ctx, audio(200*time.Millisecond)))
+	require.NoError(t, client.Send(ctx, audio(200*time.Millisecond)))
+
+	partial := ev.next(t).(assemblyai.PartialTranscript)
+	require.Equal(t, "hello", partial.Text)
+	require.Equal(t, int64(0), partial.AudioStart)
+	require.Equal(t, int64(300), partial.AudioEnd)
+
+	require.NoError(t, client.Send(ctx, audio(300*time.Millisecond)))
+	require.Equal(t, "hello world", ev.next(t).(assemblyai.PartialTranscript).Text)
+
+	// The utterance ends once the silence threshold has passed.
+	require.NoError(t, client.Send(ctx, audio(700*time.Millisecond)))
+
+	final := ev.next(t).(assemblyai.FinalTranscript)
+	require.Equal(t, "Hello, world.", final.Text)
+	require.True(t, final.Punctuated)
+	require.Len(t, final.Words, 2)
+
+	// Force the end of the second utterance after its first word.
+	require.NoError(t, client.Send(ctx, audio(900*time.Millisecond)))
+	require.Equal(t, "how", ev.next(t).(assemblyai.PartialTranscript).Text)
+
+	require.NoError(t, client.ForceEndUtterance(ctx))
+	require.Equal(t, "How", ev.next(t).(assemblyai.FinalTranscript).Text)
+
+	require.NoError(t, client.Disconnect(ctx, true))
+
+	info := ev.next(t).(assemblyai.SessionInformation)
+	require.Equal(t, 2.3, info.AudioDurationSeconds)
+
+	sessions := srv.Sessions()
+	require.Len(t, sessions, 1)
+	require.Equal(t, 16000, sessions[0].SampleRate)
+	require.Equal(t, assemblyai.RealTimeEncodingPCMS16LE, sessions[0].Encoding)
+	require.Equal(t, []string{"world"}, sessions[0].WordBoost)
+	require.Equal(t, 2300*time.Millisecond, sessions[0].AudioDuration())
+	require.Equal(t, 1, sessions[0].ForcedEndUtterances)
+	require.True(t, sessions[0].Terminated)
+}
+
+func TestRealTimeServer_EndUtteranceSilenceThreshold(t *testing.T) {
+	t.Parallel()
+
+	srv := NewRealTimeServer(nil)
+	defer srv.Close()
+
+	srv.SetUtterances(RealTimeUtterance{Text: "Hi."})
+
+	ev, transcriber := newRealtimeEvents()
+	transcriber.OnPartialTranscript = nil
+
+	client := srv.Client(assemblyai.WithRealTimeTranscriber(transcriber))
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	require.NoError(t, client.Connect(ctx))
+	require.NoError(t, client.SetEndUtteranceSilenceThreshold(ctx, 100))
+	require.NoError(t, client.Send(ctx, audio(400*time.Millisecond)))
+
+	require.Equal(t, "Hi.", ev.next(t).(assemblyai.FinalTranscript).Text)
+
+	require.NoError(t, client.Disconnect(ctx, true))
+
+	sessions := srv.Sessions()
+	require.True(t, sessions[0].DisablePartialTranscripts)
+	require.Equal(t, int64(100), assemblyai.ToInt64(sessions[0].EndUtteranceSilenceThreshold))
+}
+
+func TestRealTimeServer_Errors(t *testing.T) {
+	t.Parallel()
+
+	srv := NewRealTimeServer(&RealTimeOptions{Tokens: []string{"temp-token"}})
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	_, transcriber := newRealtimeEvents()
+
+	err := srv.Client(assemblyai.WithRealTimeTranscriber(transcriber), assemblyai.WithRealTimeEncoding("opus")).Connect(ctx)
+	require.EqualError(t, err, `Invalid encoding "opus"`)
+
+	err = srv.Client(assemblyai.WithRealTimeTranscriber(transcriber), assemblyai.WithRealTimeAuthToken("wrong")).Connect(ctx)
+	require.EqualError(t, err, "Not authorized")
+
+	srv.RejectNext(RealTimeStatusSessionExpired, "Session Expired")
+
+	err = srv.Client(assemblyai.WithRealTimeTranscriber(transcriber)).Connect(ctx)
+	require.EqualError(t, err, "Session Expired")
+
+	require.Empty(t, srv.Sessions())
+
+	srv.DropAfter(time.Second, RealTimeStatusSessionTimeout, "Session idle for too long")
+
+	ev, transcriber := newRealtimeEvents()
+
+	client := srv.Client(assemblyai.WithRealTimeTranscriber(transcriber), assemblyai.WithRealTimeAuthToken("temp-token"))
+	require.NoError(t, client.Connect(ctx))
+
+	require.NoError(t, client.Send(ctx, audio(500*time.Millisecond)))
+	require.NoError(t, client.Send(ctx, audio(500*time.Millisecond)))
+
+	err = ev.next(t).(error)
+	require.Equal(t, RealTimeStatusSessionTimeout, websocket.CloseStatus(err))
+
+	sessions := srv.Sessions()
+	require.Len(t, sessions, 1)
+	require.Equal(t, "temp-token", sessions[0].Token)
+	require.True(t, sessions[0].Closed)
+	require.False(t, sessions[0].Terminated)
+}
+
+func TestRealTimeServer_Disconnect(t *testing.T) {
+	t.Parallel()
+
+	srv := NewRealTimeServer(nil)
+	defer srv.Close()
+
+	ev, transcriber := newRealtimeEvents()
+
+	client := srv.Client(assemblyai.WithRealTimeTranscriber(transcriber))
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	require.NoError(t, client.Connect(ctx))
+
+	srv.Disconnect(0, "")
+
+	err := ev.next(t).(error)
+	require.Error(t, err)
+
+	var closeErr websocket.CloseError
+	require.False(t, errors.As(err, &closeErr))
+}
+
+func TestRealTimeSession_AudioDuration(t *testing.T) {
+	t.Parallel()
+
+	session := RealTimeSession{SampleRate: 8000, Audio: make([]byte, 16000)}
+	require.Equal(t, time.Second, session.AudioDuration())
+
+	session.Encoding = assemblyai.RealTimeEncodingPCMMulaw
+	require.Equal(t, 2*time.Second, session.AudioDuration())
+
+	require.Zero(t, RealTimeSession{Audio: make([]byte, 16000)}.AudioDuration())
+}
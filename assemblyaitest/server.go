@@ -0,0 +1,461 @@
+// Package assemblyaitest provides an in-memory fake of the AssemblyAI API for
+// testing code that uses the SDK.
+//
+// A [Server] accepts uploads, simulates the queued, processing and completed
+// states of a transcript on a clock that the test controls, serves scripted
+// transcripts, errors and LeMUR responses, fires webhooks, and records every
+// request it receives:
+//
+//	srv := assemblyaitest.NewServer(nil)
+//	defer srv.Close()
+//
+//	srv.SetTranscript("https://example.org/audio.mp3", assemblyai.Transcript{
+//		Text: assemblyai.String("Hello world."),
+//	})
+//
+//	client := srv.Client()
+//
+//	transcript, err := client.Transcripts.TranscribeFromURL(ctx, "https://example.org/audio.mp3", nil)
+//
+// A [RealTimeServer] does the same for real-time transcription, emitting
+// scripted transcripts as audio is streamed to it.
+//
+// A [Cassette] records and replays interactions with the real API, and a
+// [ChaosTransport] injects latency and failures into requests to any of them.
+package assemblyaitest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/AssemblyAI/assemblyai-go-sdk"
+)
+
+// FakeAPIKey is the API key used by [Server.Client].
+const FakeAPIKey = "fake-api-key"
+
+// Options configures a [Server].
+type Options struct {
+	// How long a new transcript stays queued, and how long it's processed
+	// after that, according to the server's clock. When both are zero,
+	// transcripts complete as soon as they're created.
+	QueuedDuration     time.Duration
+	ProcessingDuration time.Duration
+
+	// Initial time of the server's clock. Defaults to midnight UTC on
+	// January 1, 2024.
+	Now time.Time
+
+	// API key that requests must be authorized with. Defaults to accepting
+	// any non-empty key.
+	APIKey string
+
+	// HTTP client used to send webhooks. Defaults to [http.DefaultClient].
+	WebhookClient *http.Client
+}
+
+// Request is a request received by a [Server].
+type Request struct {
+	Method string
+	Path   string
+	Query  url.Values
+	Header http.Header
+	Body   []byte
+}
+
+// DecodeJSON decodes the body of the request into v.
+func (r Request) DecodeJSON(v interface{}) error {
+	return json.Unmarshal(r.Body, v)
+}
+
+// Webhook is a webhook sent by a [Server] when a transcript completes or fails.
+type Webhook struct {
+	URL          string
+	Header       http.Header
+	Notification assemblyai.TranscriptReadyNotification
+
+	// Status code returned by the webhook receiver, or zero if the request
+	// failed.
+	StatusCode int
+
+	// Error sending the request, if any.
+	Err error
+}
+
+type failure struct {
+	method  string
+	path    string
+	status  int
+	message string
+}
+
+// Server is a fake AssemblyAI API. It's safe for concurrent use.
+type Server struct {
+	// Base URL of the server, for use with [assemblyai.WithBaseURL].
+	URL string
+
+	opts Options
+	srv  *httptest.Server
+
+	mu          sync.Mutex
+	now         time.Time
+	seq         int
+	uploads     map[string][]byte
+	scripts     map[string]script
+	transcripts []*transcript
+	failures    []failure
+	requests    []Request
+	webhooks    []Webhook
+	lemur       LeMURHandler
+	lemurData   map[string]json.RawMessage
+
+	// Closed once the last batch of webhooks has been delivered. Batches are
+	// delivered in order, each after the previous one.
+	delivered chan struct{}
+}
+
+// NewServer starts a fake API server. Call [Server.Close] when done.
+func NewServer(opts *Options) *Server {
+	s := &Server{
+		uploads:   make(map[string][]byte),
+		scripts:   make(map[string]script),
+		lemur:     DefaultLeMURHandler,
+		lemurData: make(map[string]json.RawMessage),
+	}
+
+	if opts != nil {
+		s.opts = *opts
+	}
+
+	if s.opts.Now.IsZero() {
+		s.opts.Now = time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	}
+
+	if s.opts.WebhookClient == nil {
+		s.opts.WebhookClient = http.DefaultClient
+	}
+
+	s.now = s.opts.Now
+
+	s.srv = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	s.URL = s.srv.URL
+
+	return s
+}
+
+// Close waits for webhooks in flight to be delivered and shuts down the
+// server.
+func (s *Server) Close() {
+	s.WaitWebhooks()
+	s.srv.Close()
+}
+
+// Client returns a client that's configured to use the server. Additional
+// options are applied after the base URL and API key.
+func (s *Server) Client(opts ...assemblyai.ClientOption) *assemblyai.Client {
+	key := s.opts.APIKey
+	if key == "" {
+		key = FakeAPIKey
+	}
+
+	options := []assemblyai.ClientOption{
+		assemblyai.WithBaseURL(s.URL),
+		assemblyai.WithAPIKey(key),
+	}
+
+	return assemblyai.NewClientWithOptions(append(options, opts...)...)
+}
+
+// Now returns the current time of the server's clock.
+func (s *Server) Now() time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.now
+}
+
+// Advance moves the server's clock forward. Transcripts that complete or fail
+// as a result send their webhooks before Advance returns.
+func (s *Server) Advance(d time.Duration) {
+	s.mu.Lock()
+	s.now = s.now.Add(d)
+	pending := s.settleLocked()
+	s.mu.Unlock()
+
+	s.sendWebhooks(pending)
+	s.WaitWebhooks()
+}
+
+// FailNext makes the next request with the given method and path fail with
+// the given status code and error message. Failures are used in the order
+// they were added.
+func (s *Server) FailNext(method, path string, status int, message string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.failures = append(s.failures, failure{method: method, path: path, status: status, message: message})
+}
+
+// Upload returns the data uploaded to the given URL.
+func (s *Server) Upload(uploadURL string) ([]byte, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, ok := s.uploads[uploadURL]
+	return data, ok
+}
+
+// Requests returns the requests received by the server, in order.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]Request(nil), s.requests...)
+}
+
+// Webhooks returns the webhooks sent by the server, in order. Webhooks of
+// transcripts that finish while handling a request are delivered in the
+// background, so call [Server.WaitWebhooks] first to include them.
+func (s *Server) Webhooks() []Webhook {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]Webhook(nil), s.webhooks...)
+}
+
+// WaitWebhooks waits until the webhooks sent so far have been delivered.
+func (s *Server) WaitWebhooks() {
+	s.mu.Lock()
+	delivered := s.delivered
+	s.mu.Unlock()
+
+	if delivered != nil {
+		<-delivered
+	}
+}
+
+// Reset forgets the received requests and sent webhooks.
+func (s *Server) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests = nil
+	s.webhooks = nil
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	s.mu.Lock()
+
+	s.requests = append(s.requests, Request{
+		Method: r.Method,
+		Path:   r.URL.Path,
+		Query:  r.URL.Query(),
+		Header: r.Header.Clone(),
+		Body:   body,
+	})
+
+	if f, ok := s.takeFailureLocked(r.Method, r.URL.Path); ok {
+		s.mu.Unlock()
+		writeError(w, f.status, f.message)
+		return
+	}
+
+	key := r.Header.Get("Authorization")
+
+	if key == "" || (s.opts.APIKey != "" && key != s.opts.APIKey) {
+		s.mu.Unlock()
+		writeError(w, http.StatusUnauthorized, "Authentication error, API token missing/invalid")
+		return
+	}
+
+	pending := s.settleLocked()
+
+	s.mu.Unlock()
+
+	s.sendWebhooks(pending)
+
+	status, v := s.route(r.Method, r.URL, body)
+
+	switch v := v.(type) {
+	case []byte:
+		w.Header().Set("Content-Type", "text/plain")
+		w.WriteHeader(status)
+		w.Write(v)
+	default:
+		writeJSON(w, status, v)
+	}
+}
+
+func (s *Server) takeFailureLocked(method, path string) (failure, bool) {
+	for i, f := range s.failures {
+		if f.method == method && f.path == path {
+			s.failures = append(s.failures[:i], s.failures[i+1:]...)
+			return f, true
+		}
+	}
+
+	return failure{}, false
+}
+
+// route handles an authorized request and returns the status code and the
+// value to respond with. Webhooks of transcripts that finish in the meantime
+// are sent in the background, like the API does, so a webhook receiver may
+// call the server without blocking the response.
+func (s *Server) route(method string, u *url.URL, body []byte) (int, interface{}) {
+	if method == "POST" && strings.HasPrefix(u.Path, "/lemur/v3/generate/") {
+		return s.generate(strings.TrimPrefix(u.Path, "/lemur/v3/generate/"), body)
+	}
+
+	s.mu.Lock()
+	status, v := s.routeLocked(method, u, body)
+	pending := s.settleLocked()
+	s.mu.Unlock()
+
+	s.sendWebhooks(pending)
+
+	return status, v
+}
+
+// routeLocked handles a request and returns the status code and the value to
+// respond with.
+func (s *Server) routeLocked(method string, u *url.URL, body []byte) (int, interface{}) {
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+
+	switch {
+	case method == "POST" && u.Path == "/v2/upload":
+		return s.uploadLocked(body)
+	case method == "POST" && u.Path == "/v2/realtime/token":
+		return s.realtimeTokenLocked()
+	case u.Path == "/v2/transcript":
+		switch method {
+		case "POST":
+			return s.createTranscriptLocked(body)
+		case "GET":
+			return s.listTranscriptsLocked(u)
+		}
+	case len(parts) >= 3 && parts[0] == "v2" && parts[1] == "transcript":
+		return s.transcriptLocked(method, parts[2], parts[3:], u.Query())
+	case len(parts) == 3 && parts[0] == "lemur":
+		return s.lemurResponseLocked(method, parts[2])
+	}
+
+	return notFound()
+}
+
+func (s *Server) uploadLocked(data []byte) (int, interface{}) {
+	s.seq++
+
+	uploadURL := fmt.Sprintf("https://cdn.assemblyai.com/upload/fake-%d", s.seq)
+
+	s.uploads[uploadURL] = data
+
+	return http.StatusOK, assemblyai.UploadedFile{UploadURL: assemblyai.String(uploadURL)}
+}
+
+func (s *Server) realtimeTokenLocked() (int, interface{}) {
+	s.seq++
+
+	return http.StatusOK, assemblyai.RealtimeTemporaryTokenResponse{
+		Token: assemblyai.String(fmt.Sprintf("fake-realtime-token-%d", s.seq)),
+	}
+}
+
+// sendWebhooks delivers webhooks in the background, after any earlier ones.
+func (s *Server) sendWebhooks(webhooks []Webhook) {
+	if len(webhooks) == 0 {
+		return
+	}
+
+	done := make(chan struct{})
+
+	s.mu.Lock()
+	prev := s.delivered
+	s.delivered = done
+	s.mu.Unlock()
+
+	go func() {
+		defer close(done)
+
+		if prev != nil {
+			<-prev
+		}
+
+		s.deliverWebhooks(webhooks)
+	}()
+}
+
+func (s *Server) deliverWebhooks(webhooks []Webhook) {
+	for _, wh := range webhooks {
+		b, err := json.Marshal(wh.Notification)
+		if err != nil {
+			wh.Err = err
+		} else {
+			wh.StatusCode, wh.Err = s.postWebhook(wh, b)
+		}
+
+		s.mu.Lock()
+
+		s.webhooks = append(s.webhooks, wh)
+
+		if t := s.findLocked(assemblyai.ToString(wh.Notification.TranscriptID)); t != nil && wh.StatusCode != 0 {
+			t.webhookStatusCode = int64(wh.StatusCode)
+		}
+
+		s.mu.Unlock()
+	}
+}
+
+func (s *Server) postWebhook(wh Webhook, body []byte) (int, error) {
+	req, err := http.NewRequest("POST", wh.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+
+	req.Header = wh.Header.Clone()
+
+	resp, err := s.opts.WebhookClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	return resp.StatusCode, nil
+}
+
+type errorResponse struct {
+	Message string `json:"error"`
+}
+
+func notFound() (int, interface{}) {
+	return http.StatusNotFound, errorResponse{Message: "Not found"}
+}
+
+func badRequest(format string, args ...interface{}) (int, interface{}) {
+	return http.StatusBadRequest, errorResponse{Message: fmt.Sprintf(format, args...)}
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, errorResponse{Message: message})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
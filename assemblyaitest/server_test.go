@@ -0,0 +1,459 @@
+package assemblyaitest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/AssemblyAI/assemblyai-go-sdk"
+	"github.com/stretchr/testify/require"
+)
+
+const fakeAudioURL = "https://example.org/audio.mp3"
+
+func TestServer_TranscribeFromURL(t *testing.T) {
+	t.Parallel()
+
+	srv := NewServer(nil)
+	defer srv.Close()
+
+	srv.SetTranscript(fakeAudioURL, assemblyai.Transcript{
+		Text:    assemblyai.String("Smoke from wildfires in Canada. Air quality alerts are in effect."),
+		Summary: assemblyai.String("Wildfire smoke."),
+	})
+
+	client := srv.Client()
+
+	transcript, err := client.Transcripts.TranscribeFromURL(context.Background(), fakeAudioURL, &assemblyai.TranscriptOptionalParams{
+		SpeakerLabels: assemblyai.Bool(true),
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, assemblyai.TranscriptStatusCompleted, transcript.Status)
+	require.Equal(t, fakeAudioURL, assemblyai.ToString(transcript.AudioURL))
+	require.Equal(t, "Wildfire smoke.", assemblyai.ToString(transcript.Summary))
+	require.True(t, assemblyai.ToBool(transcript.SpeakerLabels))
+	require.Len(t, transcript.Words, 11)
+	require.Equal(t, 5.5, assemblyai.ToFloat64(transcript.AudioDuration))
+
+	requests := srv.Requests()
+	require.Len(t, requests, 2)
+	require.Equal(t, "POST", requests[0].Method)
+	require.Equal(t, "/v2/transcript", requests[0].Path)
+	require.Equal(t, FakeAPIKey, requests[0].Header.Get("Authorization"))
+
+	var params assemblyai.TranscriptParams
+	require.NoError(t, requests[0].DecodeJSON(&params))
+	require.True(t, assemblyai.ToBool(params.SpeakerLabels))
+
+	require.Equal(t, "/v2/transcript/"+assemblyai.ToString(transcript.ID), requests[1].Path)
+
+	// Audio that isn't scripted gets the default text.
+	transcript, err = client.Transcripts.TranscribeFromURL(context.Background(), "https://example.org/other.mp3", nil)
+	require.NoError(t, err)
+	require.Equal(t, DefaultText, assemblyai.ToString(transcript.Text))
+}
+
+func TestServer_Lifecycle(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu       sync.Mutex
+		received []assemblyai.TranscriptReadyNotification
+	)
+
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "secret", r.Header.Get("X-Webhook-Secret"))
+
+		var n assemblyai.TranscriptReadyNotification
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&n))
+
+		mu.Lock()
+		received = append(received, n)
+		mu.Unlock()
+	}))
+	defer receiver.Close()
+
+	srv := NewServer(&Options{
+		QueuedDuration:     10 * time.Second,
+		ProcessingDuration: 20 * time.Second,
+	})
+	defer srv.Close()
+
+	client := srv.Client()
+	ctx := context.Background()
+
+	transcript, err := client.Transcripts.SubmitFromURL(ctx, fakeAudioURL, &assemblyai.TranscriptOptionalParams{
+		WebhookURL:             assemblyai.String(receiver.URL),
+		WebhookAuthHeaderName:  assemblyai.String("X-Webhook-Secret"),
+		WebhookAuthHeaderValue: assemblyai.String("secret"),
+	})
+	require.NoError(t, err)
+	require.Equal(t, assemblyai.TranscriptStatusQueued, transcript.Status)
+	require.Nil(t, transcript.Text)
+
+	id := assemblyai.ToString(transcript.ID)
+
+	srv.Advance(10 * time.Second)
+
+	transcript, err = client.Transcripts.Get(ctx, id)
+	require.NoError(t, err)
+	require.Equal(t, assemblyai.TranscriptStatusProcessing, transcript.Status)
+	require.Empty(t, srv.Webhooks())
+
+	srv.Advance(20 * time.Second)
+
+	require.Equal(t, []assemblyai.TranscriptReadyNotification{{
+		TranscriptID: assemblyai.String(id),
+		Status:       "completed",
+	}}, received)
+
+	webhooks := srv.Webhooks()
+	require.Len(t, webhooks, 1)
+	require.Equal(t, receiver.URL, webhooks[0].URL)
+	require.Equal(t, http.StatusOK, webhooks[0].StatusCode)
+	require.NoError(t, webhooks[0].Err)
+
+	transcript, err = client.Transcripts.Get(ctx, id)
+	require.NoError(t, err)
+	require.Equal(t, assemblyai.TranscriptStatusCompleted, transcript.Status)
+	require.Equal(t, DefaultText, assemblyai.ToString(transcript.Text))
+	require.True(t, assemblyai.ToBool(transcript.WebhookAuth))
+	require.Equal(t, int64(200), assemblyai.ToInt64(transcript.WebhookStatusCode))
+
+	require.Equal(t, time.Date(2024, time.January, 1, 0, 0, 30, 0, time.UTC), srv.Now())
+}
+
+func TestServer_WebhooksDontBlockRequests(t *testing.T) {
+	t.Parallel()
+
+	release := make(chan struct{})
+
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer receiver.Close()
+
+	srv := NewServer(nil)
+	defer srv.Close()
+
+	client := srv.Client()
+	ctx := context.Background()
+
+	submitted, err := client.Transcripts.SubmitFromURL(ctx, fakeAudioURL, &assemblyai.TranscriptOptionalParams{
+		WebhookURL: assemblyai.String(receiver.URL),
+	})
+
+	// The transcript completes while the next request is handled, and the
+	// response doesn't wait for the webhook.
+	var transcript assemblyai.Transcript
+	if err == nil {
+		transcript, err = client.Transcripts.Get(ctx, assemblyai.ToString(submitted.ID))
+	}
+
+	close(release)
+
+	require.NoError(t, err)
+	require.Equal(t, assemblyai.TranscriptStatusCompleted, transcript.Status)
+
+	srv.WaitWebhooks()
+
+	webhooks := srv.Webhooks()
+	require.Len(t, webhooks, 1)
+	require.Equal(t, http.StatusOK, webhooks[0].StatusCode)
+}
+
+func TestServer_UploadAndErrors(t *testing.T) {
+	t.Parallel()
+
+	srv := NewServer(nil)
+	defer srv.Close()
+
+	srv.SetTranscriptError("", "Audio file could not be decoded.")
+
+	client := srv.Client()
+	ctx := context.Background()
+
+	transcript, err := client.Transcripts.TranscribeFromReader(ctx, strings.NewReader("fake audio"), nil)
+	require.NoError(t, err)
+	require.Equal(t, assemblyai.TranscriptStatusError, transcript.Status)
+	require.Equal(t, "Audio file could not be decoded.", assemblyai.ToString(transcript.Error))
+
+	data, ok := srv.Upload(assemblyai.ToString(transcript.AudioURL))
+	require.True(t, ok)
+	require.Equal(t, "fake audio", string(data))
+
+	srv.FailNext("POST", "/v2/transcript", http.StatusTooManyRequests, "Too many requests")
+
+	_, err = client.Transcripts.SubmitFromURL(ctx, fakeAudioURL, nil)
+
+	var apierr assemblyai.APIError
+	require.True(t, errors.As(err, &apierr))
+	require.Equal(t, http.StatusTooManyRequests, apierr.Status)
+	require.Equal(t, "Too many requests", apierr.Message)
+
+	// Failures only apply once.
+	_, err = client.Transcripts.SubmitFromURL(ctx, fakeAudioURL, nil)
+	require.NoError(t, err)
+
+	_, err = assemblyai.NewClientWithOptions(assemblyai.WithBaseURL(srv.URL), assemblyai.WithAPIKey("")).Transcripts.Get(ctx, "x")
+	require.True(t, errors.As(err, &apierr))
+	require.Equal(t, http.StatusUnauthorized, apierr.Status)
+
+	_, err = client.Transcripts.Get(ctx, "unknown")
+	require.True(t, errors.As(err, &apierr))
+	require.Equal(t, http.StatusBadRequest, apierr.Status)
+}
+
+func TestServer_ListAndDelete(t *testing.T) {
+	t.Parallel()
+
+	srv := NewServer(&Options{QueuedDuration: time.Minute})
+	defer srv.Close()
+
+	client := srv.Client()
+	ctx := context.Background()
+
+	first := srv.AddTranscript(assemblyai.Transcript{Text: assemblyai.String("first")})
+	second := srv.AddTranscript(assemblyai.Transcript{ID: assemblyai.String("custom-id"), Text: assemblyai.String("second")})
+
+	srv.Advance(24 * time.Hour)
+
+	queued, err := client.Transcripts.SubmitFromURL(ctx, fakeAudioURL, nil)
+	require.NoError(t, err)
+
+	list, err := client.Transcripts.List(ctx, assemblyai.ListTranscriptParams{})
+	require.NoError(t, err)
+	require.Len(t, list.Transcripts, 3)
+	require.Equal(t, queued.ID, list.Transcripts[0].ID)
+	require.Equal(t, "custom-id", assemblyai.ToString(list.Transcripts[1].ID))
+	require.Equal(t, first, assemblyai.ToString(list.Transcripts[2].ID))
+	require.Equal(t, "2024-01-01T00:00:00.000000", assemblyai.ToString(list.Transcripts[2].Created))
+	require.Equal(t, int64(3), assemblyai.ToInt64(list.PageDetails.ResultCount))
+
+	list, err = client.Transcripts.List(ctx, assemblyai.ListTranscriptParams{
+		Status: assemblyai.TranscriptStatusCompleted,
+		Limit:  assemblyai.Int64(1),
+	})
+	require.NoError(t, err)
+	require.Len(t, list.Transcripts, 1)
+	require.Equal(t, second, assemblyai.ToString(list.Transcripts[0].ID))
+
+	list, err = client.Transcripts.List(ctx, assemblyai.ListTranscriptParams{BeforeID: assemblyai.String(second)})
+	require.NoError(t, err)
+	require.Len(t, list.Transcripts, 1)
+	require.Equal(t, first, assemblyai.ToString(list.Transcripts[0].ID))
+
+	// With after_id, the page holds the transcripts right after the cursor.
+	list, err = client.Transcripts.List(ctx, assemblyai.ListTranscriptParams{
+		AfterID: assemblyai.String(first),
+		Limit:   assemblyai.Int64(1),
+	})
+	require.NoError(t, err)
+	require.Len(t, list.Transcripts, 1)
+	require.Equal(t, second, assemblyai.ToString(list.Transcripts[0].ID))
+
+	list, err = client.Transcripts.List(ctx, assemblyai.ListTranscriptParams{AfterID: assemblyai.String(first)})
+	require.NoError(t, err)
+	require.Len(t, list.Transcripts, 2)
+	require.Equal(t, queued.ID, list.Transcripts[0].ID)
+	require.Equal(t, second, assemblyai.ToString(list.Transcripts[1].ID))
+
+	list, err = client.Transcripts.List(ctx, assemblyai.ListTranscriptParams{CreatedOn: assemblyai.String("2024-01-02")})
+	require.NoError(t, err)
+	require.Len(t, list.Transcripts, 1)
+
+	// Transcripts can't be deleted while they're processed.
+	_, err = client.Transcripts.Delete(ctx, assemblyai.ToString(queued.ID))
+	require.Error(t, err)
+
+	deleted, err := client.Transcripts.Delete(ctx, first)
+	require.NoError(t, err)
+	require.Equal(t, "Deleted by user.", assemblyai.ToString(deleted.Text))
+	require.Equal(t, "http://deleted_by_user", assemblyai.ToString(deleted.AudioURL))
+
+	transcript, ok := srv.Transcript(first)
+	require.True(t, ok)
+	require.Equal(t, deleted, transcript)
+}
+
+func TestServer_TranscriptResources(t *testing.T) {
+	t.Parallel()
+
+	srv := NewServer(nil)
+	defer srv.Close()
+
+	word := func(text string, start int64, speaker string) assemblyai.TranscriptWord {
+		return assemblyai.TranscriptWord{
+			Text:       assemblyai.String(text),
+			Start:      assemblyai.Int64(start),
+			End:        assemblyai.Int64(start + 400),
+			Speaker:    assemblyai.String(speaker),
+			Confidence: assemblyai.Float64(0.9),
+		}
+	}
+
+	words := []assemblyai.TranscriptWord{
+		word("Is", 0, "A"),
+		word("it", 500, "A"),
+		word("smoky?", 1000, "A"),
+		word("Very", 2000, "B"),
+		word("smoky", 2500, "B"),
+		word("today.", 3000, "B"),
+	}
+
+	id := srv.AddTranscript(assemblyai.Transcript{
+		Words: words,
+		Utterances: []assemblyai.TranscriptUtterance{
+			{Speaker: assemblyai.String("A"), Text: assemblyai.String("Is it smoky?"), Start: assemblyai.Int64(0), End: assemblyai.Int64(1400), Words: words[:3]},
+			{Speaker: assemblyai.String("B"), Text: assemblyai.String("Very smoky today."), Start: assemblyai.Int64(2000), End: assemblyai.Int64(3400), Words: words[3:]},
+		},
+		RedactPIIAudio: assemblyai.Bool(true),
+	})
+
+	client := srv.Client()
+	ctx := context.Background()
+
+	sentences, err := client.Transcripts.GetSentences(ctx, id)
+	require.NoError(t, err)
+	require.Len(t, sentences.Sentences, 2)
+	require.Equal(t, "Very smoky today.", assemblyai.ToString(sentences.Sentences[1].Text))
+	require.Equal(t, "B", assemblyai.ToString(sentences.Sentences[1].Speaker))
+	require.Equal(t, int64(3400), assemblyai.ToInt64(sentences.Sentences[1].End))
+
+	paragraphs, err := client.Transcripts.GetParagraphs(ctx, id)
+	require.NoError(t, err)
+	require.Len(t, paragraphs.Paragraphs, 2)
+	require.Equal(t, "Is it smoky?", assemblyai.ToString(paragraphs.Paragraphs[0].Text))
+
+	results, err := client.Transcripts.WordSearch(ctx, id, []string{"smoky", "very smoky", "fire"})
+	require.NoError(t, err)
+	require.Equal(t, int64(3), assemblyai.ToInt64(results.TotalCount))
+	require.Equal(t, []int64{2, 4}, results.Matches[0].Indexes)
+	require.Equal(t, []assemblyai.WordSearchTimestamp{{2000, 2900}}, results.Matches[1].Timestamps)
+	require.Equal(t, int64(0), assemblyai.ToInt64(results.Matches[2].Count))
+
+	srt, err := client.Transcripts.GetSubtitles(ctx, id, assemblyai.SubtitleFormatSRT, &assemblyai.TranscriptGetSubtitlesOptions{CharsPerCaption: 12})
+	require.NoError(t, err)
+	require.Equal(t, "1\n00:00:00,000 --> 00:00:01,400\nIs it smoky?\n\n2\n00:00:02,000 --> 00:00:02,900\nVery smoky\n\n3\n00:00:03,000 --> 00:00:03,400\ntoday.\n", string(srt))
+
+	vtt, err := client.Transcripts.GetSubtitles(ctx, id, assemblyai.SubtitleFormatVTT, nil)
+	require.NoError(t, err)
+
+	subs, err := assemblyai.ParseVTT(vtt)
+	require.NoError(t, err)
+	require.Len(t, subs.Cues, 1)
+	require.Equal(t, "Is it smoky? Very smoky today.", subs.Cues[0].Text)
+
+	audio, err := client.Transcripts.GetRedactedAudio(ctx, id)
+	require.NoError(t, err)
+	require.Equal(t, assemblyai.RedactedAudioStatusReady, audio.Status)
+	require.True(t, strings.HasSuffix(assemblyai.ToString(audio.RedactedAudioURL), ".mp3"))
+}
+
+func TestServer_LeMUR(t *testing.T) {
+	t.Parallel()
+
+	srv := NewServer(nil)
+	defer srv.Close()
+
+	id := srv.AddTranscript(assemblyai.Transcript{Text: assemblyai.String("The sky is orange.")})
+
+	srv.SetLeMURHandler(func(req LeMURRequest) string {
+		switch req.Endpoint {
+		case "task":
+			return strings.ToUpper(assemblyai.ToString(req.Transcripts[0].Text))
+		case "question-answer":
+			return "Orange"
+		}
+		return DefaultLeMURHandler(req)
+	})
+
+	client := srv.Client()
+	ctx := context.Background()
+
+	params := assemblyai.LeMURBaseParams{TranscriptIDs: []string{id}}
+
+	task, err := client.LeMUR.Task(ctx, assemblyai.LeMURTaskParams{
+		Prompt:          assemblyai.String("Shout the transcript."),
+		LeMURBaseParams: params,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "THE SKY IS ORANGE.", assemblyai.ToString(task.Response))
+	require.Equal(t, int64(10), assemblyai.ToInt64(task.Usage.InputTokens))
+	require.Equal(t, int64(5), assemblyai.ToInt64(task.Usage.OutputTokens))
+
+	answers, err := client.LeMUR.Question(ctx, assemblyai.LeMURQuestionAnswerParams{
+		Questions:       []assemblyai.LeMURQuestion{{Question: assemblyai.String("What color is the sky?")}},
+		LeMURBaseParams: params,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []assemblyai.LeMURQuestionAnswer{{
+		Question: assemblyai.String("What color is the sky?"),
+		Answer:   assemblyai.String("Orange"),
+	}}, answers.Response)
+
+	summary, err := client.LeMUR.Summarize(ctx, assemblyai.LeMURSummaryParams{LeMURBaseParams: params})
+	require.NoError(t, err)
+	require.Equal(t, "Fake summary response.", assemblyai.ToString(summary.Response))
+
+	var stored assemblyai.LeMURTaskResponse
+	require.NoError(t, client.LeMUR.GetResponseData(ctx, assemblyai.ToString(task.RequestID), &stored))
+	require.Equal(t, task, stored)
+
+	purged, err := client.LeMUR.PurgeRequestData(ctx, assemblyai.ToString(task.RequestID))
+	require.NoError(t, err)
+	require.True(t, assemblyai.ToBool(purged.Deleted))
+	require.Equal(t, task.RequestID, purged.RequestIDToPurge)
+
+	require.Error(t, client.LeMUR.GetResponseData(ctx, assemblyai.ToString(task.RequestID), &stored))
+
+	_, err = client.LeMUR.ActionItems(ctx, assemblyai.LeMURActionItemsParams{
+		LeMURBaseParams: assemblyai.LeMURBaseParams{TranscriptIDs: []string{"unknown"}},
+	})
+
+	var apierr assemblyai.APIError
+	require.True(t, errors.As(err, &apierr))
+	require.Equal(t, "Transcript unknown not found", apierr.Message)
+}
+
+func TestServer_Sync(t *testing.T) {
+	t.Parallel()
+
+	srv := NewServer(nil)
+	defer srv.Close()
+
+	for _, text := range []string{"one", "two", "three"} {
+		srv.AddTranscript(assemblyai.Transcript{Text: assemblyai.String(text)})
+	}
+
+	store, err := assemblyai.NewFileStore(t.TempDir())
+	require.NoError(t, err)
+
+	result, err := srv.Client().Transcripts.Sync(context.Background(), store, &assemblyai.SyncOptions{PageSize: 2})
+	require.NoError(t, err)
+	require.Len(t, result.Added, 3)
+
+	// Syncing again only picks up new transcripts, over several pages.
+	for _, text := range []string{"four", "five", "six", "seven", "eight"} {
+		srv.AddTranscript(assemblyai.Transcript{Text: assemblyai.String(text)})
+	}
+
+	result, err = srv.Client().Transcripts.Sync(context.Background(), store, &assemblyai.SyncOptions{PageSize: 2})
+	require.NoError(t, err)
+	require.Len(t, result.Added, 5)
+
+	result, err = srv.Client().Transcripts.Sync(context.Background(), store, nil)
+	require.NoError(t, err)
+	require.Empty(t, result.Added)
+
+	stored, err := store.Query(context.Background(), assemblyai.StoreQuery{})
+	require.NoError(t, err)
+	require.Len(t, stored, 8)
+}
@@ -0,0 +1,694 @@
+package assemblyaitest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/AssemblyAI/assemblyai-go-sdk"
+)
+
+// DefaultText is the text of transcripts for audio that hasn't been scripted
+// with [Server.SetTranscript].
+var DefaultText = "This is a fake transcript."
+
+// DefaultCharsPerCaption is the maximum length of a subtitle cue when the
+// request doesn't set chars_per_caption.
+var DefaultCharsPerCaption = 32
+
+// Each word of a transcript that's scripted with text only lasts this long.
+const fakeWordDuration = 500
+
+// Layout of the created field of transcript list items.
+const listCreatedLayout = "2006-01-02T15:04:05.000000"
+
+type script struct {
+	transcript assemblyai.Transcript
+	err        string
+}
+
+type transcript struct {
+	id      string
+	created time.Time
+	status  assemblyai.TranscriptStatus
+	deleted bool
+
+	// Whether the transcript was added with AddTranscript, and so skips the
+	// queue.
+	added bool
+
+	// The transcript while it's being processed, and once it's done.
+	pending assemblyai.Transcript
+	result  assemblyai.Transcript
+
+	webhookHeaderValue string
+	webhookStatusCode  int64
+}
+
+func (t *transcript) done() bool {
+	return t.status == assemblyai.TranscriptStatusCompleted || t.status == assemblyai.TranscriptStatusError
+}
+
+// SetTranscript scripts the result of transcribing the audio at the given
+// URL, or at any URL if audioURL is empty. The parameters of the transcription
+// request are applied on top of it, and the ID, status and audio URL are set
+// by the server.
+//
+// If the transcript has text but no words, the words are generated from the
+// text, each lasting half a second.
+func (s *Server) SetTranscript(audioURL string, t assemblyai.Transcript) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.scripts[audioURL] = script{transcript: t}
+}
+
+// SetTranscriptError makes the transcription of the audio at the given URL, or
+// at any URL if audioURL is empty, fail with the given error message.
+func (s *Server) SetTranscriptError(audioURL string, message string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.scripts[audioURL] = script{err: message}
+}
+
+// AddTranscript adds a finished transcript to the server, as if it had been
+// created earlier, and returns its ID. The ID is generated if the transcript
+// doesn't have one, and the status defaults to completed.
+func (s *Server) AddTranscript(t assemblyai.Transcript) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result := copyTranscript(t)
+	fillTranscript(&result)
+
+	id := assemblyai.ToString(result.ID)
+	if id == "" {
+		id = s.nextTranscriptIDLocked()
+	}
+
+	result.ID = assemblyai.String(id)
+
+	status := result.Status
+	if status != assemblyai.TranscriptStatusError {
+		status = assemblyai.TranscriptStatusCompleted
+	}
+
+	s.transcripts = append(s.transcripts, &transcript{
+		id:      id,
+		created: s.now,
+		status:  status,
+		added:   true,
+		result:  result,
+	})
+
+	return id
+}
+
+// Transcript returns the transcript with the given ID, as it would currently
+// be returned by the API.
+func (s *Server) Transcript(id string) (assemblyai.Transcript, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t := s.findLocked(id)
+	if t == nil {
+		return assemblyai.Transcript{}, false
+	}
+
+	return copyTranscript(s.viewLocked(t)), true
+}
+
+func (s *Server) nextTranscriptIDLocked() string {
+	s.seq++
+	return fmt.Sprintf("fake-transcript-%d", s.seq)
+}
+
+func (s *Server) findLocked(id string) *transcript {
+	for _, t := range s.transcripts {
+		if t.id == id {
+			return t
+		}
+	}
+	return nil
+}
+
+// settleLocked updates the status of the transcripts according to the clock,
+// and returns the webhooks to send for the ones that finished.
+func (s *Server) settleLocked() []Webhook {
+	var webhooks []Webhook
+
+	for _, t := range s.transcripts {
+		if t.done() {
+			continue
+		}
+
+		elapsed := s.now.Sub(t.created)
+
+		switch {
+		case elapsed < s.opts.QueuedDuration:
+			t.status = assemblyai.TranscriptStatusQueued
+			continue
+		case elapsed < s.opts.QueuedDuration+s.opts.ProcessingDuration:
+			t.status = assemblyai.TranscriptStatusProcessing
+			continue
+		case t.result.Error != nil:
+			t.status = assemblyai.TranscriptStatusError
+		default:
+			t.status = assemblyai.TranscriptStatusCompleted
+		}
+
+		webhookURL := assemblyai.ToString(t.result.WebhookURL)
+		if webhookURL == "" || t.deleted {
+			continue
+		}
+
+		header := make(http.Header)
+		header.Set("Content-Type", "application/json")
+
+		if name := assemblyai.ToString(t.result.WebhookAuthHeaderName); name != "" {
+			header.Set(name, t.webhookHeaderValue)
+		}
+
+		webhooks = append(webhooks, Webhook{
+			URL:    webhookURL,
+			Header: header,
+			Notification: assemblyai.TranscriptReadyNotification{
+				TranscriptID: assemblyai.String(t.id),
+				Status:       assemblyai.TranscriptReadyStatus(t.status),
+			},
+		})
+	}
+
+	return webhooks
+}
+
+// viewLocked returns the transcript as it's currently returned by the API.
+func (s *Server) viewLocked(t *transcript) assemblyai.Transcript {
+	if t.deleted {
+		v := assemblyai.Transcript{
+			ID:            assemblyai.String(t.id),
+			Status:        assemblyai.TranscriptStatusCompleted,
+			AudioURL:      assemblyai.String("http://deleted_by_user"),
+			Text:          assemblyai.String("Deleted by user."),
+			AudioDuration: t.result.AudioDuration,
+		}
+
+		if t.result.WebhookURL != nil {
+			v.WebhookURL = assemblyai.String("http://deleted_by_user")
+		}
+
+		return v
+	}
+
+	v := t.pending
+	if t.done() {
+		v = t.result
+	}
+
+	v.Status = t.status
+
+	if t.webhookStatusCode != 0 {
+		v.WebhookStatusCode = assemblyai.Int64(t.webhookStatusCode)
+	}
+
+	return v
+}
+
+func (s *Server) createTranscriptLocked(body []byte) (int, interface{}) {
+	var params assemblyai.TranscriptParams
+
+	if err := json.Unmarshal(body, &params); err != nil {
+		return badRequest("Invalid request body: %s", err)
+	}
+
+	audioURL := assemblyai.ToString(params.AudioURL)
+	if audioURL == "" {
+		return badRequest("audio_url is required")
+	}
+
+	sc, ok := s.scripts[audioURL]
+	if !ok {
+		sc, ok = s.scripts[""]
+	}
+	if !ok {
+		sc = script{transcript: assemblyai.Transcript{Text: assemblyai.String(DefaultText)}}
+	}
+
+	webhookHeaderValue := assemblyai.ToString(params.WebhookAuthHeaderValue)
+
+	// The webhook header value is write-only.
+	params.WebhookAuthHeaderValue = nil
+
+	b, err := json.Marshal(params)
+	if err != nil {
+		return http.StatusInternalServerError, errorResponse{Message: err.Error()}
+	}
+
+	t := &transcript{
+		id:                 s.nextTranscriptIDLocked(),
+		created:            s.now,
+		status:             assemblyai.TranscriptStatusQueued,
+		webhookHeaderValue: webhookHeaderValue,
+	}
+
+	if sc.err != "" {
+		t.result.Error = assemblyai.String(sc.err)
+	} else {
+		t.result = copyTranscript(sc.transcript)
+		fillTranscript(&t.result)
+	}
+
+	for _, v := range []*assemblyai.Transcript{&t.pending, &t.result} {
+		// Decoding on top of the scripted transcript keeps the fields that
+		// aren't request parameters.
+		if err := json.Unmarshal(b, v); err != nil {
+			return http.StatusInternalServerError, errorResponse{Message: err.Error()}
+		}
+
+		v.ID = assemblyai.String(t.id)
+		v.WebhookAuth = assemblyai.Bool(params.WebhookAuthHeaderName != nil)
+	}
+
+	s.transcripts = append(s.transcripts, t)
+
+	return http.StatusOK, s.viewLocked(t)
+}
+
+func (s *Server) listTranscriptsLocked(u *url.URL) (int, interface{}) {
+	q := u.Query()
+
+	limit := 10
+
+	if v := q.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > 200 {
+			return badRequest("limit must be between 1 and 200")
+		}
+		limit = n
+	}
+
+	bound := func(name string) (int, bool) {
+		id := q.Get(name)
+		if id == "" {
+			return -1, true
+		}
+		for i, t := range s.transcripts {
+			if t.id == id {
+				return i, true
+			}
+		}
+		return -1, false
+	}
+
+	before, ok := bound("before_id")
+	if !ok {
+		return badRequest("Transcript %s not found", q.Get("before_id"))
+	}
+
+	after, ok := bound("after_id")
+	if !ok {
+		return badRequest("Transcript %s not found", q.Get("after_id"))
+	}
+
+	list := assemblyai.TranscriptList{Transcripts: []assemblyai.TranscriptListItem{}}
+
+	// Transcripts are listed from newest to oldest. With after_id, the page
+	// holds the transcripts right after the cursor, so walk forward from it
+	// and reverse the page at the end.
+	first, last, step := len(s.transcripts)-1, -1, -1
+	if after >= 0 {
+		first, last, step = after+1, len(s.transcripts), 1
+	}
+
+	for i := first; i != last && len(list.Transcripts) < limit; i += step {
+		t := s.transcripts[i]
+
+		if before >= 0 && i >= before || after >= 0 && i <= after {
+			continue
+		}
+
+		view := s.viewLocked(t)
+
+		if status := q.Get("status"); status != "" && string(view.Status) != status {
+			continue
+		}
+
+		if createdOn := q.Get("created_on"); createdOn != "" && t.created.Format("2006-01-02") != createdOn {
+			continue
+		}
+
+		if q.Get("throttled_only") == "true" && !assemblyai.ToBool(view.Throttled) {
+			continue
+		}
+
+		item := assemblyai.TranscriptListItem{
+			ID:          assemblyai.String(t.id),
+			AudioURL:    view.AudioURL,
+			Status:      view.Status,
+			Created:     assemblyai.String(t.created.Format(listCreatedLayout)),
+			ResourceURL: assemblyai.String(s.URL + "/v2/transcript/" + t.id),
+			Error:       view.Error,
+		}
+
+		if t.done() {
+			completed := t.created.Add(s.opts.QueuedDuration + s.opts.ProcessingDuration)
+			if t.added {
+				completed = t.created
+			}
+			item.Completed = assemblyai.String(completed.Format(listCreatedLayout))
+		}
+
+		list.Transcripts = append(list.Transcripts, item)
+	}
+
+	if step > 0 {
+		items := list.Transcripts
+		for l, r := 0, len(items)-1; l < r; l, r = l+1, r-1 {
+			items[l], items[r] = items[r], items[l]
+		}
+	}
+
+	list.PageDetails = assemblyai.PageDetails{
+		Limit:       assemblyai.Int64(int64(limit)),
+		ResultCount: assemblyai.Int64(int64(len(list.Transcripts))),
+		CurrentURL:  assemblyai.String(s.URL + u.RequestURI()),
+	}
+
+	if n := len(list.Transcripts); n > 0 {
+		page := func(name, id string) *string {
+			values := url.Values{}
+			values.Set("limit", strconv.Itoa(limit))
+			values.Set(name, id)
+			return assemblyai.String(s.URL + "/v2/transcript?" + values.Encode())
+		}
+
+		list.PageDetails.PrevURL = page("before_id", assemblyai.ToString(list.Transcripts[n-1].ID))
+		list.PageDetails.NextURL = page("after_id", assemblyai.ToString(list.Transcripts[0].ID))
+	}
+
+	return http.StatusOK, list
+}
+
+func (s *Server) transcriptLocked(method, id string, sub []string, q url.Values) (int, interface{}) {
+	t := s.findLocked(id)
+	if t == nil {
+		return badRequest("Transcript lookup error, transcript id not found")
+	}
+
+	if len(sub) == 0 {
+		switch method {
+		case "GET":
+			return http.StatusOK, s.viewLocked(t)
+		case "DELETE":
+			if !t.done() {
+				return badRequest("Transcript %s can't be deleted while it's %s", id, t.status)
+			}
+			t.deleted = true
+			return http.StatusOK, s.viewLocked(t)
+		}
+		return notFound()
+	}
+
+	if method != "GET" || len(sub) != 1 {
+		return notFound()
+	}
+
+	if t.status != assemblyai.TranscriptStatusCompleted || t.deleted {
+		return badRequest("This transcript has a status of '%s'. Transcripts must have a status of 'completed' before requesting %s.", s.viewLocked(t).Status, sub[0])
+	}
+
+	result := t.result
+
+	switch sub[0] {
+	case "sentences":
+		return http.StatusOK, assemblyai.SentencesResponse{
+			ID:            result.ID,
+			AudioDuration: result.AudioDuration,
+			Confidence:    result.Confidence,
+			Sentences:     sentences(result.Words),
+		}
+	case "paragraphs":
+		return http.StatusOK, assemblyai.ParagraphsResponse{
+			ID:            result.ID,
+			AudioDuration: result.AudioDuration,
+			Confidence:    result.Confidence,
+			Paragraphs:    paragraphs(result),
+		}
+	case "word-search":
+		return http.StatusOK, wordSearch(result, strings.Split(q.Get("words"), ","))
+	case "srt", "vtt":
+		chars := DefaultCharsPerCaption
+
+		if v := q.Get("chars_per_caption"); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil || n < 1 {
+				return badRequest("chars_per_caption must be a positive integer")
+			}
+			chars = n
+		}
+
+		subs := subtitles(result.Words, chars)
+
+		if sub[0] == "srt" {
+			return http.StatusOK, subs.SRT()
+		}
+		return http.StatusOK, subs.VTT()
+	case "redacted-audio":
+		if !assemblyai.ToBool(result.RedactPIIAudio) {
+			return badRequest("Redacted audio is not available for this transcript")
+		}
+
+		ext := "mp3"
+		if result.RedactPIIAudioQuality == assemblyai.RedactPIIAudioQualityWAV {
+			ext = "wav"
+		}
+
+		return http.StatusOK, assemblyai.RedactedAudioResponse{
+			Status:           assemblyai.RedactedAudioStatusReady,
+			RedactedAudioURL: assemblyai.String(fmt.Sprintf("https://cdn.assemblyai.com/redacted-audio/%s.%s", id, ext)),
+		}
+	}
+
+	return notFound()
+}
+
+// copyTranscript returns a deep copy of a transcript.
+func copyTranscript(t assemblyai.Transcript) assemblyai.Transcript {
+	var c assemblyai.Transcript
+
+	b, err := json.Marshal(t)
+	if err != nil {
+		panic(err)
+	}
+
+	if err := json.Unmarshal(b, &c); err != nil {
+		panic(err)
+	}
+
+	return c
+}
+
+// fillTranscript derives the words from the text, or the text from the words,
+// and the audio duration from the words.
+func fillTranscript(t *assemblyai.Transcript) {
+	if len(t.Words) == 0 && t.Text != nil {
+		for i, text := range strings.Fields(*t.Text) {
+			start := int64(i * fakeWordDuration)
+
+			t.Words = append(t.Words, assemblyai.TranscriptWord{
+				Text:       assemblyai.String(text),
+				Start:      assemblyai.Int64(start),
+				End:        assemblyai.Int64(start + fakeWordDuration),
+				Confidence: assemblyai.Float64(1),
+			})
+		}
+	}
+
+	if t.Text == nil && len(t.Words) > 0 {
+		texts := make([]string, len(t.Words))
+		for i, w := range t.Words {
+			texts[i] = assemblyai.ToString(w.Text)
+		}
+		t.Text = assemblyai.String(strings.Join(texts, " "))
+	}
+
+	if t.AudioDuration == nil && len(t.Words) > 0 {
+		end := assemblyai.ToInt64(t.Words[len(t.Words)-1].End)
+		t.AudioDuration = assemblyai.Float64(float64(end) / 1000)
+	}
+}
+
+func joinWords(words []assemblyai.TranscriptWord) (text string, confidence *float64) {
+	texts := make([]string, len(words))
+
+	var sum float64
+
+	for i, w := range words {
+		texts[i] = assemblyai.ToString(w.Text)
+		sum += assemblyai.ToFloat64(w.Confidence)
+	}
+
+	if len(words) > 0 {
+		confidence = assemblyai.Float64(sum / float64(len(words)))
+	}
+
+	return strings.Join(texts, " "), confidence
+}
+
+// sentences splits words into sentences at words that end with a period,
+// question mark or exclamation mark.
+func sentences(words []assemblyai.TranscriptWord) []assemblyai.TranscriptSentence {
+	var (
+		result []assemblyai.TranscriptSentence
+		start  int
+	)
+
+	for i, w := range words {
+		text := assemblyai.ToString(w.Text)
+
+		if i < len(words)-1 && !strings.HasSuffix(text, ".") && !strings.HasSuffix(text, "?") && !strings.HasSuffix(text, "!") {
+			continue
+		}
+
+		sentence := words[start : i+1]
+		text, confidence := joinWords(sentence)
+
+		result = append(result, assemblyai.TranscriptSentence{
+			Text:       assemblyai.String(text),
+			Start:      sentence[0].Start,
+			End:        sentence[len(sentence)-1].End,
+			Confidence: confidence,
+			Speaker:    sentence[0].Speaker,
+			Channel:    sentence[0].Channel,
+			Words:      sentence,
+		})
+
+		start = i + 1
+	}
+
+	return result
+}
+
+// paragraphs returns a paragraph per utterance, or a single paragraph if
+// there are no utterances.
+func paragraphs(t assemblyai.Transcript) []assemblyai.TranscriptParagraph {
+	var result []assemblyai.TranscriptParagraph
+
+	for _, u := range t.Utterances {
+		result = append(result, assemblyai.TranscriptParagraph{
+			Text:       u.Text,
+			Start:      u.Start,
+			End:        u.End,
+			Confidence: u.Confidence,
+			Words:      u.Words,
+		})
+	}
+
+	if len(result) > 0 || len(t.Words) == 0 {
+		return result
+	}
+
+	text, confidence := joinWords(t.Words)
+
+	return []assemblyai.TranscriptParagraph{{
+		Text:       assemblyai.String(text),
+		Start:      t.Words[0].Start,
+		End:        t.Words[len(t.Words)-1].End,
+		Confidence: confidence,
+		Words:      t.Words,
+	}}
+}
+
+func normalizeWord(s string) string {
+	return strings.ToLower(strings.TrimFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	}))
+}
+
+// wordSearch matches each search term, which may be a phrase, against the
+// words of the transcript, ignoring case and punctuation.
+func wordSearch(t assemblyai.Transcript, terms []string) assemblyai.WordSearchResponse {
+	resp := assemblyai.WordSearchResponse{
+		ID:         t.ID,
+		Matches:    []assemblyai.WordSearchMatch{},
+		TotalCount: assemblyai.Int64(0),
+	}
+
+	words := make([]string, len(t.Words))
+	for i, w := range t.Words {
+		words[i] = normalizeWord(assemblyai.ToString(w.Text))
+	}
+
+	for _, term := range terms {
+		var phrase []string
+		for _, f := range strings.Fields(term) {
+			phrase = append(phrase, normalizeWord(f))
+		}
+
+		if len(phrase) == 0 {
+			continue
+		}
+
+		match := assemblyai.WordSearchMatch{
+			Text:       assemblyai.String(strings.TrimSpace(term)),
+			Indexes:    []int64{},
+			Timestamps: []assemblyai.WordSearchTimestamp{},
+		}
+
+	words:
+		for i := 0; i+len(phrase) <= len(words); i++ {
+			for j, p := range phrase {
+				if words[i+j] != p {
+					continue words
+				}
+			}
+
+			match.Indexes = append(match.Indexes, int64(i))
+			match.Timestamps = append(match.Timestamps, assemblyai.WordSearchTimestamp{
+				assemblyai.ToInt64(t.Words[i].Start),
+				assemblyai.ToInt64(t.Words[i+len(phrase)-1].End),
+			})
+		}
+
+		match.Count = assemblyai.Int64(int64(len(match.Indexes)))
+		*resp.TotalCount += *match.Count
+
+		resp.Matches = append(resp.Matches, match)
+	}
+
+	return resp
+}
+
+// subtitles groups words into cues of at most chars characters. Words longer
+// than that get a cue of their own.
+func subtitles(words []assemblyai.TranscriptWord, chars int) assemblyai.Subtitles {
+	var subs assemblyai.Subtitles
+
+	for _, w := range words {
+		text := assemblyai.ToString(w.Text)
+		start := time.Duration(assemblyai.ToInt64(w.Start)) * time.Millisecond
+		end := time.Duration(assemblyai.ToInt64(w.End)) * time.Millisecond
+
+		if n := len(subs.Cues); n > 0 && len(subs.Cues[n-1].Text)+1+len(text) <= chars {
+			cue := &subs.Cues[n-1]
+			cue.Text += " " + text
+			cue.End = end
+			continue
+		}
+
+		subs.Cues = append(subs.Cues, assemblyai.Cue{
+			Index: len(subs.Cues) + 1,
+			Start: start,
+			End:   end,
+			Text:  text,
+		})
+	}
+
+	return subs
+}
@@ -0,0 +1,185 @@
+package assemblyai
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DefaultMaxPause is the longest pause within a single turn of a
+// [Conversation]. Longer pauses start a new turn.
+var DefaultMaxPause = 2 * time.Second
+
+// ConversationOptions configures how [Transcript.Conversation] builds turns.
+type ConversationOptions struct {
+	// Names maps speaker labels or channel numbers to display names, for
+	// example "1" to "Agent".
+	Names map[string]string
+
+	// Group words by channel rather than by speaker label.
+	ByChannel bool
+
+	// Longest pause within a single turn. Defaults to [DefaultMaxPause].
+	MaxPause time.Duration
+}
+
+// Turn is a stretch of speech by a single speaker or channel.
+type Turn struct {
+	// Speaker label, or channel number when grouping by channel.
+	Speaker string
+
+	// Display name of the speaker.
+	Name string
+
+	Start time.Duration
+	End   time.Duration
+
+	Text string
+
+	Words []TranscriptWord
+
+	// Whether the turn started before the previous turn had ended.
+	Overlapping bool
+}
+
+// Conversation is a turn-by-turn view of a transcript.
+type Conversation struct {
+	Turns []Turn
+}
+
+// ChatMessage is a turn formatted as a chat message.
+type ChatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// Conversation merges the utterances of the transcript, or its words if there
+// are no utterances, into chronologically ordered turns. Speech is grouped by
+// speaker label when speaker diarization is enabled and by channel otherwise.
+// When two speakers talk over each other, each keeps their own turn and the
+// later turn is marked as overlapping.
+func (t Transcript) Conversation(opts *ConversationOptions) Conversation {
+	var options ConversationOptions
+
+	if opts != nil {
+		options = *opts
+	}
+
+	if options.MaxPause <= 0 {
+		options.MaxPause = DefaultMaxPause
+	}
+
+	segments := t.turns(options.ByChannel, options.MaxPause)
+
+	var conv Conversation
+
+	for _, seg := range segments {
+		if n := len(conv.Turns); n > 0 {
+			prev := &conv.Turns[n-1]
+
+			if prev.Speaker == seg.Speaker && seg.Start-prev.End <= options.MaxPause {
+				prev.End = maxDuration(prev.End, seg.End)
+				prev.Text += " " + seg.Text
+				prev.Words = append(prev.Words, seg.Words...)
+				continue
+			}
+
+			seg.Overlapping = seg.Start < prev.End
+		}
+
+		seg.Name = speakerName(seg.Speaker, options)
+
+		conv.Turns = append(conv.Turns, seg)
+	}
+
+	return conv
+}
+
+func speakerName(speaker string, options ConversationOptions) string {
+	if name, ok := options.Names[speaker]; ok {
+		return name
+	}
+
+	switch {
+	case speaker == "":
+		return "Speaker"
+	case options.ByChannel:
+		return "Channel " + speaker
+	default:
+		return "Speaker " + speaker
+	}
+}
+
+// Text renders the conversation as plain text, with one line per turn.
+func (c Conversation) Text() string {
+	var b strings.Builder
+
+	for _, turn := range c.Turns {
+		fmt.Fprintf(&b, "%s: %s\n", turn.Name, turn.Text)
+	}
+
+	return b.String()
+}
+
+// Markdown renders the conversation as Markdown, with the speaker name and
+// start time of each turn.
+func (c Conversation) Markdown() string {
+	var b strings.Builder
+
+	for i, turn := range c.Turns {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+
+		fmt.Fprintf(&b, "**%s** _(%s", turn.Name, formatClock(turn.Start))
+
+		if turn.Overlapping {
+			b.WriteString(", overlapping")
+		}
+
+		fmt.Fprintf(&b, ")_: %s\n", turn.Text)
+	}
+
+	return b.String()
+}
+
+// ChatMessages returns the turns as chat messages for use as input to a
+// language model. Roles maps speaker labels, channel numbers or display names
+// to chat roles, for example "Agent" to "assistant". Speakers without a role
+// use their display name as role.
+func (c Conversation) ChatMessages(roles map[string]string) []ChatMessage {
+	messages := make([]ChatMessage, 0, len(c.Turns))
+
+	for _, turn := range c.Turns {
+		role, ok := roles[turn.Speaker]
+		if !ok {
+			role, ok = roles[turn.Name]
+		}
+		if !ok {
+			role = turn.Name
+		}
+
+		messages = append(messages, ChatMessage{Role: role, Content: turn.Text})
+	}
+
+	return messages
+}
+
+// ChatJSON renders the turns as a JSON array of chat messages. See
+// [Conversation.ChatMessages].
+func (c Conversation) ChatJSON(roles map[string]string) ([]byte, error) {
+	return json.Marshal(c.ChatMessages(roles))
+}
+
+// formatClock formats a duration as mm:ss, or h:mm:ss for durations of an hour
+// or more.
+func formatClock(d time.Duration) string {
+	secs := int64(d / time.Second)
+
+	if secs >= 3600 {
+		return fmt.Sprintf("%d:%02d:%02d", secs/3600, secs/60%60, secs%60)
+	}
+
+	return fmt.Sprintf("%02d:%02d", secs/60, secs%60)
+}
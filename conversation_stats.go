@@ -0,0 +1,369 @@
+package assemblyai
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// DefaultMinSilence is the shortest pause between turns that counts as
+// silence in [ConversationStats].
+var DefaultMinSilence = time.Second
+
+// TimeRange is a span of time relative to the start of the audio.
+type TimeRange struct {
+	Start time.Duration
+	End   time.Duration
+}
+
+// Duration returns the length of the range.
+func (r TimeRange) Duration() time.Duration {
+	return r.End - r.Start
+}
+
+// ConversationStatsOptions configures how [Transcript.ConversationStats]
+// computes its metrics.
+type ConversationStatsOptions struct {
+	// Shortest pause between turns that counts as silence. Defaults to
+	// [DefaultMinSilence].
+	MinSilence time.Duration
+
+	// Size of the buckets in [ConversationStats.Series]. The series is only
+	// computed when set.
+	BucketSize time.Duration
+}
+
+// ConversationStats holds talk-time metrics for a transcript.
+type ConversationStats struct {
+	// Length of the conversation.
+	Duration time.Duration
+
+	// Metrics for each speaker, sorted by speaker label.
+	Speakers []SpeakerStats
+
+	// Metrics for each audio channel, sorted by channel. Only set for
+	// multichannel and dual channel transcripts.
+	Channels []SpeakerStats
+
+	// Number of times a speaker started talking before the previous speaker
+	// had finished.
+	Interruptions int
+
+	// Total time when more than one speaker was talking.
+	Overlap time.Duration
+
+	// Pauses between turns longer than the minimum silence.
+	Silences []TimeRange
+
+	// Total time of all pauses in Silences.
+	Silence time.Duration
+
+	// Talk time and words per speaker over fixed windows, for charts.
+	Series []StatsBucket
+}
+
+// SpeakerStats holds the metrics for a single speaker or channel.
+type SpeakerStats struct {
+	// Speaker label or channel number.
+	Speaker string
+
+	// Total time the speaker was talking.
+	TalkTime time.Duration
+
+	// Share of the total talk time of all speakers, from 0 to 1.
+	TalkRatio float64
+
+	// Number of utterances by the speaker.
+	Turns int
+
+	// Number of words spoken.
+	Words int
+
+	// Average speaking rate while talking.
+	WordsPerMinute float64
+
+	// Longest stretch where the speaker talked without anyone else taking a
+	// turn.
+	LongestMonologue time.Duration
+
+	// Number of times the speaker started talking over someone else.
+	Interruptions int
+
+	// Number of times someone else started talking over the speaker.
+	Interrupted int
+
+	// Number of questions asked by the speaker.
+	Questions int
+}
+
+// StatsBucket holds the metrics for a single window of the conversation.
+type StatsBucket struct {
+	TimeRange
+
+	// Time each speaker was talking within the window.
+	TalkTime map[string]time.Duration
+
+	// Number of words each speaker started within the window.
+	Words map[string]int
+}
+
+// ConversationStats computes talk-time metrics from the utterances of the
+// transcript, or from the words if speaker diarization wasn't enabled.
+func (t Transcript) ConversationStats(opts *ConversationStatsOptions) ConversationStats {
+	var options ConversationStatsOptions
+
+	if opts != nil {
+		options = *opts
+	}
+
+	if options.MinSilence <= 0 {
+		options.MinSilence = DefaultMinSilence
+	}
+
+	turns := t.turns(false, DefaultMaxPause)
+
+	stats := ConversationStats{
+		Duration: t.audioDuration(),
+		Speakers: speakerStats(turns),
+	}
+
+	for _, tr := range turns {
+		if tr.End > stats.Duration {
+			stats.Duration = tr.End
+		}
+	}
+
+	if ToBool(t.Multichannel) || ToBool(t.DualChannel) {
+		stats.Channels = speakerStats(t.turns(true, DefaultMaxPause))
+	}
+
+	// The speaker and end of the earlier turn that ends last, which may not
+	// be the previous turn if that one was short.
+	var (
+		lastSpeaker string
+		lastEnd     time.Duration
+	)
+
+	for i, tr := range turns {
+		if i > 0 {
+			if lastSpeaker != tr.Speaker && lastEnd > tr.Start {
+				stats.Interruptions++
+				stats.Overlap += minDuration(lastEnd, tr.End) - tr.Start
+			}
+
+			if gap := tr.Start - lastEnd; gap >= options.MinSilence {
+				stats.Silences = append(stats.Silences, TimeRange{Start: lastEnd, End: tr.Start})
+				stats.Silence += gap
+			}
+		}
+
+		if tr.End > lastEnd {
+			lastSpeaker, lastEnd = tr.Speaker, tr.End
+		}
+	}
+
+	if options.BucketSize > 0 {
+		stats.Series = statsSeries(turns, stats.Duration, options.BucketSize)
+	}
+
+	return stats
+}
+
+// audioDuration returns the duration of the audio, or zero if it's unknown.
+func (t Transcript) audioDuration() time.Duration {
+	return time.Duration(ToFloat64(t.AudioDuration) * float64(time.Second))
+}
+
+// turns returns the turns of the transcript in chronological order, keyed by
+// speaker, or by channel if byChannel is true. Utterances are used as turns
+// when present. Otherwise consecutive words of a speaker are grouped into
+// turns, which end at pauses longer than maxPause.
+func (t Transcript) turns(byChannel bool, maxPause time.Duration) []Turn {
+	key := func(speaker, channel *string) string {
+		if byChannel || speaker == nil {
+			return ToString(channel)
+		}
+		return ToString(speaker)
+	}
+
+	var turns []Turn
+
+	if len(t.Utterances) > 0 {
+		for _, u := range t.Utterances {
+			turns = append(turns, Turn{
+				Speaker: key(u.Speaker, u.Channel),
+				Start:   toDuration(u.Start),
+				End:     toDuration(u.End),
+				Text:    ToString(u.Text),
+				Words:   u.Words,
+			})
+		}
+	} else {
+		words := append([]TranscriptWord(nil), t.Words...)
+
+		sort.SliceStable(words, func(i, j int) bool {
+			return ToInt64(words[i].Start) < ToInt64(words[j].Start)
+		})
+
+		// Keep a turn open for each speaker, so that someone talking over
+		// them doesn't split the turn. A turn is closed once someone else
+		// starts talking after it has ended.
+		open := make(map[string]int)
+
+		for _, w := range words {
+			k := key(w.Speaker, w.Channel)
+			start, end := toDuration(w.Start), toDuration(w.End)
+
+			for other, i := range open {
+				if other != k && start >= turns[i].End {
+					delete(open, other)
+				}
+			}
+
+			if i, ok := open[k]; ok && start-turns[i].End <= maxPause {
+				turns[i].End = end
+				turns[i].Text += " " + ToString(w.Text)
+				turns[i].Words = append(turns[i].Words, w)
+				continue
+			}
+
+			open[k] = len(turns)
+
+			turns = append(turns, Turn{
+				Speaker: k,
+				Start:   start,
+				End:     end,
+				Text:    ToString(w.Text),
+				Words:   []TranscriptWord{w},
+			})
+		}
+	}
+
+	sort.SliceStable(turns, func(i, j int) bool {
+		return turns[i].Start < turns[j].Start
+	})
+
+	return turns
+}
+
+func speakerStats(turns []Turn) []SpeakerStats {
+	bySpeaker := make(map[string]*SpeakerStats)
+
+	get := func(speaker string) *SpeakerStats {
+		s, ok := bySpeaker[speaker]
+		if !ok {
+			s = &SpeakerStats{Speaker: speaker}
+			bySpeaker[speaker] = s
+		}
+		return s
+	}
+
+	var (
+		total     time.Duration
+		monologue TimeRange
+
+		// The earlier turn that ends last.
+		latest Turn
+	)
+
+	for i, tr := range turns {
+		s := get(tr.Speaker)
+
+		s.Turns++
+		s.TalkTime += tr.End - tr.Start
+		s.Questions += strings.Count(tr.Text, "?")
+
+		if len(tr.Words) > 0 {
+			s.Words += len(tr.Words)
+		} else {
+			s.Words += len(strings.Fields(tr.Text))
+		}
+
+		total += tr.End - tr.Start
+
+		if i > 0 && turns[i-1].Speaker == tr.Speaker {
+			monologue.End = tr.End
+		} else {
+			monologue = TimeRange{Start: tr.Start, End: tr.End}
+		}
+
+		if monologue.Duration() > s.LongestMonologue {
+			s.LongestMonologue = monologue.Duration()
+		}
+
+		if i > 0 && latest.Speaker != tr.Speaker && latest.End > tr.Start {
+			s.Interruptions++
+			get(latest.Speaker).Interrupted++
+		}
+
+		if i == 0 || tr.End > latest.End {
+			latest = tr
+		}
+	}
+
+	result := make([]SpeakerStats, 0, len(bySpeaker))
+
+	for _, s := range bySpeaker {
+		if total > 0 {
+			s.TalkRatio = float64(s.TalkTime) / float64(total)
+		}
+
+		if s.TalkTime > 0 {
+			s.WordsPerMinute = float64(s.Words) / s.TalkTime.Minutes()
+		}
+
+		result = append(result, *s)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Speaker < result[j].Speaker
+	})
+
+	return result
+}
+
+func statsSeries(turns []Turn, duration, size time.Duration) []StatsBucket {
+	n := int((duration + size - 1) / size)
+
+	series := make([]StatsBucket, n)
+
+	for i := range series {
+		series[i] = StatsBucket{
+			TimeRange: TimeRange{Start: time.Duration(i) * size, End: time.Duration(i+1) * size},
+			TalkTime:  make(map[string]time.Duration),
+			Words:     make(map[string]int),
+		}
+	}
+
+	add := func(speaker string, start, end time.Duration) {
+		for i := int(start / size); i < n && series[i].Start < end; i++ {
+			overlap := minDuration(end, series[i].End) - maxDuration(start, series[i].Start)
+			if overlap > 0 {
+				series[i].TalkTime[speaker] += overlap
+			}
+		}
+	}
+
+	for _, tr := range turns {
+		if len(tr.Words) == 0 {
+			add(tr.Speaker, tr.Start, tr.End)
+
+			if i := int(tr.Start / size); i < n {
+				series[i].Words[tr.Speaker] += len(strings.Fields(tr.Text))
+			}
+			continue
+		}
+
+		for _, w := range tr.Words {
+			start, end := toDuration(w.Start), toDuration(w.End)
+
+			add(tr.Speaker, start, end)
+
+			if i := int(start / size); i < n {
+				series[i].Words[tr.Speaker]++
+			}
+		}
+	}
+
+	return series
+}
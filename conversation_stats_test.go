@@ -0,0 +1,80 @@
+package assemblyai
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTranscript_ConversationStats(t *testing.T) {
+	t.Parallel()
+
+	transcript := readTranscript(t, "testdata/transcript/conversation-stats.json")
+
+	stats := transcript.ConversationStats(&ConversationStatsOptions{
+		BucketSize: 10 * time.Second,
+	})
+
+	require.Equal(t, 20*time.Second, stats.Duration)
+	require.Equal(t, 1, stats.Interruptions)
+	require.Equal(t, 500*time.Millisecond, stats.Overlap)
+	require.Equal(t, []TimeRange{{Start: 8 * time.Second, End: 10 * time.Second}}, stats.Silences)
+
+	require.Len(t, stats.Speakers, 2)
+
+	a, b := stats.Speakers[0], stats.Speakers[1]
+
+	require.Equal(t, "A", a.Speaker)
+	require.Equal(t, 9*time.Second, a.TalkTime)
+	require.Equal(t, 3, a.Turns)
+	require.Equal(t, 2, a.Questions)
+	require.Equal(t, 5*time.Second, a.LongestMonologue)
+	require.Equal(t, 1, a.Interrupted)
+	require.InDelta(t, 9.0/13.5, a.TalkRatio, 1e-9)
+
+	require.Equal(t, "B", b.Speaker)
+	require.Equal(t, 1, b.Interruptions)
+	require.Equal(t, 4, b.Words)
+	require.InDelta(t, 4/(4.5/60), b.WordsPerMinute, 1e-9)
+
+	require.Len(t, stats.Channels, 2)
+	require.Equal(t, "1", stats.Channels[0].Speaker)
+	require.Equal(t, 9*time.Second, stats.Channels[0].TalkTime)
+
+	require.Len(t, stats.Series, 2)
+	require.Equal(t, 4*time.Second, stats.Series[0].TalkTime["A"])
+	require.Equal(t, 4500*time.Millisecond, stats.Series[0].TalkTime["B"])
+	require.Equal(t, 5*time.Second, stats.Series[1].TalkTime["A"])
+}
+
+func TestTranscript_ConversationStatsFromWords(t *testing.T) {
+	t.Parallel()
+
+	transcript := readTranscript(t, "testdata/transcript/completed.json")
+
+	stats := transcript.ConversationStats(nil)
+
+	require.Len(t, stats.Speakers, 1)
+	require.Equal(t, len(transcript.Words), stats.Speakers[0].Words)
+	require.InDelta(t, 1.0, stats.Speakers[0].TalkRatio, 1e-9)
+	require.Nil(t, stats.Channels)
+	require.Nil(t, stats.Series)
+}
+
+func TestTranscript_ConversationStatsOverlap(t *testing.T) {
+	t.Parallel()
+
+	transcript := readTranscript(t, "testdata/transcript/conversation-overlap.json")
+
+	stats := transcript.ConversationStats(nil)
+
+	// C talks over A, even though B's interruption ended before C started.
+	require.Equal(t, 2, stats.Interruptions)
+	require.Equal(t, 2*time.Second, stats.Overlap)
+
+	require.Equal(t, "A", stats.Speakers[0].Speaker)
+	require.Equal(t, 2, stats.Speakers[0].Interrupted)
+	require.Equal(t, "C", stats.Speakers[2].Speaker)
+	require.Equal(t, 1, stats.Speakers[2].Interruptions)
+}
@@ -0,0 +1,79 @@
+package assemblyai
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTranscript_Conversation(t *testing.T) {
+	t.Parallel()
+
+	// Words are sorted by channel, as returned for multichannel audio.
+	transcript := readTranscript(t, "testdata/transcript/multichannel.json")
+
+	conv := transcript.Conversation(&ConversationOptions{
+		Names: map[string]string{"1": "Agent", "2": "Customer"},
+	})
+
+	require.Len(t, conv.Turns, 4)
+
+	require.Equal(t, "Agent", conv.Turns[0].Name)
+	require.Equal(t, "Thanks for calling. How can I help?", conv.Turns[0].Text)
+	require.Equal(t, 3500*time.Millisecond, conv.Turns[0].End)
+
+	require.Equal(t, "Hi.", conv.Turns[1].Text)
+	require.True(t, conv.Turns[1].Overlapping)
+
+	require.Equal(t, "Customer", conv.Turns[2].Name)
+	require.False(t, conv.Turns[2].Overlapping)
+
+	require.Equal(t, "Agent", conv.Turns[3].Name)
+	require.Equal(t, 7*time.Second, conv.Turns[3].Start)
+
+	wantText := "Agent: Thanks for calling. How can I help?\n" +
+		"Customer: Hi.\n" +
+		"Customer: My card expired.\n" +
+		"Agent: Okay.\n"
+
+	require.Equal(t, wantText, conv.Text())
+
+	wantMarkdown := "**Agent** _(00:00)_: Thanks for calling. How can I help?\n" +
+		"\n**Customer** _(00:01, overlapping)_: Hi.\n" +
+		"\n**Customer** _(00:04)_: My card expired.\n" +
+		"\n**Agent** _(00:07)_: Okay.\n"
+
+	require.Equal(t, wantMarkdown, conv.Markdown())
+
+	b, err := conv.ChatJSON(map[string]string{"Agent": "assistant", "2": "user"})
+	require.NoError(t, err)
+	require.JSONEq(t, `[
+		{"role": "assistant", "content": "Thanks for calling. How can I help?"},
+		{"role": "user", "content": "Hi."},
+		{"role": "user", "content": "My card expired."},
+		{"role": "assistant", "content": "Okay."}
+	]`, string(b))
+
+	// Stats are computed from the same turns.
+	stats := transcript.ConversationStats(nil)
+	require.Equal(t, 1, stats.Interruptions)
+	require.Len(t, stats.Channels, 2)
+	require.Equal(t, 2, stats.Channels[0].Turns)
+	require.Equal(t, 2, stats.Channels[1].Turns)
+}
+
+func TestTranscript_ConversationFromUtterances(t *testing.T) {
+	t.Parallel()
+
+	transcript := Transcript{
+		Utterances: []TranscriptUtterance{
+			{Speaker: String("B"), Start: Int64(3000), End: Int64(4000), Text: String("Good morning.")},
+			{Speaker: String("A"), Start: Int64(0), End: Int64(2000), Text: String("Good morning, professor.")},
+		},
+	}
+
+	conv := transcript.Conversation(nil)
+
+	require.Equal(t, "Speaker A: Good morning, professor.\nSpeaker B: Good morning.\n", conv.Text())
+}
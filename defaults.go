@@ -0,0 +1,282 @@
+package assemblyai
+
+import (
+	"reflect"
+	"sort"
+	"sync"
+)
+
+// Names of the built-in transcript presets.
+const (
+	// Two-speaker phone calls with PII redaction and sentiment analysis.
+	TranscriptPresetCallCenter = "call-center"
+
+	// Long-form, multi-speaker audio with chapters, highlights and entities.
+	TranscriptPresetPodcast = "podcast"
+)
+
+var (
+	presetsMu sync.RWMutex
+	presets   = map[string]TranscriptOptionalParams{
+		TranscriptPresetCallCenter: {
+			SpeakerLabels:     Bool(true),
+			SpeakersExpected:  Int64(2),
+			SentimentAnalysis: Bool(true),
+			RedactPII:         Bool(true),
+			RedactPIIPolicies: []PIIPolicy{
+				PIIPolicyPersonName,
+				PIIPolicyPhoneNumber,
+				PIIPolicyEmailAddress,
+				PIIPolicyCreditCardNumber,
+				PIIPolicyCreditCardCVV,
+				PIIPolicyCreditCardExpiration,
+				PIIPolicyBankingInformation,
+				PIIPolicyUSSocialSecurityNumber,
+			},
+			RedactPIISub: SubstitutionPolicyEntityName,
+		},
+		TranscriptPresetPodcast: {
+			SpeakerLabels:   Bool(true),
+			AutoChapters:    Bool(true),
+			AutoHighlights:  Bool(true),
+			EntityDetection: Bool(true),
+		},
+	}
+)
+
+// RegisterTranscriptPreset registers a named set of transcript parameters,
+// replacing any existing preset with the same name.
+func RegisterTranscriptPreset(name string, params TranscriptOptionalParams) {
+	presetsMu.Lock()
+	defer presetsMu.Unlock()
+
+	presets[name] = params.Merge()
+}
+
+// TranscriptPreset returns a copy of the preset registered under name, and
+// whether it exists.
+func TranscriptPreset(name string) (TranscriptOptionalParams, bool) {
+	presetsMu.RLock()
+	defer presetsMu.RUnlock()
+
+	params, ok := presets[name]
+	if !ok {
+		return TranscriptOptionalParams{}, false
+	}
+
+	return params.Merge(), true
+}
+
+// TranscriptPresets returns the names of all registered presets in
+// alphabetical order.
+func TranscriptPresets() []string {
+	presetsMu.RLock()
+	defer presetsMu.RUnlock()
+
+	names := make([]string, 0, len(presets))
+	for name := range presets {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// WithDefaultTranscriptParams sets parameters that are used for every
+// transcript submitted by the client. Parameters passed to the Submit and
+// Transcribe methods are merged on top of them using
+// [TranscriptOptionalParams.Merge].
+//
+// The option can be used multiple times, for example to layer custom settings
+// on top of a preset:
+//
+//	params, ok := assemblyai.TranscriptPreset(assemblyai.TranscriptPresetPodcast)
+//	if !ok {
+//		// ...
+//	}
+//
+//	client := assemblyai.NewClientWithOptions(
+//		assemblyai.WithAPIKey(apiKey),
+//		assemblyai.WithDefaultTranscriptParams(params),
+//		assemblyai.WithDefaultTranscriptParams(assemblyai.TranscriptOptionalParams{
+//			SpeechModel: assemblyai.SpeechModelNano,
+//		}),
+//	)
+func WithDefaultTranscriptParams(params TranscriptOptionalParams) ClientOption {
+	return func(c *Client) {
+		merged := params.Merge()
+
+		if c.defaultParams != nil {
+			merged = c.defaultParams.Merge(&params)
+		}
+
+		c.defaultParams = &merged
+	}
+}
+
+// Merge returns a deep copy of p with each of the overrides layered on top, in
+// order.
+//
+// A field of an override replaces the current value if it's set: non-nil for
+// pointers and slices, non-empty for strings. This means that explicitly
+// setting a boolean to false disables a feature enabled by an earlier layer,
+// and an empty, non-nil slice clears it.
+//
+// Disabling a feature also clears the parameters of earlier layers that
+// depend on it, and enabling a feature clears the ones it can't be combined
+// with. For example, setting RedactPII to false clears RedactPIIPolicies and
+// RedactPIISub, and enabling LanguageDetection clears LanguageCode, unless
+// the same override sets them.
+func (p TranscriptOptionalParams) Merge(overrides ...*TranscriptOptionalParams) TranscriptOptionalParams {
+	var merged TranscriptOptionalParams
+
+	dst := reflect.ValueOf(&merged).Elem()
+
+	layers := append([]*TranscriptOptionalParams{&p}, overrides...)
+
+	for _, layer := range layers {
+		if layer == nil {
+			continue
+		}
+
+		src := reflect.ValueOf(layer).Elem()
+
+		for i := 0; i < src.NumField(); i++ {
+			if field := src.Field(i); !field.IsZero() {
+				dst.Field(i).Set(deepCopy(field))
+			}
+		}
+
+		clearDependents(&merged, layer)
+	}
+
+	return merged
+}
+
+// clearDependents clears the parameters of merged that depend on a feature
+// that layer disables, or conflict with a feature that layer enables, unless
+// layer sets them too.
+func clearDependents(merged, layer *TranscriptOptionalParams) {
+	disabled := func(b *bool) bool { return b != nil && !*b }
+
+	if disabled(layer.SpeakerLabels) && layer.SpeakersExpected == nil {
+		merged.SpeakersExpected = nil
+	}
+
+	if disabled(layer.Summarization) {
+		if layer.SummaryType == "" {
+			merged.SummaryType = ""
+		}
+		if layer.SummaryModel == "" {
+			merged.SummaryModel = ""
+		}
+	}
+
+	// Summarization and AutoChapters can't be enabled together.
+	if ToBool(layer.Summarization) && layer.AutoChapters == nil {
+		merged.AutoChapters = nil
+	}
+
+	if ToBool(layer.AutoChapters) && layer.Summarization == nil {
+		merged.Summarization = nil
+
+		if layer.SummaryType == "" {
+			merged.SummaryType = ""
+		}
+		if layer.SummaryModel == "" {
+			merged.SummaryModel = ""
+		}
+	}
+
+	if disabled(layer.RedactPII) {
+		if layer.RedactPIIPolicies == nil {
+			merged.RedactPIIPolicies = nil
+		}
+		if layer.RedactPIISub == "" {
+			merged.RedactPIISub = ""
+		}
+		if layer.RedactPIIAudio == nil {
+			merged.RedactPIIAudio = nil
+		}
+	}
+
+	if (disabled(layer.RedactPII) || disabled(layer.RedactPIIAudio)) && layer.RedactPIIAudioQuality == "" {
+		merged.RedactPIIAudioQuality = ""
+	}
+
+	if disabled(layer.ContentSafety) && layer.ContentSafetyConfidence == nil {
+		merged.ContentSafetyConfidence = nil
+	}
+
+	if disabled(layer.LanguageDetection) && layer.LanguageConfidenceThreshold == nil {
+		merged.LanguageConfidenceThreshold = nil
+	}
+
+	if ToBool(layer.LanguageDetection) && layer.LanguageCode == "" {
+		merged.LanguageCode = ""
+	}
+
+	if layer.LanguageCode != "" && layer.LanguageDetection == nil {
+		merged.LanguageDetection = nil
+		merged.LanguageConfidenceThreshold = nil
+	}
+
+	if ToBool(layer.Multichannel) && layer.DualChannel == nil {
+		merged.DualChannel = nil
+	}
+
+	if ToBool(layer.DualChannel) && layer.Multichannel == nil {
+		merged.Multichannel = nil
+	}
+
+	if layer.WordBoost != nil && len(layer.WordBoost) == 0 && layer.BoostParam == "" {
+		merged.BoostParam = ""
+	}
+}
+
+// withDefaults merges params over the default parameters of the client.
+func (c *Client) withDefaults(params *TranscriptOptionalParams) *TranscriptOptionalParams {
+	if c.defaultParams == nil {
+		return params
+	}
+
+	merged := c.defaultParams.Merge(params)
+
+	return &merged
+}
+
+// deepCopy returns a copy of v that shares no pointers or slices with it.
+func deepCopy(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return v
+		}
+
+		cp := reflect.New(v.Type().Elem())
+		cp.Elem().Set(deepCopy(v.Elem()))
+
+		return cp
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+
+		cp := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			cp.Index(i).Set(deepCopy(v.Index(i)))
+		}
+
+		return cp
+	case reflect.Struct:
+		cp := reflect.New(v.Type()).Elem()
+		for i := 0; i < v.NumField(); i++ {
+			cp.Field(i).Set(deepCopy(v.Field(i)))
+		}
+
+		return cp
+	default:
+		return v
+	}
+}
@@ -0,0 +1,174 @@
+package assemblyai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTranscriptOptionalParams_Merge(t *testing.T) {
+	t.Parallel()
+
+	defaults := TranscriptOptionalParams{
+		SpeechModel:       SpeechModelBest,
+		WebhookURL:        String("https://example.com/webhook"),
+		SpeakerLabels:     Bool(true),
+		WordBoost:         []string{"AssemblyAI"},
+		RedactPIIPolicies: []PIIPolicy{"person_name"},
+		CustomSpelling: []TranscriptCustomSpelling{
+			{From: []string{"assembly ai"}, To: String("AssemblyAI")},
+		},
+	}
+
+	merged := defaults.Merge(nil, &TranscriptOptionalParams{
+		SpeechModel:       SpeechModelNano,
+		SpeakerLabels:     Bool(false),
+		RedactPIIPolicies: []PIIPolicy{},
+		LanguageCode:      "de",
+	})
+
+	require.Equal(t, SpeechModelNano, merged.SpeechModel)
+	require.Equal(t, "https://example.com/webhook", ToString(merged.WebhookURL))
+	require.False(t, ToBool(merged.SpeakerLabels))
+	require.NotNil(t, merged.SpeakerLabels)
+	require.Equal(t, []string{"AssemblyAI"}, merged.WordBoost)
+	require.Empty(t, merged.RedactPIIPolicies)
+	require.Equal(t, TranscriptLanguageCode("de"), merged.LanguageCode)
+
+	// The result doesn't share memory with its layers.
+	*merged.WebhookURL = "https://example.com/other"
+	merged.WordBoost[0] = "other"
+	merged.CustomSpelling[0].From[0] = "other"
+
+	require.Equal(t, "https://example.com/webhook", *defaults.WebhookURL)
+	require.Equal(t, "AssemblyAI", defaults.WordBoost[0])
+	require.Equal(t, "assembly ai", defaults.CustomSpelling[0].From[0])
+
+	// Disabling or switching features clears what depends on them.
+	merged = TranscriptOptionalParams{
+		LanguageCode:            "de",
+		Summarization:           Bool(true),
+		SummaryType:             SummaryTypeBullets,
+		ContentSafety:           Bool(true),
+		ContentSafetyConfidence: Int64(60),
+	}.Merge(&TranscriptOptionalParams{
+		LanguageDetection: Bool(true),
+		Summarization:     Bool(false),
+		ContentSafety:     Bool(false),
+	})
+
+	require.Empty(t, merged.LanguageCode)
+	require.Empty(t, merged.SummaryType)
+	require.Nil(t, merged.ContentSafetyConfidence)
+	require.NoError(t, merged.Validate())
+
+	// Summarization and AutoChapters replace each other.
+	podcast, ok := TranscriptPreset(TranscriptPresetPodcast)
+	require.True(t, ok)
+
+	merged = podcast.Merge(&TranscriptOptionalParams{Summarization: Bool(true), SummaryType: SummaryTypeBullets})
+
+	require.True(t, ToBool(merged.Summarization))
+	require.Nil(t, merged.AutoChapters)
+	require.NoError(t, merged.Validate())
+
+	merged = merged.Merge(&TranscriptOptionalParams{AutoChapters: Bool(true)})
+
+	require.True(t, ToBool(merged.AutoChapters))
+	require.Nil(t, merged.Summarization)
+	require.Empty(t, merged.SummaryType)
+	require.NoError(t, merged.Validate())
+}
+
+func TestTranscriptPresets(t *testing.T) {
+	t.Parallel()
+
+	for _, name := range []string{TranscriptPresetCallCenter, TranscriptPresetPodcast} {
+		params, ok := TranscriptPreset(name)
+		require.True(t, ok, name)
+		require.NoError(t, params.Validate(), name)
+	}
+
+	RegisterTranscriptPreset("test-preset", TranscriptOptionalParams{Punctuate: Bool(false)})
+
+	params, ok := TranscriptPreset("test-preset")
+	require.True(t, ok)
+	require.False(t, ToBool(params.Punctuate))
+
+	require.Contains(t, TranscriptPresets(), "test-preset")
+
+	_, ok = TranscriptPreset("missing")
+	require.False(t, ok)
+}
+
+func TestTranscripts_DefaultParams(t *testing.T) {
+	t.Parallel()
+
+	client, handler, teardown := setup()
+	defer teardown()
+
+	var body map[string]interface{}
+
+	handler.HandleFunc("/v2/transcript", func(w http.ResponseWriter, r *http.Request) {
+		body = nil
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"id": %q, "status": "queued"}`, fakeTranscriptID)
+	})
+
+	preset, ok := TranscriptPreset(TranscriptPresetCallCenter)
+	require.True(t, ok)
+
+	WithDefaultTranscriptParams(preset)(client)
+	WithDefaultTranscriptParams(TranscriptOptionalParams{
+		SpeechModel: SpeechModelBest,
+		WordBoost:   []string{"refund"},
+	})(client)
+
+	ctx := context.Background()
+
+	_, err := client.Transcripts.SubmitFromURL(ctx, fakeAudioURL, nil)
+	require.NoError(t, err)
+
+	require.Equal(t, fakeAudioURL, body["audio_url"])
+	require.Equal(t, "best", body["speech_model"])
+	require.Equal(t, true, body["speaker_labels"])
+	require.Equal(t, true, body["redact_pii"])
+	require.Equal(t, []interface{}{"refund"}, body["word_boost"])
+
+	_, err = client.Transcripts.SubmitFromURL(ctx, fakeAudioURL, &TranscriptOptionalParams{
+		SpeakersExpected: Int64(3),
+		SpeechModel:      SpeechModelNano,
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, "nano", body["speech_model"])
+	require.Equal(t, float64(3), body["speakers_expected"])
+	require.Equal(t, []interface{}{"refund"}, body["word_boost"])
+
+	// Disabling a feature of the defaults drops the parameters that depend on
+	// it.
+	_, err = client.Transcripts.SubmitFromURL(ctx, fakeAudioURL, &TranscriptOptionalParams{
+		SpeakerLabels: Bool(false),
+		RedactPII:     Bool(false),
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, false, body["speaker_labels"])
+	require.Equal(t, false, body["redact_pii"])
+	require.NotContains(t, body, "speakers_expected")
+	require.NotContains(t, body, "redact_pii_policies")
+	require.NotContains(t, body, "redact_pii_sub")
+
+	// Overrides that conflict with the defaults are caught by validation.
+	_, err = client.Transcripts.SubmitFromURL(ctx, fakeAudioURL, &TranscriptOptionalParams{
+		SpeakerLabels:    Bool(false),
+		SpeakersExpected: Int64(3),
+	})
+	require.ErrorIs(t, err, ErrInvalidParams)
+}
@@ -0,0 +1,943 @@
+package assemblyai
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrUnknownValue is returned when parsing a value that isn't known to the
+// SDK.
+var ErrUnknownValue = errors.New("unknown value")
+
+// enumValue is a known value of an enum-like string type.
+type enumValue struct {
+	value string
+	name  string
+}
+
+// enumTable lists the known values of an enum-like string type, in the order
+// they should be presented in.
+type enumTable []enumValue
+
+func (t enumTable) name(value string) string {
+	for _, v := range t {
+		if v.value == value {
+			return v.name
+		}
+	}
+	return value
+}
+
+func (t enumTable) valid(value string) bool {
+	for _, v := range t {
+		if v.value == value {
+			return true
+		}
+	}
+	return false
+}
+
+func (t enumTable) parse(kind, s string) (string, error) {
+	s = strings.TrimSpace(s)
+
+	for _, v := range t {
+		if strings.EqualFold(v.value, s) || strings.EqualFold(v.name, s) {
+			return v.value, nil
+		}
+	}
+
+	return "", fmt.Errorf("%w: %s %q", ErrUnknownValue, kind, s)
+}
+
+const (
+	EntityTypeAccountNumber          EntityType = "account_number"
+	EntityTypeBankingInformation     EntityType = "banking_information"
+	EntityTypeBloodType              EntityType = "blood_type"
+	EntityTypeCreditCardCVV          EntityType = "credit_card_cvv"
+	EntityTypeCreditCardExpiration   EntityType = "credit_card_expiration"
+	EntityTypeCreditCardNumber       EntityType = "credit_card_number"
+	EntityTypeDate                   EntityType = "date"
+	EntityTypeDateInterval           EntityType = "date_interval"
+	EntityTypeDateOfBirth            EntityType = "date_of_birth"
+	EntityTypeDriversLicense         EntityType = "drivers_license"
+	EntityTypeDrug                   EntityType = "drug"
+	EntityTypeDuration               EntityType = "duration"
+	EntityTypeEmailAddress           EntityType = "email_address"
+	EntityTypeEvent                  EntityType = "event"
+	EntityTypeFilename               EntityType = "filename"
+	EntityTypeGenderSexuality        EntityType = "gender_sexuality"
+	EntityTypeHealthcareNumber       EntityType = "healthcare_number"
+	EntityTypeInjury                 EntityType = "injury"
+	EntityTypeIPAddress              EntityType = "ip_address"
+	EntityTypeLanguage               EntityType = "language"
+	EntityTypeLocation               EntityType = "location"
+	EntityTypeMaritalStatus          EntityType = "marital_status"
+	EntityTypeMedicalCondition       EntityType = "medical_condition"
+	EntityTypeMedicalProcess         EntityType = "medical_process"
+	EntityTypeMoneyAmount            EntityType = "money_amount"
+	EntityTypeNationality            EntityType = "nationality"
+	EntityTypeNumberSequence         EntityType = "number_sequence"
+	EntityTypeOccupation             EntityType = "occupation"
+	EntityTypeOrganization           EntityType = "organization"
+	EntityTypePassportNumber         EntityType = "passport_number"
+	EntityTypePassword               EntityType = "password"
+	EntityTypePersonAge              EntityType = "person_age"
+	EntityTypePersonName             EntityType = "person_name"
+	EntityTypePhoneNumber            EntityType = "phone_number"
+	EntityTypePhysicalAttribute      EntityType = "physical_attribute"
+	EntityTypePoliticalAffiliation   EntityType = "political_affiliation"
+	EntityTypeReligion               EntityType = "religion"
+	EntityTypeStatistics             EntityType = "statistics"
+	EntityTypeTime                   EntityType = "time"
+	EntityTypeURL                    EntityType = "url"
+	EntityTypeUSSocialSecurityNumber EntityType = "us_social_security_number"
+	EntityTypeUsername               EntityType = "username"
+	EntityTypeVehicleID              EntityType = "vehicle_id"
+	EntityTypeZodiacSign             EntityType = "zodiac_sign"
+)
+
+const (
+	PIIPolicyAccountNumber          PIIPolicy = "account_number"
+	PIIPolicyBankingInformation     PIIPolicy = "banking_information"
+	PIIPolicyBloodType              PIIPolicy = "blood_type"
+	PIIPolicyCreditCardCVV          PIIPolicy = "credit_card_cvv"
+	PIIPolicyCreditCardExpiration   PIIPolicy = "credit_card_expiration"
+	PIIPolicyCreditCardNumber       PIIPolicy = "credit_card_number"
+	PIIPolicyDate                   PIIPolicy = "date"
+	PIIPolicyDateInterval           PIIPolicy = "date_interval"
+	PIIPolicyDateOfBirth            PIIPolicy = "date_of_birth"
+	PIIPolicyDriversLicense         PIIPolicy = "drivers_license"
+	PIIPolicyDrug                   PIIPolicy = "drug"
+	PIIPolicyDuration               PIIPolicy = "duration"
+	PIIPolicyEmailAddress           PIIPolicy = "email_address"
+	PIIPolicyEvent                  PIIPolicy = "event"
+	PIIPolicyFilename               PIIPolicy = "filename"
+	PIIPolicyGenderSexuality        PIIPolicy = "gender_sexuality"
+	PIIPolicyHealthcareNumber       PIIPolicy = "healthcare_number"
+	PIIPolicyInjury                 PIIPolicy = "injury"
+	PIIPolicyIPAddress              PIIPolicy = "ip_address"
+	PIIPolicyLanguage               PIIPolicy = "language"
+	PIIPolicyLocation               PIIPolicy = "location"
+	PIIPolicyMaritalStatus          PIIPolicy = "marital_status"
+	PIIPolicyMedicalCondition       PIIPolicy = "medical_condition"
+	PIIPolicyMedicalProcess         PIIPolicy = "medical_process"
+	PIIPolicyMoneyAmount            PIIPolicy = "money_amount"
+	PIIPolicyNationality            PIIPolicy = "nationality"
+	PIIPolicyNumberSequence         PIIPolicy = "number_sequence"
+	PIIPolicyOccupation             PIIPolicy = "occupation"
+	PIIPolicyOrganization           PIIPolicy = "organization"
+	PIIPolicyPassportNumber         PIIPolicy = "passport_number"
+	PIIPolicyPassword               PIIPolicy = "password"
+	PIIPolicyPersonAge              PIIPolicy = "person_age"
+	PIIPolicyPersonName             PIIPolicy = "person_name"
+	PIIPolicyPhoneNumber            PIIPolicy = "phone_number"
+	PIIPolicyPhysicalAttribute      PIIPolicy = "physical_attribute"
+	PIIPolicyPoliticalAffiliation   PIIPolicy = "political_affiliation"
+	PIIPolicyReligion               PIIPolicy = "religion"
+	PIIPolicyStatistics             PIIPolicy = "statistics"
+	PIIPolicyTime                   PIIPolicy = "time"
+	PIIPolicyURL                    PIIPolicy = "url"
+	PIIPolicyUSSocialSecurityNumber PIIPolicy = "us_social_security_number"
+	PIIPolicyUsername               PIIPolicy = "username"
+	PIIPolicyVehicleID              PIIPolicy = "vehicle_id"
+	PIIPolicyZodiacSign             PIIPolicy = "zodiac_sign"
+)
+
+const (
+	// Replace PII with the name of the entity, for example "[PERSON_NAME]".
+	SubstitutionPolicyEntityName SubstitutionPolicy = "entity_name"
+
+	// Replace each character of PII with "#".
+	SubstitutionPolicyHash SubstitutionPolicy = "hash"
+)
+
+const (
+	// SubRip subtitles.
+	SubtitleFormatSRT SubtitleFormat = "srt"
+
+	// Web Video Text Tracks subtitles.
+	SubtitleFormatVTT SubtitleFormat = "vtt"
+)
+
+const (
+	// Best for files with a single speaker such as presentations or lectures.
+	SummaryModelInformative SummaryModel = "informative"
+
+	// Best for any 2 person conversation such as customer/agent or interview/interviewee calls. Requires speaker labels or multichannel transcription.
+	SummaryModelConversational SummaryModel = "conversational"
+
+	// Best for creating video, podcast, or media titles.
+	SummaryModelCatchy SummaryModel = "catchy"
+)
+
+const (
+	// A bulleted summary with the most important points.
+	SummaryTypeBullets SummaryType = "bullets"
+
+	// A longer bullet point list summarizing the entire transcription text.
+	SummaryTypeBulletsVerbose SummaryType = "bullets_verbose"
+
+	// A few words summarizing the entire transcription text.
+	SummaryTypeGist SummaryType = "gist"
+
+	// A single sentence summarizing the entire transcription text.
+	SummaryTypeHeadline SummaryType = "headline"
+
+	// A single paragraph summarizing the entire transcription text.
+	SummaryTypeParagraph SummaryType = "paragraph"
+)
+
+const (
+	TranscriptLanguageCodeEnglish           TranscriptLanguageCode = "en"
+	TranscriptLanguageCodeEnglishAustralian TranscriptLanguageCode = "en_au"
+	TranscriptLanguageCodeEnglishBritish    TranscriptLanguageCode = "en_uk"
+	TranscriptLanguageCodeEnglishUS         TranscriptLanguageCode = "en_us"
+	TranscriptLanguageCodeSpanish           TranscriptLanguageCode = "es"
+	TranscriptLanguageCodeFrench            TranscriptLanguageCode = "fr"
+	TranscriptLanguageCodeGerman            TranscriptLanguageCode = "de"
+	TranscriptLanguageCodeItalian           TranscriptLanguageCode = "it"
+	TranscriptLanguageCodePortuguese        TranscriptLanguageCode = "pt"
+	TranscriptLanguageCodeDutch             TranscriptLanguageCode = "nl"
+	TranscriptLanguageCodeAfrikaans         TranscriptLanguageCode = "af"
+	TranscriptLanguageCodeAlbanian          TranscriptLanguageCode = "sq"
+	TranscriptLanguageCodeAmharic           TranscriptLanguageCode = "am"
+	TranscriptLanguageCodeArabic            TranscriptLanguageCode = "ar"
+	TranscriptLanguageCodeArmenian          TranscriptLanguageCode = "hy"
+	TranscriptLanguageCodeAssamese          TranscriptLanguageCode = "as"
+	TranscriptLanguageCodeAzerbaijani       TranscriptLanguageCode = "az"
+	TranscriptLanguageCodeBashkir           TranscriptLanguageCode = "ba"
+	TranscriptLanguageCodeBasque            TranscriptLanguageCode = "eu"
+	TranscriptLanguageCodeBelarusian        TranscriptLanguageCode = "be"
+	TranscriptLanguageCodeBengali           TranscriptLanguageCode = "bn"
+	TranscriptLanguageCodeBosnian           TranscriptLanguageCode = "bs"
+	TranscriptLanguageCodeBreton            TranscriptLanguageCode = "br"
+	TranscriptLanguageCodeBulgarian         TranscriptLanguageCode = "bg"
+	TranscriptLanguageCodeBurmese           TranscriptLanguageCode = "my"
+	TranscriptLanguageCodeCatalan           TranscriptLanguageCode = "ca"
+	TranscriptLanguageCodeChinese           TranscriptLanguageCode = "zh"
+	TranscriptLanguageCodeCroatian          TranscriptLanguageCode = "hr"
+	TranscriptLanguageCodeCzech             TranscriptLanguageCode = "cs"
+	TranscriptLanguageCodeDanish            TranscriptLanguageCode = "da"
+	TranscriptLanguageCodeEstonian          TranscriptLanguageCode = "et"
+	TranscriptLanguageCodeFaroese           TranscriptLanguageCode = "fo"
+	TranscriptLanguageCodeFinnish           TranscriptLanguageCode = "fi"
+	TranscriptLanguageCodeGalician          TranscriptLanguageCode = "gl"
+	TranscriptLanguageCodeGeorgian          TranscriptLanguageCode = "ka"
+	TranscriptLanguageCodeGreek             TranscriptLanguageCode = "el"
+	TranscriptLanguageCodeGujarati          TranscriptLanguageCode = "gu"
+	TranscriptLanguageCodeHaitian           TranscriptLanguageCode = "ht"
+	TranscriptLanguageCodeHausa             TranscriptLanguageCode = "ha"
+	TranscriptLanguageCodeHawaiian          TranscriptLanguageCode = "haw"
+	TranscriptLanguageCodeHebrew            TranscriptLanguageCode = "he"
+	TranscriptLanguageCodeHindi             TranscriptLanguageCode = "hi"
+	TranscriptLanguageCodeHungarian         TranscriptLanguageCode = "hu"
+	TranscriptLanguageCodeIcelandic         TranscriptLanguageCode = "is"
+	TranscriptLanguageCodeIndonesian        TranscriptLanguageCode = "id"
+	TranscriptLanguageCodeJapanese          TranscriptLanguageCode = "ja"
+	TranscriptLanguageCodeJavanese          TranscriptLanguageCode = "jw"
+	TranscriptLanguageCodeKannada           TranscriptLanguageCode = "kn"
+	TranscriptLanguageCodeKazakh            TranscriptLanguageCode = "kk"
+	TranscriptLanguageCodeKhmer             TranscriptLanguageCode = "km"
+	TranscriptLanguageCodeKorean            TranscriptLanguageCode = "ko"
+	TranscriptLanguageCodeLao               TranscriptLanguageCode = "lo"
+	TranscriptLanguageCodeLatin             TranscriptLanguageCode = "la"
+	TranscriptLanguageCodeLatvian           TranscriptLanguageCode = "lv"
+	TranscriptLanguageCodeLingala           TranscriptLanguageCode = "ln"
+	TranscriptLanguageCodeLithuanian        TranscriptLanguageCode = "lt"
+	TranscriptLanguageCodeLuxembourgish     TranscriptLanguageCode = "lb"
+	TranscriptLanguageCodeMacedonian        TranscriptLanguageCode = "mk"
+	TranscriptLanguageCodeMalagasy          TranscriptLanguageCode = "mg"
+	TranscriptLanguageCodeMalay             TranscriptLanguageCode = "ms"
+	TranscriptLanguageCodeMalayalam         TranscriptLanguageCode = "ml"
+	TranscriptLanguageCodeMaltese           TranscriptLanguageCode = "mt"
+	TranscriptLanguageCodeMaori             TranscriptLanguageCode = "mi"
+	TranscriptLanguageCodeMarathi           TranscriptLanguageCode = "mr"
+	TranscriptLanguageCodeMongolian         TranscriptLanguageCode = "mn"
+	TranscriptLanguageCodeNepali            TranscriptLanguageCode = "ne"
+	TranscriptLanguageCodeNorwegian         TranscriptLanguageCode = "no"
+	TranscriptLanguageCodeNorwegianNynorsk  TranscriptLanguageCode = "nn"
+	TranscriptLanguageCodeOccitan           TranscriptLanguageCode = "oc"
+	TranscriptLanguageCodePanjabi           TranscriptLanguageCode = "pa"
+	TranscriptLanguageCodePashto            TranscriptLanguageCode = "ps"
+	TranscriptLanguageCodePersian           TranscriptLanguageCode = "fa"
+	TranscriptLanguageCodePolish            TranscriptLanguageCode = "pl"
+	TranscriptLanguageCodeRomanian          TranscriptLanguageCode = "ro"
+	TranscriptLanguageCodeRussian           TranscriptLanguageCode = "ru"
+	TranscriptLanguageCodeSanskrit          TranscriptLanguageCode = "sa"
+	TranscriptLanguageCodeSerbian           TranscriptLanguageCode = "sr"
+	TranscriptLanguageCodeShona             TranscriptLanguageCode = "sn"
+	TranscriptLanguageCodeSindhi            TranscriptLanguageCode = "sd"
+	TranscriptLanguageCodeSinhala           TranscriptLanguageCode = "si"
+	TranscriptLanguageCodeSlovak            TranscriptLanguageCode = "sk"
+	TranscriptLanguageCodeSlovenian         TranscriptLanguageCode = "sl"
+	TranscriptLanguageCodeSomali            TranscriptLanguageCode = "so"
+	TranscriptLanguageCodeSundanese         TranscriptLanguageCode = "su"
+	TranscriptLanguageCodeSwahili           TranscriptLanguageCode = "sw"
+	TranscriptLanguageCodeSwedish           TranscriptLanguageCode = "sv"
+	TranscriptLanguageCodeTagalog           TranscriptLanguageCode = "tl"
+	TranscriptLanguageCodeTajik             TranscriptLanguageCode = "tg"
+	TranscriptLanguageCodeTamil             TranscriptLanguageCode = "ta"
+	TranscriptLanguageCodeTatar             TranscriptLanguageCode = "tt"
+	TranscriptLanguageCodeTelugu            TranscriptLanguageCode = "te"
+	TranscriptLanguageCodeThai              TranscriptLanguageCode = "th"
+	TranscriptLanguageCodeTibetan           TranscriptLanguageCode = "bo"
+	TranscriptLanguageCodeTurkish           TranscriptLanguageCode = "tr"
+	TranscriptLanguageCodeTurkmen           TranscriptLanguageCode = "tk"
+	TranscriptLanguageCodeUkrainian         TranscriptLanguageCode = "uk"
+	TranscriptLanguageCodeUrdu              TranscriptLanguageCode = "ur"
+	TranscriptLanguageCodeUzbek             TranscriptLanguageCode = "uz"
+	TranscriptLanguageCodeVietnamese        TranscriptLanguageCode = "vi"
+	TranscriptLanguageCodeWelsh             TranscriptLanguageCode = "cy"
+	TranscriptLanguageCodeYiddish           TranscriptLanguageCode = "yi"
+	TranscriptLanguageCodeYoruba            TranscriptLanguageCode = "yo"
+)
+
+const (
+	TranscriptBoostParamLow     TranscriptBoostParam = "low"
+	TranscriptBoostParamDefault TranscriptBoostParam = "default"
+	TranscriptBoostParamHigh    TranscriptBoostParam = "high"
+)
+
+const (
+	// A compressed MP3 file. This is the default.
+	RedactPIIAudioQualityMP3 RedactPIIAudioQuality = "mp3"
+
+	// An uncompressed WAV file.
+	RedactPIIAudioQualityWAV RedactPIIAudioQuality = "wav"
+)
+
+const (
+	SentimentPositive Sentiment = "POSITIVE"
+	SentimentNeutral  Sentiment = "NEUTRAL"
+	SentimentNegative Sentiment = "NEGATIVE"
+)
+
+var entityTypeTable = enumTable{
+	{string(EntityTypeAccountNumber), "Account number"},
+	{string(EntityTypeBankingInformation), "Banking information"},
+	{string(EntityTypeBloodType), "Blood type"},
+	{string(EntityTypeCreditCardCVV), "Credit card CVV"},
+	{string(EntityTypeCreditCardExpiration), "Credit card expiration"},
+	{string(EntityTypeCreditCardNumber), "Credit card number"},
+	{string(EntityTypeDate), "Date"},
+	{string(EntityTypeDateInterval), "Date interval"},
+	{string(EntityTypeDateOfBirth), "Date of birth"},
+	{string(EntityTypeDriversLicense), "Driver's license"},
+	{string(EntityTypeDrug), "Drug"},
+	{string(EntityTypeDuration), "Duration"},
+	{string(EntityTypeEmailAddress), "Email address"},
+	{string(EntityTypeEvent), "Event"},
+	{string(EntityTypeFilename), "Filename"},
+	{string(EntityTypeGenderSexuality), "Gender and sexuality"},
+	{string(EntityTypeHealthcareNumber), "Healthcare number"},
+	{string(EntityTypeInjury), "Injury"},
+	{string(EntityTypeIPAddress), "IP address"},
+	{string(EntityTypeLanguage), "Language"},
+	{string(EntityTypeLocation), "Location"},
+	{string(EntityTypeMaritalStatus), "Marital status"},
+	{string(EntityTypeMedicalCondition), "Medical condition"},
+	{string(EntityTypeMedicalProcess), "Medical process"},
+	{string(EntityTypeMoneyAmount), "Money amount"},
+	{string(EntityTypeNationality), "Nationality"},
+	{string(EntityTypeNumberSequence), "Number sequence"},
+	{string(EntityTypeOccupation), "Occupation"},
+	{string(EntityTypeOrganization), "Organization"},
+	{string(EntityTypePassportNumber), "Passport number"},
+	{string(EntityTypePassword), "Password"},
+	{string(EntityTypePersonAge), "Person age"},
+	{string(EntityTypePersonName), "Person name"},
+	{string(EntityTypePhoneNumber), "Phone number"},
+	{string(EntityTypePhysicalAttribute), "Physical attribute"},
+	{string(EntityTypePoliticalAffiliation), "Political affiliation"},
+	{string(EntityTypeReligion), "Religion"},
+	{string(EntityTypeStatistics), "Statistics"},
+	{string(EntityTypeTime), "Time"},
+	{string(EntityTypeURL), "URL"},
+	{string(EntityTypeUSSocialSecurityNumber), "US Social Security number"},
+	{string(EntityTypeUsername), "Username"},
+	{string(EntityTypeVehicleID), "Vehicle ID"},
+	{string(EntityTypeZodiacSign), "Zodiac sign"},
+}
+
+// EntityTypes returns all known values of [EntityType].
+func EntityTypes() []EntityType {
+	values := make([]EntityType, len(entityTypeTable))
+	for i, v := range entityTypeTable {
+		values[i] = EntityType(v.value)
+	}
+	return values
+}
+
+// Name returns the human-readable name, or the raw value if it's unknown.
+func (e EntityType) Name() string {
+	return entityTypeTable.name(string(e))
+}
+
+// Valid reports whether e is a known entity type.
+func (e EntityType) Valid() bool {
+	return entityTypeTable.valid(string(e))
+}
+
+// ParseEntityType looks up an entity type by its value or name, ignoring case.
+// It returns an error wrapping [ErrUnknownValue] if there's no match.
+func ParseEntityType(s string) (EntityType, error) {
+	v, err := entityTypeTable.parse("entity type", s)
+	return EntityType(v), err
+}
+
+var piiPolicyTable = enumTable{
+	{string(PIIPolicyAccountNumber), "Account number"},
+	{string(PIIPolicyBankingInformation), "Banking information"},
+	{string(PIIPolicyBloodType), "Blood type"},
+	{string(PIIPolicyCreditCardCVV), "Credit card CVV"},
+	{string(PIIPolicyCreditCardExpiration), "Credit card expiration"},
+	{string(PIIPolicyCreditCardNumber), "Credit card number"},
+	{string(PIIPolicyDate), "Date"},
+	{string(PIIPolicyDateInterval), "Date interval"},
+	{string(PIIPolicyDateOfBirth), "Date of birth"},
+	{string(PIIPolicyDriversLicense), "Driver's license"},
+	{string(PIIPolicyDrug), "Drug"},
+	{string(PIIPolicyDuration), "Duration"},
+	{string(PIIPolicyEmailAddress), "Email address"},
+	{string(PIIPolicyEvent), "Event"},
+	{string(PIIPolicyFilename), "Filename"},
+	{string(PIIPolicyGenderSexuality), "Gender and sexuality"},
+	{string(PIIPolicyHealthcareNumber), "Healthcare number"},
+	{string(PIIPolicyInjury), "Injury"},
+	{string(PIIPolicyIPAddress), "IP address"},
+	{string(PIIPolicyLanguage), "Language"},
+	{string(PIIPolicyLocation), "Location"},
+	{string(PIIPolicyMaritalStatus), "Marital status"},
+	{string(PIIPolicyMedicalCondition), "Medical condition"},
+	{string(PIIPolicyMedicalProcess), "Medical process"},
+	{string(PIIPolicyMoneyAmount), "Money amount"},
+	{string(PIIPolicyNationality), "Nationality"},
+	{string(PIIPolicyNumberSequence), "Number sequence"},
+	{string(PIIPolicyOccupation), "Occupation"},
+	{string(PIIPolicyOrganization), "Organization"},
+	{string(PIIPolicyPassportNumber), "Passport number"},
+	{string(PIIPolicyPassword), "Password"},
+	{string(PIIPolicyPersonAge), "Person age"},
+	{string(PIIPolicyPersonName), "Person name"},
+	{string(PIIPolicyPhoneNumber), "Phone number"},
+	{string(PIIPolicyPhysicalAttribute), "Physical attribute"},
+	{string(PIIPolicyPoliticalAffiliation), "Political affiliation"},
+	{string(PIIPolicyReligion), "Religion"},
+	{string(PIIPolicyStatistics), "Statistics"},
+	{string(PIIPolicyTime), "Time"},
+	{string(PIIPolicyURL), "URL"},
+	{string(PIIPolicyUSSocialSecurityNumber), "US Social Security number"},
+	{string(PIIPolicyUsername), "Username"},
+	{string(PIIPolicyVehicleID), "Vehicle ID"},
+	{string(PIIPolicyZodiacSign), "Zodiac sign"},
+}
+
+// PIIPolicies returns all known values of [PIIPolicy].
+func PIIPolicies() []PIIPolicy {
+	values := make([]PIIPolicy, len(piiPolicyTable))
+	for i, v := range piiPolicyTable {
+		values[i] = PIIPolicy(v.value)
+	}
+	return values
+}
+
+// Name returns the human-readable name, or the raw value if it's unknown.
+func (p PIIPolicy) Name() string {
+	return piiPolicyTable.name(string(p))
+}
+
+// Valid reports whether p is a known PII policy.
+func (p PIIPolicy) Valid() bool {
+	return piiPolicyTable.valid(string(p))
+}
+
+// ParsePIIPolicy looks up a PII policy by its value or name, ignoring case.
+// It returns an error wrapping [ErrUnknownValue] if there's no match.
+func ParsePIIPolicy(s string) (PIIPolicy, error) {
+	v, err := piiPolicyTable.parse("PII policy", s)
+	return PIIPolicy(v), err
+}
+
+var substitutionPolicyTable = enumTable{
+	{string(SubstitutionPolicyEntityName), "Entity name"},
+	{string(SubstitutionPolicyHash), "Hash"},
+}
+
+// SubstitutionPolicies returns all known values of [SubstitutionPolicy].
+func SubstitutionPolicies() []SubstitutionPolicy {
+	values := make([]SubstitutionPolicy, len(substitutionPolicyTable))
+	for i, v := range substitutionPolicyTable {
+		values[i] = SubstitutionPolicy(v.value)
+	}
+	return values
+}
+
+// Name returns the human-readable name, or the raw value if it's unknown.
+func (s SubstitutionPolicy) Name() string {
+	return substitutionPolicyTable.name(string(s))
+}
+
+// Valid reports whether s is a known substitution policy.
+func (s SubstitutionPolicy) Valid() bool {
+	return substitutionPolicyTable.valid(string(s))
+}
+
+// ParseSubstitutionPolicy looks up a substitution policy by its value or name, ignoring case.
+// It returns an error wrapping [ErrUnknownValue] if there's no match.
+func ParseSubstitutionPolicy(s string) (SubstitutionPolicy, error) {
+	v, err := substitutionPolicyTable.parse("substitution policy", s)
+	return SubstitutionPolicy(v), err
+}
+
+var summaryModelTable = enumTable{
+	{string(SummaryModelInformative), "Informative"},
+	{string(SummaryModelConversational), "Conversational"},
+	{string(SummaryModelCatchy), "Catchy"},
+}
+
+// SummaryModels returns all known values of [SummaryModel].
+func SummaryModels() []SummaryModel {
+	values := make([]SummaryModel, len(summaryModelTable))
+	for i, v := range summaryModelTable {
+		values[i] = SummaryModel(v.value)
+	}
+	return values
+}
+
+// Name returns the human-readable name, or the raw value if it's unknown.
+func (s SummaryModel) Name() string {
+	return summaryModelTable.name(string(s))
+}
+
+// Valid reports whether s is a known summary model.
+func (s SummaryModel) Valid() bool {
+	return summaryModelTable.valid(string(s))
+}
+
+// ParseSummaryModel looks up a summary model by its value or name, ignoring case.
+// It returns an error wrapping [ErrUnknownValue] if there's no match.
+func ParseSummaryModel(s string) (SummaryModel, error) {
+	v, err := summaryModelTable.parse("summary model", s)
+	return SummaryModel(v), err
+}
+
+var summaryTypeTable = enumTable{
+	{string(SummaryTypeBullets), "Bullets"},
+	{string(SummaryTypeBulletsVerbose), "Bullets (verbose)"},
+	{string(SummaryTypeGist), "Gist"},
+	{string(SummaryTypeHeadline), "Headline"},
+	{string(SummaryTypeParagraph), "Paragraph"},
+}
+
+// SummaryTypes returns all known values of [SummaryType].
+func SummaryTypes() []SummaryType {
+	values := make([]SummaryType, len(summaryTypeTable))
+	for i, v := range summaryTypeTable {
+		values[i] = SummaryType(v.value)
+	}
+	return values
+}
+
+// Name returns the human-readable name, or the raw value if it's unknown.
+func (s SummaryType) Name() string {
+	return summaryTypeTable.name(string(s))
+}
+
+// Valid reports whether s is a known summary type.
+func (s SummaryType) Valid() bool {
+	return summaryTypeTable.valid(string(s))
+}
+
+// ParseSummaryType looks up a summary type by its value or name, ignoring case.
+// It returns an error wrapping [ErrUnknownValue] if there's no match.
+func ParseSummaryType(s string) (SummaryType, error) {
+	v, err := summaryTypeTable.parse("summary type", s)
+	return SummaryType(v), err
+}
+
+var subtitleFormatTable = enumTable{
+	{string(SubtitleFormatSRT), "SubRip"},
+	{string(SubtitleFormatVTT), "WebVTT"},
+}
+
+// SubtitleFormats returns all known values of [SubtitleFormat].
+func SubtitleFormats() []SubtitleFormat {
+	values := make([]SubtitleFormat, len(subtitleFormatTable))
+	for i, v := range subtitleFormatTable {
+		values[i] = SubtitleFormat(v.value)
+	}
+	return values
+}
+
+// Name returns the human-readable name, or the raw value if it's unknown.
+func (s SubtitleFormat) Name() string {
+	return subtitleFormatTable.name(string(s))
+}
+
+// Valid reports whether s is a known subtitle format.
+func (s SubtitleFormat) Valid() bool {
+	return subtitleFormatTable.valid(string(s))
+}
+
+// ParseSubtitleFormat looks up a subtitle format by its value or name, ignoring case.
+// It returns an error wrapping [ErrUnknownValue] if there's no match.
+func ParseSubtitleFormat(s string) (SubtitleFormat, error) {
+	v, err := subtitleFormatTable.parse("subtitle format", s)
+	return SubtitleFormat(v), err
+}
+
+var speechModelTable = enumTable{
+	{string(SpeechModelBest), "Best"},
+	{string(SpeechModelNano), "Nano"},
+	{string(SpeechModelConformer2), "Conformer-2"},
+}
+
+// SpeechModels returns all known values of [SpeechModel].
+func SpeechModels() []SpeechModel {
+	values := make([]SpeechModel, len(speechModelTable))
+	for i, v := range speechModelTable {
+		values[i] = SpeechModel(v.value)
+	}
+	return values
+}
+
+// Name returns the human-readable name, or the raw value if it's unknown.
+func (s SpeechModel) Name() string {
+	return speechModelTable.name(string(s))
+}
+
+// Valid reports whether s is a known speech model.
+func (s SpeechModel) Valid() bool {
+	return speechModelTable.valid(string(s))
+}
+
+// ParseSpeechModel looks up a speech model by its value or name, ignoring case.
+// It returns an error wrapping [ErrUnknownValue] if there's no match.
+func ParseSpeechModel(s string) (SpeechModel, error) {
+	v, err := speechModelTable.parse("speech model", s)
+	return SpeechModel(v), err
+}
+
+var transcriptLanguageCodeTable = enumTable{
+	{string(TranscriptLanguageCodeEnglish), "English (global)"},
+	{string(TranscriptLanguageCodeEnglishAustralian), "English (Australian)"},
+	{string(TranscriptLanguageCodeEnglishBritish), "English (British)"},
+	{string(TranscriptLanguageCodeEnglishUS), "English (US)"},
+	{string(TranscriptLanguageCodeSpanish), "Spanish"},
+	{string(TranscriptLanguageCodeFrench), "French"},
+	{string(TranscriptLanguageCodeGerman), "German"},
+	{string(TranscriptLanguageCodeItalian), "Italian"},
+	{string(TranscriptLanguageCodePortuguese), "Portuguese"},
+	{string(TranscriptLanguageCodeDutch), "Dutch"},
+	{string(TranscriptLanguageCodeAfrikaans), "Afrikaans"},
+	{string(TranscriptLanguageCodeAlbanian), "Albanian"},
+	{string(TranscriptLanguageCodeAmharic), "Amharic"},
+	{string(TranscriptLanguageCodeArabic), "Arabic"},
+	{string(TranscriptLanguageCodeArmenian), "Armenian"},
+	{string(TranscriptLanguageCodeAssamese), "Assamese"},
+	{string(TranscriptLanguageCodeAzerbaijani), "Azerbaijani"},
+	{string(TranscriptLanguageCodeBashkir), "Bashkir"},
+	{string(TranscriptLanguageCodeBasque), "Basque"},
+	{string(TranscriptLanguageCodeBelarusian), "Belarusian"},
+	{string(TranscriptLanguageCodeBengali), "Bengali"},
+	{string(TranscriptLanguageCodeBosnian), "Bosnian"},
+	{string(TranscriptLanguageCodeBreton), "Breton"},
+	{string(TranscriptLanguageCodeBulgarian), "Bulgarian"},
+	{string(TranscriptLanguageCodeBurmese), "Burmese"},
+	{string(TranscriptLanguageCodeCatalan), "Catalan"},
+	{string(TranscriptLanguageCodeChinese), "Chinese"},
+	{string(TranscriptLanguageCodeCroatian), "Croatian"},
+	{string(TranscriptLanguageCodeCzech), "Czech"},
+	{string(TranscriptLanguageCodeDanish), "Danish"},
+	{string(TranscriptLanguageCodeEstonian), "Estonian"},
+	{string(TranscriptLanguageCodeFaroese), "Faroese"},
+	{string(TranscriptLanguageCodeFinnish), "Finnish"},
+	{string(TranscriptLanguageCodeGalician), "Galician"},
+	{string(TranscriptLanguageCodeGeorgian), "Georgian"},
+	{string(TranscriptLanguageCodeGreek), "Greek"},
+	{string(TranscriptLanguageCodeGujarati), "Gujarati"},
+	{string(TranscriptLanguageCodeHaitian), "Haitian"},
+	{string(TranscriptLanguageCodeHausa), "Hausa"},
+	{string(TranscriptLanguageCodeHawaiian), "Hawaiian"},
+	{string(TranscriptLanguageCodeHebrew), "Hebrew"},
+	{string(TranscriptLanguageCodeHindi), "Hindi"},
+	{string(TranscriptLanguageCodeHungarian), "Hungarian"},
+	{string(TranscriptLanguageCodeIcelandic), "Icelandic"},
+	{string(TranscriptLanguageCodeIndonesian), "Indonesian"},
+	{string(TranscriptLanguageCodeJapanese), "Japanese"},
+	{string(TranscriptLanguageCodeJavanese), "Javanese"},
+	{string(TranscriptLanguageCodeKannada), "Kannada"},
+	{string(TranscriptLanguageCodeKazakh), "Kazakh"},
+	{string(TranscriptLanguageCodeKhmer), "Khmer"},
+	{string(TranscriptLanguageCodeKorean), "Korean"},
+	{string(TranscriptLanguageCodeLao), "Lao"},
+	{string(TranscriptLanguageCodeLatin), "Latin"},
+	{string(TranscriptLanguageCodeLatvian), "Latvian"},
+	{string(TranscriptLanguageCodeLingala), "Lingala"},
+	{string(TranscriptLanguageCodeLithuanian), "Lithuanian"},
+	{string(TranscriptLanguageCodeLuxembourgish), "Luxembourgish"},
+	{string(TranscriptLanguageCodeMacedonian), "Macedonian"},
+	{string(TranscriptLanguageCodeMalagasy), "Malagasy"},
+	{string(TranscriptLanguageCodeMalay), "Malay"},
+	{string(TranscriptLanguageCodeMalayalam), "Malayalam"},
+	{string(TranscriptLanguageCodeMaltese), "Maltese"},
+	{string(TranscriptLanguageCodeMaori), "Maori"},
+	{string(TranscriptLanguageCodeMarathi), "Marathi"},
+	{string(TranscriptLanguageCodeMongolian), "Mongolian"},
+	{string(TranscriptLanguageCodeNepali), "Nepali"},
+	{string(TranscriptLanguageCodeNorwegian), "Norwegian"},
+	{string(TranscriptLanguageCodeNorwegianNynorsk), "Norwegian Nynorsk"},
+	{string(TranscriptLanguageCodeOccitan), "Occitan"},
+	{string(TranscriptLanguageCodePanjabi), "Panjabi"},
+	{string(TranscriptLanguageCodePashto), "Pashto"},
+	{string(TranscriptLanguageCodePersian), "Persian"},
+	{string(TranscriptLanguageCodePolish), "Polish"},
+	{string(TranscriptLanguageCodeRomanian), "Romanian"},
+	{string(TranscriptLanguageCodeRussian), "Russian"},
+	{string(TranscriptLanguageCodeSanskrit), "Sanskrit"},
+	{string(TranscriptLanguageCodeSerbian), "Serbian"},
+	{string(TranscriptLanguageCodeShona), "Shona"},
+	{string(TranscriptLanguageCodeSindhi), "Sindhi"},
+	{string(TranscriptLanguageCodeSinhala), "Sinhala"},
+	{string(TranscriptLanguageCodeSlovak), "Slovak"},
+	{string(TranscriptLanguageCodeSlovenian), "Slovenian"},
+	{string(TranscriptLanguageCodeSomali), "Somali"},
+	{string(TranscriptLanguageCodeSundanese), "Sundanese"},
+	{string(TranscriptLanguageCodeSwahili), "Swahili"},
+	{string(TranscriptLanguageCodeSwedish), "Swedish"},
+	{string(TranscriptLanguageCodeTagalog), "Tagalog"},
+	{string(TranscriptLanguageCodeTajik), "Tajik"},
+	{string(TranscriptLanguageCodeTamil), "Tamil"},
+	{string(TranscriptLanguageCodeTatar), "Tatar"},
+	{string(TranscriptLanguageCodeTelugu), "Telugu"},
+	{string(TranscriptLanguageCodeThai), "Thai"},
+	{string(TranscriptLanguageCodeTibetan), "Tibetan"},
+	{string(TranscriptLanguageCodeTurkish), "Turkish"},
+	{string(TranscriptLanguageCodeTurkmen), "Turkmen"},
+	{string(TranscriptLanguageCodeUkrainian), "Ukrainian"},
+	{string(TranscriptLanguageCodeUrdu), "Urdu"},
+	{string(TranscriptLanguageCodeUzbek), "Uzbek"},
+	{string(TranscriptLanguageCodeVietnamese), "Vietnamese"},
+	{string(TranscriptLanguageCodeWelsh), "Welsh"},
+	{string(TranscriptLanguageCodeYiddish), "Yiddish"},
+	{string(TranscriptLanguageCodeYoruba), "Yoruba"},
+}
+
+// TranscriptLanguageCodes returns all known values of [TranscriptLanguageCode].
+func TranscriptLanguageCodes() []TranscriptLanguageCode {
+	values := make([]TranscriptLanguageCode, len(transcriptLanguageCodeTable))
+	for i, v := range transcriptLanguageCodeTable {
+		values[i] = TranscriptLanguageCode(v.value)
+	}
+	return values
+}
+
+// Name returns the human-readable name, or the raw value if it's unknown.
+func (t TranscriptLanguageCode) Name() string {
+	return transcriptLanguageCodeTable.name(string(t))
+}
+
+// Valid reports whether t is a known language code.
+func (t TranscriptLanguageCode) Valid() bool {
+	return transcriptLanguageCodeTable.valid(string(t))
+}
+
+// ParseTranscriptLanguageCode looks up a language code by its value or name, ignoring case.
+// It returns an error wrapping [ErrUnknownValue] if there's no match.
+func ParseTranscriptLanguageCode(s string) (TranscriptLanguageCode, error) {
+	v, err := transcriptLanguageCodeTable.parse("language code", s)
+	return TranscriptLanguageCode(v), err
+}
+
+var transcriptBoostParamTable = enumTable{
+	{string(TranscriptBoostParamLow), "Low"},
+	{string(TranscriptBoostParamDefault), "Default"},
+	{string(TranscriptBoostParamHigh), "High"},
+}
+
+// TranscriptBoostParams returns all known values of [TranscriptBoostParam].
+func TranscriptBoostParams() []TranscriptBoostParam {
+	values := make([]TranscriptBoostParam, len(transcriptBoostParamTable))
+	for i, v := range transcriptBoostParamTable {
+		values[i] = TranscriptBoostParam(v.value)
+	}
+	return values
+}
+
+// Name returns the human-readable name, or the raw value if it's unknown.
+func (t TranscriptBoostParam) Name() string {
+	return transcriptBoostParamTable.name(string(t))
+}
+
+// Valid reports whether t is a known boost param.
+func (t TranscriptBoostParam) Valid() bool {
+	return transcriptBoostParamTable.valid(string(t))
+}
+
+// ParseTranscriptBoostParam looks up a boost param by its value or name, ignoring case.
+// It returns an error wrapping [ErrUnknownValue] if there's no match.
+func ParseTranscriptBoostParam(s string) (TranscriptBoostParam, error) {
+	v, err := transcriptBoostParamTable.parse("boost param", s)
+	return TranscriptBoostParam(v), err
+}
+
+var redactPIIAudioQualityTable = enumTable{
+	{string(RedactPIIAudioQualityMP3), "MP3"},
+	{string(RedactPIIAudioQualityWAV), "WAV"},
+}
+
+// RedactPIIAudioQualities returns all known values of [RedactPIIAudioQuality].
+func RedactPIIAudioQualities() []RedactPIIAudioQuality {
+	values := make([]RedactPIIAudioQuality, len(redactPIIAudioQualityTable))
+	for i, v := range redactPIIAudioQualityTable {
+		values[i] = RedactPIIAudioQuality(v.value)
+	}
+	return values
+}
+
+// Name returns the human-readable name, or the raw value if it's unknown.
+func (r RedactPIIAudioQuality) Name() string {
+	return redactPIIAudioQualityTable.name(string(r))
+}
+
+// Valid reports whether r is a known redacted audio quality.
+func (r RedactPIIAudioQuality) Valid() bool {
+	return redactPIIAudioQualityTable.valid(string(r))
+}
+
+// ParseRedactPIIAudioQuality looks up a redacted audio quality by its value or name, ignoring case.
+// It returns an error wrapping [ErrUnknownValue] if there's no match.
+func ParseRedactPIIAudioQuality(s string) (RedactPIIAudioQuality, error) {
+	v, err := redactPIIAudioQualityTable.parse("redacted audio quality", s)
+	return RedactPIIAudioQuality(v), err
+}
+
+var sentimentTable = enumTable{
+	{string(SentimentPositive), "Positive"},
+	{string(SentimentNeutral), "Neutral"},
+	{string(SentimentNegative), "Negative"},
+}
+
+// Sentiments returns all known values of [Sentiment].
+func Sentiments() []Sentiment {
+	values := make([]Sentiment, len(sentimentTable))
+	for i, v := range sentimentTable {
+		values[i] = Sentiment(v.value)
+	}
+	return values
+}
+
+// Name returns the human-readable name, or the raw value if it's unknown.
+func (s Sentiment) Name() string {
+	return sentimentTable.name(string(s))
+}
+
+// Valid reports whether s is a known sentiment.
+func (s Sentiment) Valid() bool {
+	return sentimentTable.valid(string(s))
+}
+
+// ParseSentiment looks up a sentiment by its value or name, ignoring case.
+// It returns an error wrapping [ErrUnknownValue] if there's no match.
+func ParseSentiment(s string) (Sentiment, error) {
+	v, err := sentimentTable.parse("sentiment", s)
+	return Sentiment(v), err
+}
+
+// speechModelLanguages lists the languages supported by each speech model.
+var speechModelLanguages = map[SpeechModel][]TranscriptLanguageCode{
+	SpeechModelBest: {
+		TranscriptLanguageCodeEnglish,
+		TranscriptLanguageCodeEnglishAustralian,
+		TranscriptLanguageCodeEnglishBritish,
+		TranscriptLanguageCodeEnglishUS,
+		TranscriptLanguageCodeSpanish,
+		TranscriptLanguageCodeFrench,
+		TranscriptLanguageCodeGerman,
+		TranscriptLanguageCodeItalian,
+		TranscriptLanguageCodePortuguese,
+		TranscriptLanguageCodeDutch,
+		TranscriptLanguageCodeHindi,
+		TranscriptLanguageCodeJapanese,
+		TranscriptLanguageCodeChinese,
+		TranscriptLanguageCodeFinnish,
+		TranscriptLanguageCodeKorean,
+		TranscriptLanguageCodePolish,
+		TranscriptLanguageCodeRussian,
+		TranscriptLanguageCodeTurkish,
+		TranscriptLanguageCodeUkrainian,
+		TranscriptLanguageCodeVietnamese,
+	},
+	SpeechModelNano: TranscriptLanguageCodes(),
+	SpeechModelConformer2: {
+		TranscriptLanguageCodeEnglish,
+		TranscriptLanguageCodeEnglishAustralian,
+		TranscriptLanguageCodeEnglishBritish,
+		TranscriptLanguageCodeEnglishUS,
+	},
+}
+
+// Languages returns the languages supported by the speech model, or nil if the
+// model is unknown.
+func (s SpeechModel) Languages() []TranscriptLanguageCode {
+	return append([]TranscriptLanguageCode(nil), speechModelLanguages[s]...)
+}
+
+// SupportsLanguage reports whether the speech model can transcribe audio in
+// the given language.
+func (s SpeechModel) SupportsLanguage(code TranscriptLanguageCode) bool {
+	for _, c := range speechModelLanguages[s] {
+		if c == code {
+			return true
+		}
+	}
+	return false
+}
+
+// summaryModelTypes lists the summary types supported by each summary model,
+// starting with the default one.
+var summaryModelTypes = map[SummaryModel][]SummaryType{
+	SummaryModelInformative: {
+		SummaryTypeBullets,
+		SummaryTypeBulletsVerbose,
+		SummaryTypeHeadline,
+		SummaryTypeParagraph,
+	},
+	SummaryModelConversational: {
+		SummaryTypeBullets,
+		SummaryTypeBulletsVerbose,
+		SummaryTypeHeadline,
+		SummaryTypeParagraph,
+	},
+	SummaryModelCatchy: {
+		SummaryTypeGist,
+		SummaryTypeHeadline,
+	},
+}
+
+// SummaryTypes returns the summary types supported by the summary model, or
+// nil if the model is unknown.
+func (s SummaryModel) SummaryTypes() []SummaryType {
+	return append([]SummaryType(nil), summaryModelTypes[s]...)
+}
+
+// SupportsSummaryType reports whether the summary model can produce the given
+// type of summary.
+func (s SummaryModel) SupportsSummaryType(typ SummaryType) bool {
+	for _, t := range summaryModelTypes[s] {
+		if t == typ {
+			return true
+		}
+	}
+	return false
+}
+
+// DefaultSummaryType returns the summary type used by the summary model when
+// none is set, or an empty string if the model is unknown.
+func (s SummaryModel) DefaultSummaryType() SummaryType {
+	if types := summaryModelTypes[s]; len(types) > 0 {
+		return types[0]
+	}
+	return ""
+}
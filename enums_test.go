@@ -0,0 +1,90 @@
+package assemblyai
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnums(t *testing.T) {
+	t.Parallel()
+
+	require.Len(t, EntityTypes(), 44)
+	require.Len(t, PIIPolicies(), 44)
+	require.Len(t, TranscriptLanguageCodes(), 102)
+	require.Equal(t, []Sentiment{SentimentPositive, SentimentNeutral, SentimentNegative}, Sentiments())
+
+	require.Equal(t, "US Social Security number", EntityTypeUSSocialSecurityNumber.Name())
+	require.Equal(t, "English (British)", TranscriptLanguageCodeEnglishBritish.Name())
+	require.Equal(t, "WebVTT", SubtitleFormatVTT.Name())
+	require.Equal(t, "klingon", TranscriptLanguageCode("klingon").Name())
+
+	require.True(t, PIIPolicyCreditCardCVV.Valid())
+	require.False(t, SummaryType("haiku").Valid())
+
+	code, err := ParseTranscriptLanguageCode(" german ")
+	require.NoError(t, err)
+	require.Equal(t, TranscriptLanguageCodeGerman, code)
+
+	code, err = ParseTranscriptLanguageCode("EN_US")
+	require.NoError(t, err)
+	require.Equal(t, TranscriptLanguageCodeEnglishUS, code)
+
+	_, err = ParsePIIPolicy("favorite_color")
+	require.ErrorIs(t, err, ErrUnknownValue)
+	require.EqualError(t, err, `unknown value: PII policy "favorite_color"`)
+
+	// Every value is unique and can be parsed back.
+	seen := map[TranscriptLanguageCode]bool{}
+	for _, c := range TranscriptLanguageCodes() {
+		require.False(t, seen[c], c)
+		seen[c] = true
+
+		parsed, err := ParseTranscriptLanguageCode(c.Name())
+		require.NoError(t, err)
+		require.Equal(t, c, parsed)
+	}
+}
+
+func TestEnums_Metadata(t *testing.T) {
+	t.Parallel()
+
+	require.True(t, SpeechModelBest.SupportsLanguage(TranscriptLanguageCodeJapanese))
+	require.False(t, SpeechModelBest.SupportsLanguage(TranscriptLanguageCodeWelsh))
+	require.True(t, SpeechModelNano.SupportsLanguage(TranscriptLanguageCodeWelsh))
+	require.False(t, SpeechModelConformer2.SupportsLanguage(TranscriptLanguageCodeSpanish))
+	require.Len(t, SpeechModelNano.Languages(), len(TranscriptLanguageCodes()))
+	require.Nil(t, SpeechModel("unknown").Languages())
+
+	require.Equal(t, []SummaryType{SummaryTypeGist, SummaryTypeHeadline}, SummaryModelCatchy.SummaryTypes())
+	require.True(t, SummaryModelInformative.SupportsSummaryType(SummaryTypeParagraph))
+	require.False(t, SummaryModelInformative.SupportsSummaryType(SummaryTypeGist))
+	require.Equal(t, SummaryTypeBullets, SummaryModelConversational.DefaultSummaryType())
+	require.Equal(t, SummaryTypeGist, SummaryModelCatchy.DefaultSummaryType())
+
+	params := TranscriptOptionalParams{
+		LanguageCode:  TranscriptLanguageCodeWelsh,
+		SpeechModel:   SpeechModelBest,
+		Summarization: Bool(true),
+		SummaryModel:  SummaryModelCatchy,
+		SummaryType:   SummaryTypeBullets,
+	}
+
+	// The tables are only checked by strict validation.
+	require.NoError(t, params.Validate())
+
+	err := params.ValidateStrict()
+	require.EqualError(t, err, "invalid transcript parameters: "+
+		"language_code: Welsh isn't supported by the Best speech model; "+
+		"summary_type: Bullets isn't supported by the Catchy summary model")
+
+	require.NoError(t, TranscriptOptionalParams{
+		LanguageCode: TranscriptLanguageCodeWelsh,
+		SpeechModel:  SpeechModelNano,
+	}.ValidateStrict())
+
+	// The API chooses the speech model when it isn't set.
+	require.NoError(t, TranscriptOptionalParams{
+		LanguageCode: TranscriptLanguageCodeWelsh,
+	}.ValidateStrict())
+}
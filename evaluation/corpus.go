@@ -0,0 +1,88 @@
+package evaluation
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// FileResult is the evaluation of a single reference/hypothesis pair in a
+// corpus.
+type FileResult struct {
+	// Name of the file, relative to the reference directory.
+	Name string
+
+	Result
+}
+
+// CorpusResult is the evaluation of a corpus of reference/hypothesis pairs.
+type CorpusResult struct {
+	// Error counts summed over every file. The corpus-level WER and CER weigh
+	// each file by the length of its reference.
+	Counts
+
+	// Results for each file, sorted by name.
+	Files []FileResult
+
+	// Reference files without a matching hypothesis file.
+	Missing []string
+}
+
+// MeanWER returns the average WER of the files, weighing each file equally.
+func (c CorpusResult) MeanWER() float64 {
+	if len(c.Files) == 0 {
+		return 0
+	}
+
+	var sum float64
+	for _, f := range c.Files {
+		sum += f.WER()
+	}
+
+	return sum / float64(len(c.Files))
+}
+
+// EvaluateDir evaluates the file with the same name in hypothesisDir against
+// every file in referenceDir. Only files matching pattern are compared, for
+// example "*.txt", and references without a hypothesis are listed in
+// [CorpusResult.Missing]. If normalizer is nil, [DefaultNormalizer] is used.
+func EvaluateDir(referenceDir, hypothesisDir, pattern string, normalizer *Normalizer) (CorpusResult, error) {
+	refs, err := filepath.Glob(filepath.Join(referenceDir, pattern))
+	if err != nil {
+		return CorpusResult{}, err
+	}
+
+	if len(refs) == 0 {
+		return CorpusResult{}, fmt.Errorf("no reference files matching %q in %s", pattern, referenceDir)
+	}
+
+	sort.Strings(refs)
+
+	var corpus CorpusResult
+
+	for _, refPath := range refs {
+		name := filepath.Base(refPath)
+
+		ref, err := os.ReadFile(refPath)
+		if err != nil {
+			return CorpusResult{}, err
+		}
+
+		hyp, err := os.ReadFile(filepath.Join(hypothesisDir, name))
+		if os.IsNotExist(err) {
+			corpus.Missing = append(corpus.Missing, name)
+			continue
+		}
+		if err != nil {
+			return CorpusResult{}, err
+		}
+
+		result := Evaluate(string(ref), string(hyp), normalizer)
+
+		corpus.Files = append(corpus.Files, FileResult{Name: name, Result: result})
+		corpus.add(result.Counts)
+	}
+
+	return corpus, nil
+}
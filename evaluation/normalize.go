@@ -0,0 +1,149 @@
+package evaluation
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// DefaultFillers are the filler words removed by a [Normalizer] with
+// RemoveFillers enabled and no custom Fillers.
+var DefaultFillers = []string{"ah", "eh", "er", "hm", "hmm", "mhm", "mm", "uh", "uhh", "um", "umm"}
+
+// DefaultNormalizer ignores casing, punctuation, the way numbers are written
+// and filler words.
+var DefaultNormalizer = Normalizer{
+	Lowercase:         true,
+	RemovePunctuation: true,
+	SpellNumbers:      true,
+	RemoveFillers:     true,
+}
+
+// Normalizer configures how text is normalized before comparison.
+type Normalizer struct {
+	// Lowercase all words.
+	Lowercase bool
+
+	// Remove punctuation, except for apostrophes within words.
+	RemovePunctuation bool
+
+	// Spell out whole numbers, for example "150" becomes "one hundred fifty".
+	SpellNumbers bool
+
+	// Remove filler words, like "um".
+	RemoveFillers bool
+
+	// Filler words to remove. Defaults to [DefaultFillers].
+	Fillers []string
+}
+
+var digitGroupRegexp = regexp.MustCompile(`(\d),(\d{3})`)
+
+// Words normalizes text and splits it into words.
+func (n Normalizer) Words(text string) []string {
+	fillers := n.Fillers
+	if len(fillers) == 0 {
+		fillers = DefaultFillers
+	}
+
+	isFiller := make(map[string]bool, len(fillers))
+	for _, f := range fillers {
+		isFiller[strings.ToLower(f)] = true
+	}
+
+	if n.SpellNumbers {
+		// Join digit groups so that "1,000" is read as a single number.
+		for digitGroupRegexp.MatchString(text) {
+			text = digitGroupRegexp.ReplaceAllString(text, "$1$2")
+		}
+	}
+
+	var words []string
+
+	for _, w := range strings.Fields(text) {
+		if n.RemovePunctuation {
+			w = strings.Map(func(r rune) rune {
+				if unicode.IsPunct(r) && r != '\'' || unicode.IsSymbol(r) {
+					return ' '
+				}
+				return r
+			}, w)
+		}
+
+		for _, w := range strings.Fields(w) {
+			if n.RemovePunctuation {
+				w = strings.Trim(w, "'")
+				if w == "" {
+					continue
+				}
+			}
+
+			if n.Lowercase {
+				w = strings.ToLower(w)
+			}
+
+			if n.RemoveFillers && isFiller[strings.ToLower(w)] {
+				continue
+			}
+
+			if n.SpellNumbers {
+				if v, err := strconv.ParseInt(w, 10, 64); err == nil && v >= 0 {
+					words = append(words, strings.Fields(spellNumber(v))...)
+					continue
+				}
+			}
+
+			words = append(words, w)
+		}
+	}
+
+	return words
+}
+
+var (
+	smallNumbers = []string{
+		"zero", "one", "two", "three", "four", "five", "six", "seven", "eight", "nine",
+		"ten", "eleven", "twelve", "thirteen", "fourteen", "fifteen", "sixteen", "seventeen", "eighteen", "nineteen",
+	}
+	tens   = []string{"", "", "twenty", "thirty", "forty", "fifty", "sixty", "seventy", "eighty", "ninety"}
+	scales = []struct {
+		value int64
+		name  string
+	}{
+		{1_000_000_000_000, "trillion"},
+		{1_000_000_000, "billion"},
+		{1_000_000, "million"},
+		{1_000, "thousand"},
+		{100, "hundred"},
+	}
+)
+
+// spellNumber spells out a non-negative whole number in English words.
+func spellNumber(v int64) string {
+	if v < 20 {
+		return smallNumbers[v]
+	}
+
+	if v < 100 {
+		if v%10 == 0 {
+			return tens[v/10]
+		}
+		return tens[v/10] + " " + smallNumbers[v%10]
+	}
+
+	for _, s := range scales {
+		if v < s.value {
+			continue
+		}
+
+		words := spellNumber(v/s.value) + " " + s.name
+		if v%s.value != 0 {
+			words += " " + spellNumber(v%s.value)
+		}
+
+		return words
+	}
+
+	return strconv.FormatInt(v, 10)
+}
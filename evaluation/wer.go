@@ -0,0 +1,384 @@
+// Package evaluation measures the accuracy of transcripts against human
+// reference transcripts.
+//
+// It computes the word error rate (WER) and character error rate (CER) used to
+// compare speech models and configurations, for example
+// [assemblyai.SpeechModelBest] and [assemblyai.SpeechModelNano].
+package evaluation
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/AssemblyAI/assemblyai-go-sdk"
+)
+
+// EditType describes how a reference word was transcribed.
+type EditType string
+
+const (
+	// The hypothesis word matches the reference word.
+	EditMatch EditType = "match"
+
+	// The hypothesis word differs from the reference word.
+	EditSubstitution EditType = "substitution"
+
+	// The reference word is missing from the hypothesis.
+	EditDeletion EditType = "deletion"
+
+	// The hypothesis word isn't in the reference.
+	EditInsertion EditType = "insertion"
+)
+
+// Edit is a single step in the alignment of a hypothesis to a reference.
+type Edit struct {
+	Type EditType
+
+	// The reference word. Empty for insertions.
+	Reference string
+
+	// The hypothesis word. Empty for deletions.
+	Hypothesis string
+}
+
+// Counts holds the error counts of one or more evaluations.
+type Counts struct {
+	Matches       int
+	Substitutions int
+	Deletions     int
+	Insertions    int
+
+	// Number of words in the normalized reference.
+	ReferenceWords int
+
+	// Number of character edits and characters in the normalized reference.
+	CharacterErrors     int
+	ReferenceCharacters int
+}
+
+// WER returns the word error rate.
+func (c Counts) WER() float64 {
+	if c.ReferenceWords == 0 {
+		if c.Insertions == 0 {
+			return 0
+		}
+		return 1
+	}
+	return float64(c.Substitutions+c.Deletions+c.Insertions) / float64(c.ReferenceWords)
+}
+
+// CER returns the character error rate.
+func (c Counts) CER() float64 {
+	if c.ReferenceCharacters == 0 {
+		if c.CharacterErrors == 0 {
+			return 0
+		}
+		return 1
+	}
+	return float64(c.CharacterErrors) / float64(c.ReferenceCharacters)
+}
+
+func (c *Counts) add(other Counts) {
+	c.Matches += other.Matches
+	c.Substitutions += other.Substitutions
+	c.Deletions += other.Deletions
+	c.Insertions += other.Insertions
+	c.ReferenceWords += other.ReferenceWords
+	c.CharacterErrors += other.CharacterErrors
+	c.ReferenceCharacters += other.ReferenceCharacters
+}
+
+// Result is the evaluation of a single hypothesis against its reference.
+type Result struct {
+	Counts
+
+	// The word alignment of the hypothesis to the reference.
+	Alignment []Edit
+}
+
+// Evaluate compares a hypothesis transcript to a reference transcript. If
+// normalizer is nil, [DefaultNormalizer] is used.
+func Evaluate(reference, hypothesis string, normalizer *Normalizer) Result {
+	if normalizer == nil {
+		normalizer = &DefaultNormalizer
+	}
+
+	ref := normalizer.Words(reference)
+	hyp := normalizer.Words(hypothesis)
+
+	result := Result{Alignment: align(ref, hyp)}
+
+	for _, e := range result.Alignment {
+		switch e.Type {
+		case EditMatch:
+			result.Matches++
+		case EditSubstitution:
+			result.Substitutions++
+		case EditDeletion:
+			result.Deletions++
+		case EditInsertion:
+			result.Insertions++
+		}
+	}
+
+	refChars := []rune(strings.Join(ref, " "))
+	hypChars := []rune(strings.Join(hyp, " "))
+
+	result.ReferenceWords = len(ref)
+	result.ReferenceCharacters = len(refChars)
+	result.CharacterErrors = editDistance(refChars, hypChars)
+
+	return result
+}
+
+// EvaluateTranscript compares the text of a transcript to a reference
+// transcript.
+func EvaluateTranscript(reference string, transcript assemblyai.Transcript, normalizer *Normalizer) Result {
+	return Evaluate(reference, assemblyai.ToString(transcript.Text), normalizer)
+}
+
+// AlignmentView renders the alignment as rows of reference words, hypothesis
+// words and edit markers, wrapped at width characters. Substitutions are
+// marked with S, deletions with D and insertions with I.
+func (r Result) AlignmentView(width int) string {
+	var (
+		b             strings.Builder
+		ref, hyp, ops strings.Builder
+	)
+
+	flush := func() {
+		if ref.Len() == 0 {
+			return
+		}
+
+		fmt.Fprintf(&b, "REF: %s\nHYP: %s\n     %s\n\n",
+			strings.TrimRight(ref.String(), " "),
+			strings.TrimRight(hyp.String(), " "),
+			strings.TrimRight(ops.String(), " "))
+
+		ref.Reset()
+		hyp.Reset()
+		ops.Reset()
+	}
+
+	for _, e := range r.Alignment {
+		refWord, hypWord := e.Reference, e.Hypothesis
+
+		switch e.Type {
+		case EditDeletion:
+			hypWord = strings.Repeat("*", utf8.RuneCountInString(refWord))
+		case EditInsertion:
+			refWord = strings.Repeat("*", utf8.RuneCountInString(hypWord))
+		}
+
+		n := utf8.RuneCountInString(refWord)
+		if m := utf8.RuneCountInString(hypWord); m > n {
+			n = m
+		}
+
+		if width > 0 && ref.Len() > 0 && utf8.RuneCountInString(ref.String())+n > width {
+			flush()
+		}
+
+		marker := ""
+		switch e.Type {
+		case EditSubstitution:
+			marker = "S"
+		case EditDeletion:
+			marker = "D"
+		case EditInsertion:
+			marker = "I"
+		}
+
+		fmt.Fprintf(&ref, "%-*s ", n, refWord)
+		fmt.Fprintf(&hyp, "%-*s ", n, hypWord)
+		fmt.Fprintf(&ops, "%-*s ", n, marker)
+	}
+
+	flush()
+
+	return strings.TrimRight(b.String(), "\n") + "\n"
+}
+
+// Edits recorded for each cell by alignTable.
+const (
+	opMatch byte = iota
+	opSubstitution
+	opDeletion
+	opInsertion
+)
+
+// maxAlignTableCells is the largest problem that align solves with a full
+// table of edits.
+const maxAlignTableCells = 1 << 16
+
+// align returns the minimum edit alignment of hyp to ref.
+//
+// Large inputs are split in half with Hirschberg's algorithm until they're
+// small enough for alignTable, so memory stays linear in the length of the
+// inputs.
+func align(ref, hyp []string) []Edit {
+	if (len(ref)+1)*(len(hyp)+1) <= maxAlignTableCells || len(ref) < 2 {
+		return alignTable(ref, hyp)
+	}
+
+	mid := len(ref) / 2
+
+	forward := distanceRow(ref[:mid], hyp)
+	backward := distanceRow(reversed(ref[mid:]), reversed(hyp))
+
+	// Split hyp where the cost of aligning both halves is the lowest.
+	split := 0
+	for k := 1; k <= len(hyp); k++ {
+		if forward[k]+backward[len(hyp)-k] < forward[split]+backward[len(hyp)-split] {
+			split = k
+		}
+	}
+
+	return append(align(ref[:mid], hyp[:split]), align(ref[mid:], hyp[split:])...)
+}
+
+// distanceRow returns the edit distances between ref and every prefix of hyp.
+func distanceRow(ref, hyp []string) []int {
+	prev := make([]int, len(hyp)+1)
+	curr := make([]int, len(hyp)+1)
+
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ref); i++ {
+		curr[0] = i
+
+		for j := 1; j <= len(hyp); j++ {
+			cost := 1
+			if ref[i-1] == hyp[j-1] {
+				cost = 0
+			}
+
+			curr[j] = minInt(prev[j-1]+cost, minInt(prev[j]+1, curr[j-1]+1))
+		}
+
+		prev, curr = curr, prev
+	}
+
+	return prev
+}
+
+func reversed(words []string) []string {
+	r := make([]string, len(words))
+	for i, w := range words {
+		r[len(words)-1-i] = w
+	}
+	return r
+}
+
+// alignTable returns the minimum edit alignment of hyp to ref. The edit
+// chosen at each cell is recorded in a byte per cell and followed back from
+// the end, so it takes memory proportional to the product of the lengths.
+func alignTable(ref, hyp []string) []Edit {
+	width := len(hyp) + 1
+
+	ops := make([]byte, (len(ref)+1)*width)
+	prev := make([]int, width)
+	curr := make([]int, width)
+
+	for j := 1; j < width; j++ {
+		prev[j] = j
+		ops[j] = opInsertion
+	}
+
+	for i := 1; i <= len(ref); i++ {
+		curr[0] = i
+		ops[i*width] = opDeletion
+
+		for j := 1; j <= len(hyp); j++ {
+			cost := 1
+			if ref[i-1] == hyp[j-1] {
+				cost = 0
+			}
+
+			d := minInt(prev[j-1]+cost, minInt(prev[j]+1, curr[j-1]+1))
+
+			var op byte
+
+			switch {
+			case cost == 0 && d == prev[j-1]:
+				op = opMatch
+			case d == prev[j-1]+1:
+				op = opSubstitution
+			case d == prev[j]+1:
+				op = opDeletion
+			default:
+				op = opInsertion
+			}
+
+			curr[j] = d
+			ops[i*width+j] = op
+		}
+
+		prev, curr = curr, prev
+	}
+
+	var edits []Edit
+
+	i, j := len(ref), len(hyp)
+
+	for i > 0 || j > 0 {
+		switch ops[i*width+j] {
+		case opMatch:
+			edits = append(edits, Edit{Type: EditMatch, Reference: ref[i-1], Hypothesis: hyp[j-1]})
+			i, j = i-1, j-1
+		case opSubstitution:
+			edits = append(edits, Edit{Type: EditSubstitution, Reference: ref[i-1], Hypothesis: hyp[j-1]})
+			i, j = i-1, j-1
+		case opDeletion:
+			edits = append(edits, Edit{Type: EditDeletion, Reference: ref[i-1]})
+			i--
+		default:
+			edits = append(edits, Edit{Type: EditInsertion, Hypothesis: hyp[j-1]})
+			j--
+		}
+	}
+
+	for l, r := 0, len(edits)-1; l < r; l, r = l+1, r-1 {
+		edits[l], edits[r] = edits[r], edits[l]
+	}
+
+	return edits
+}
+
+// editDistance returns the Levenshtein distance between two rune sequences.
+func editDistance(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+
+			curr[j] = minInt(prev[j-1]+cost, minInt(prev[j]+1, curr[j-1]+1))
+		}
+
+		prev, curr = curr, prev
+	}
+
+	return prev[len(b)]
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,131 @@
+package evaluation
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizer_Words(t *testing.T) {
+	t.Parallel()
+
+	got := DefaultNormalizer.Words("Um, it's reaching 1,500 micrograms... I'm assuming 24-hours!")
+	require.Equal(t, []string{"it's", "reaching", "one", "thousand", "five", "hundred", "micrograms", "i'm", "assuming", "twenty", "four", "hours"}, got)
+
+	got = Normalizer{}.Words("Um, it's 10.")
+	require.Equal(t, []string{"Um,", "it's", "10."}, got)
+
+	// Spelled numbers aren't capitalized, even when case is kept.
+	got = Normalizer{SpellNumbers: true}.Words("It's 10 AM")
+	require.Equal(t, []string{"It's", "ten", "AM"}, got)
+}
+
+func TestEvaluate(t *testing.T) {
+	t.Parallel()
+
+	result := Evaluate(
+		"Smoke from hundreds of wildfires in Canada.",
+		"smoke from the hundreds of fires in",
+		nil,
+	)
+
+	require.Equal(t, 5, result.Matches)
+	require.Equal(t, 1, result.Substitutions)
+	require.Equal(t, 1, result.Deletions)
+	require.Equal(t, 1, result.Insertions)
+	require.Equal(t, 7, result.ReferenceWords)
+	require.InDelta(t, 3.0/7.0, result.WER(), 1e-9)
+	require.Greater(t, result.CER(), 0.0)
+
+	require.Equal(t, Edit{Type: EditInsertion, Hypothesis: "the"}, result.Alignment[2])
+
+	want := "" +
+		"REF: smoke from *** hundreds of wildfires in canada\n" +
+		"HYP: smoke from the hundreds of fires     in ******\n" +
+		"                I               S            D\n"
+
+	require.Equal(t, want, result.AlignmentView(0))
+
+	perfect := Evaluate("Good morning, professor.", "good morning professor", nil)
+	require.Equal(t, 0.0, perfect.WER())
+	require.Equal(t, 0.0, perfect.CER())
+}
+
+func TestAlign_Large(t *testing.T) {
+	t.Parallel()
+
+	b, err := os.ReadFile("../testdata/transcript/wildfires.txt")
+	require.NoError(t, err)
+
+	ref := DefaultNormalizer.Words(string(b))
+
+	// Drop every seventh word and change every eleventh.
+	var hyp []string
+	for i, w := range ref {
+		switch {
+		case i%7 == 0:
+		case i%11 == 0:
+			hyp = append(hyp, "x"+w)
+		default:
+			hyp = append(hyp, w)
+		}
+	}
+
+	require.Greater(t, (len(ref)+1)*(len(hyp)+1), maxAlignTableCells)
+
+	cost := func(edits []Edit) int {
+		n := 0
+		for _, e := range edits {
+			if e.Type != EditMatch {
+				n++
+			}
+		}
+		return n
+	}
+
+	edits := align(ref, hyp)
+	require.Equal(t, cost(alignTable(ref, hyp)), cost(edits))
+
+	var gotRef, gotHyp []string
+	for _, e := range edits {
+		if e.Type != EditInsertion {
+			gotRef = append(gotRef, e.Reference)
+		}
+		if e.Type != EditDeletion {
+			gotHyp = append(gotHyp, e.Hypothesis)
+		}
+	}
+
+	require.Equal(t, ref, gotRef)
+	require.Equal(t, hyp, gotHyp)
+}
+
+func TestEvaluateDir(t *testing.T) {
+	t.Parallel()
+
+	refDir, hypDir := t.TempDir(), t.TempDir()
+
+	b, err := os.ReadFile("../testdata/transcript/wildfires.txt")
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(filepath.Join(refDir, "wildfires.txt"), b, 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(hypDir, "wildfires.txt"), b, 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(refDir, "short.txt"), []byte("one two three four"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(hypDir, "short.txt"), []byte("1 2 3"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(refDir, "missing.txt"), []byte("hello"), 0o644))
+
+	corpus, err := EvaluateDir(refDir, hypDir, "*.txt", nil)
+	require.NoError(t, err)
+
+	require.Equal(t, []string{"missing.txt"}, corpus.Missing)
+	require.Len(t, corpus.Files, 2)
+	require.Equal(t, "short.txt", corpus.Files[0].Name)
+	require.Equal(t, 0.25, corpus.Files[0].WER())
+	require.Equal(t, 0.0, corpus.Files[1].WER())
+
+	require.Equal(t, 1, corpus.Deletions)
+	require.InDelta(t, 1.0/float64(corpus.ReferenceWords), corpus.WER(), 1e-9)
+	require.Equal(t, 0.125, corpus.MeanWER())
+}
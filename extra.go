@@ -0,0 +1,294 @@
+package assemblyai
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// DecodeExtra decodes the field with the given name from the unknown fields of
+// an API response into v. It returns false if there's no such field.
+//
+//	var v NewFeatureResult
+//	ok, err := assemblyai.DecodeExtra(transcript.Extra, "new_feature_result", &v)
+func DecodeExtra(extra map[string]json.RawMessage, name string, v interface{}) (bool, error) {
+	raw, ok := extra[name]
+	if !ok {
+		return false, nil
+	}
+
+	if err := json.Unmarshal(raw, v); err != nil {
+		return true, err
+	}
+
+	return true, nil
+}
+
+// UnmarshalJSON decodes Transcript and keeps unknown fields in Extra.
+func (t *Transcript) UnmarshalJSON(data []byte) error {
+	extra, err := unmarshalExtra(data, t, t.Extra)
+	if err != nil {
+		return err
+	}
+
+	t.Extra = extra
+
+	return nil
+}
+
+// MarshalJSON encodes Transcript along with the fields in Extra.
+func (t Transcript) MarshalJSON() ([]byte, error) {
+	type alias Transcript
+	return marshalExtra(alias(t), t.Extra)
+}
+
+// UnmarshalJSON decodes TranscriptListItem and keeps unknown fields in Extra.
+func (t *TranscriptListItem) UnmarshalJSON(data []byte) error {
+	extra, err := unmarshalExtra(data, t, t.Extra)
+	if err != nil {
+		return err
+	}
+
+	t.Extra = extra
+
+	return nil
+}
+
+// MarshalJSON encodes TranscriptListItem along with the fields in Extra.
+func (t TranscriptListItem) MarshalJSON() ([]byte, error) {
+	type alias TranscriptListItem
+	return marshalExtra(alias(t), t.Extra)
+}
+
+// UnmarshalJSON decodes LeMURActionItemsResponse and keeps unknown fields in Extra.
+func (r *LeMURActionItemsResponse) UnmarshalJSON(data []byte) error {
+	extra, err := unmarshalExtra(data, r, r.Extra)
+	if err != nil {
+		return err
+	}
+
+	r.Extra = extra
+
+	return nil
+}
+
+// MarshalJSON encodes LeMURActionItemsResponse along with the fields in Extra.
+func (r LeMURActionItemsResponse) MarshalJSON() ([]byte, error) {
+	type alias LeMURActionItemsResponse
+	return marshalExtra(alias(r), r.Extra)
+}
+
+// UnmarshalJSON decodes LeMURQuestionAnswerResponse and keeps unknown fields in Extra.
+func (r *LeMURQuestionAnswerResponse) UnmarshalJSON(data []byte) error {
+	extra, err := unmarshalExtra(data, r, r.Extra)
+	if err != nil {
+		return err
+	}
+
+	r.Extra = extra
+
+	return nil
+}
+
+// MarshalJSON encodes LeMURQuestionAnswerResponse along with the fields in Extra.
+func (r LeMURQuestionAnswerResponse) MarshalJSON() ([]byte, error) {
+	type alias LeMURQuestionAnswerResponse
+	return marshalExtra(alias(r), r.Extra)
+}
+
+// UnmarshalJSON decodes LeMURSummaryResponse and keeps unknown fields in Extra.
+func (r *LeMURSummaryResponse) UnmarshalJSON(data []byte) error {
+	extra, err := unmarshalExtra(data, r, r.Extra)
+	if err != nil {
+		return err
+	}
+
+	r.Extra = extra
+
+	return nil
+}
+
+// MarshalJSON encodes LeMURSummaryResponse along with the fields in Extra.
+func (r LeMURSummaryResponse) MarshalJSON() ([]byte, error) {
+	type alias LeMURSummaryResponse
+	return marshalExtra(alias(r), r.Extra)
+}
+
+// UnmarshalJSON decodes LeMURTaskResponse and keeps unknown fields in Extra.
+func (r *LeMURTaskResponse) UnmarshalJSON(data []byte) error {
+	extra, err := unmarshalExtra(data, r, r.Extra)
+	if err != nil {
+		return err
+	}
+
+	r.Extra = extra
+
+	return nil
+}
+
+// MarshalJSON encodes LeMURTaskResponse along with the fields in Extra.
+func (r LeMURTaskResponse) MarshalJSON() ([]byte, error) {
+	type alias LeMURTaskResponse
+	return marshalExtra(alias(r), r.Extra)
+}
+
+// unmarshalExtra decodes the JSON object in data into v, which must be a
+// pointer to a struct, and adds the object fields that don't map to any of its
+// fields to extra. Like encoding/json, keys are matched to field names
+// ignoring case, and fields that aren't in data are left unchanged.
+//
+// The object is only parsed once: each value is decoded straight into its
+// field. Like decoding into a map, the map is only allocated if it's nil and
+// there are unknown fields. Unknown fields that are null are dropped.
+func unmarshalExtra(data []byte, v interface{}, extra map[string]json.RawMessage) (map[string]json.RawMessage, error) {
+	var fields map[string]json.RawMessage
+
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+
+	dst := reflect.ValueOf(v).Elem()
+	known := knownFields(dst.Type())
+
+	for name, raw := range fields {
+		if index, ok := known.lookup(name); ok {
+			if err := json.Unmarshal(raw, dst.FieldByIndex(index).Addr().Interface()); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		if string(raw) == "null" {
+			continue
+		}
+
+		if extra == nil {
+			extra = make(map[string]json.RawMessage)
+		}
+
+		extra[name] = raw
+	}
+
+	return extra, nil
+}
+
+// marshalExtra encodes v, which must be a struct, and appends the extra fields
+// that don't collide with any of its fields, sorted by name.
+func marshalExtra(v interface{}, extra map[string]json.RawMessage) ([]byte, error) {
+	b, err := json.Marshal(v)
+	if err != nil || len(extra) == 0 {
+		return b, err
+	}
+
+	known := knownFields(reflect.TypeOf(v))
+
+	names := make([]string, 0, len(extra))
+	for name := range extra {
+		if _, ok := known.lookup(name); !ok {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+
+	// Drop the closing brace so that the extra fields can be appended.
+	b = b[:len(b)-1]
+
+	for _, name := range names {
+		key, err := json.Marshal(name)
+		if err != nil {
+			return nil, err
+		}
+
+		value, err := json.Marshal(extra[name])
+		if err != nil {
+			return nil, err
+		}
+
+		if len(b) > 1 {
+			b = append(b, ',')
+		}
+
+		b = append(b, key...)
+		b = append(b, ':')
+		b = append(b, value...)
+	}
+
+	return append(b, '}'), nil
+}
+
+var knownFieldsCache sync.Map
+
+// structFields maps the JSON names of the fields of a struct type to their
+// index, both as written and lowercased.
+type structFields struct {
+	exact  map[string][]int
+	folded map[string][]int
+}
+
+// lookup returns the index of the field with the given JSON name, preferring
+// an exact match over one that ignores case.
+func (f structFields) lookup(name string) ([]int, bool) {
+	if index, ok := f.exact[name]; ok {
+		return index, true
+	}
+
+	index, ok := f.folded[strings.ToLower(name)]
+
+	return index, ok
+}
+
+// knownFields returns the JSON fields of a struct type, including the fields
+// of embedded structs. Fields of the outer struct take precedence over
+// embedded ones with the same name.
+func knownFields(t reflect.Type) structFields {
+	if v, ok := knownFieldsCache.Load(t); ok {
+		return v.(structFields)
+	}
+
+	known := structFields{
+		exact:  make(map[string][]int),
+		folded: make(map[string][]int),
+	}
+
+	add := func(name string, index []int, embedded bool) {
+		if _, ok := known.exact[name]; !ok || !embedded {
+			known.exact[name] = index
+		}
+
+		if _, ok := known.folded[strings.ToLower(name)]; !ok || !embedded {
+			known.folded[strings.ToLower(name)] = index
+		}
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+
+		tag := f.Tag.Get("json")
+		if tag == "-" || f.PkgPath != "" && !f.Anonymous {
+			continue
+		}
+
+		name := strings.Split(tag, ",")[0]
+
+		if f.Anonymous && name == "" && f.Type.Kind() == reflect.Struct {
+			embedded := knownFields(f.Type)
+			for name, index := range embedded.exact {
+				add(name, append([]int{i}, index...), true)
+			}
+			continue
+		}
+
+		if name == "" {
+			name = f.Name
+		}
+
+		add(name, []int{i}, false)
+	}
+
+	knownFieldsCache.Store(t, known)
+
+	return known
+}
@@ -0,0 +1,172 @@
+package assemblyai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTranscript_Extra(t *testing.T) {
+	t.Parallel()
+
+	client, handler, teardown := setup()
+	defer teardown()
+
+	handler.HandleFunc("/v2/transcript/"+fakeTranscriptID, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{
+			"id": %q,
+			"status": "completed",
+			"speaker_count": null,
+			"emotion_result": {"status": "success", "emotions": ["joy", "surprise"]},
+			"emotion_count": 2
+		}`, fakeTranscriptID)
+	})
+
+	ctx := context.Background()
+
+	transcript, err := client.Transcripts.Get(ctx, fakeTranscriptID)
+	require.NoError(t, err)
+
+	require.Equal(t, fakeTranscriptID, ToString(transcript.ID))
+	require.Len(t, transcript.Extra, 2)
+
+	var emotions struct {
+		Status   string   `json:"status"`
+		Emotions []string `json:"emotions"`
+	}
+
+	ok, err := DecodeExtra(transcript.Extra, "emotion_result", &emotions)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, []string{"joy", "surprise"}, emotions.Emotions)
+
+	ok, err = DecodeExtra(transcript.Extra, "missing", &emotions)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	var count string
+
+	ok, err = DecodeExtra(transcript.Extra, "emotion_count", &count)
+	require.Error(t, err)
+	require.True(t, ok)
+
+	// Unknown fields survive a round trip.
+	b, err := json.Marshal(transcript)
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"id": "TRANSCRIPT_ID",
+		"status": "completed",
+		"auto_highlights_result": {},
+		"content_safety_labels": {},
+		"iab_categories_result": {},
+		"emotion_count": 2,
+		"emotion_result": {"status": "success", "emotions": ["joy", "surprise"]}
+	}`, string(b))
+
+	var decoded Transcript
+	require.NoError(t, json.Unmarshal(b, &decoded))
+	require.Len(t, decoded.Extra, 2)
+	require.JSONEq(t, string(transcript.Extra["emotion_result"]), string(decoded.Extra["emotion_result"]))
+}
+
+func TestExtra_NoUnknownFields(t *testing.T) {
+	t.Parallel()
+
+	var item TranscriptListItem
+	require.NoError(t, json.Unmarshal([]byte(`{"id": "a", "status": "queued"}`), &item))
+	require.Nil(t, item.Extra)
+
+	b, err := json.Marshal(TranscriptList{Transcripts: []TranscriptListItem{item}})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"page_details": {}, "transcripts": [{"id": "a", "status": "queued"}]}`, string(b))
+
+	// Extra fields don't override known fields.
+	b, err = json.Marshal(TranscriptListItem{
+		ID:    String("a"),
+		Extra: map[string]json.RawMessage{"id": json.RawMessage(`"b"`)},
+	})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"id": "a"}`, string(b))
+}
+
+func TestExtra_DecodeIntoExistingValue(t *testing.T) {
+	t.Parallel()
+
+	transcript := Transcript{
+		ID:    String("a"),
+		Text:  String("hello"),
+		Extra: map[string]json.RawMessage{"cluster_id": json.RawMessage(`"c1"`)},
+	}
+
+	require.NoError(t, json.Unmarshal([]byte(`{"status": "completed", "speaker_count": 2}`), &transcript))
+
+	require.Equal(t, "a", ToString(transcript.ID))
+	require.Equal(t, "hello", ToString(transcript.Text))
+	require.Equal(t, TranscriptStatusCompleted, transcript.Status)
+	require.Equal(t, map[string]json.RawMessage{
+		"cluster_id":    json.RawMessage(`"c1"`),
+		"speaker_count": json.RawMessage(`2`),
+	}, transcript.Extra)
+}
+
+func TestLeMURResponses_Extra(t *testing.T) {
+	t.Parallel()
+
+	data := []byte(`{"request_id": "req", "response": "ok", "usage": {"input_tokens": 10}, "model": "anthropic/claude-3-5-sonnet"}`)
+
+	var task LeMURTaskResponse
+	require.NoError(t, json.Unmarshal(data, &task))
+	require.Equal(t, "req", ToString(task.RequestID))
+	require.Equal(t, int64(10), ToInt64(task.Usage.InputTokens))
+	require.Equal(t, json.RawMessage(`"anthropic/claude-3-5-sonnet"`), task.Extra["model"])
+
+	var summary LeMURSummaryResponse
+	require.NoError(t, json.Unmarshal(data, &summary))
+	require.Equal(t, "ok", ToString(summary.Response))
+	require.Len(t, summary.Extra, 1)
+
+	var actionItems LeMURActionItemsResponse
+	require.NoError(t, json.Unmarshal(data, &actionItems))
+	require.Len(t, actionItems.Extra, 1)
+
+	var qa LeMURQuestionAnswerResponse
+	require.NoError(t, json.Unmarshal([]byte(`{"request_id": "req", "response": [{"question": "q", "answer": "a"}], "model": "m"}`), &qa))
+	require.Equal(t, "a", ToString(qa.Response[0].Answer))
+	require.Len(t, qa.Extra, 1)
+
+	b, err := json.Marshal(task)
+	require.NoError(t, err)
+
+	var roundTrip map[string]interface{}
+	require.NoError(t, json.Unmarshal(b, &roundTrip))
+	require.Equal(t, "anthropic/claude-3-5-sonnet", roundTrip["model"])
+}
+
+func TestExtra_KeysIgnoreCase(t *testing.T) {
+	t.Parallel()
+
+	var item TranscriptListItem
+	require.NoError(t, json.Unmarshal([]byte(`{"ID": "a", "Status": "queued", "Cluster_ID": "c1"}`), &item))
+	require.Equal(t, "a", ToString(item.ID))
+	require.Equal(t, TranscriptStatusQueued, item.Status)
+	require.Equal(t, map[string]json.RawMessage{"Cluster_ID": json.RawMessage(`"c1"`)}, item.Extra)
+
+	var task LeMURTaskResponse
+	require.NoError(t, json.Unmarshal([]byte(`{"Request_ID": "req", "RESPONSE": "ok"}`), &task))
+	require.Equal(t, "req", ToString(task.RequestID))
+	require.Equal(t, "ok", ToString(task.Response))
+	require.Nil(t, task.Extra)
+
+	// Extra fields that differ from known fields only in case are dropped too.
+	b, err := json.Marshal(TranscriptListItem{
+		ID:    String("a"),
+		Extra: map[string]json.RawMessage{"ID": json.RawMessage(`"b"`)},
+	})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"id": "a"}`, string(b))
+}
@@ -1,5 +1,7 @@
 package assemblyai
 
+import "time"
+
 func String(v string) *string {
 	return &v
 }
@@ -43,3 +45,22 @@ func ToBool(p *bool) (v bool) {
 	}
 	return *p
 }
+
+// toDuration converts a timestamp in milliseconds to a time.Duration.
+func toDuration(ms *int64) time.Duration {
+	return time.Duration(ToInt64(ms)) * time.Millisecond
+}
+
+func minDuration(a, b time.Duration) time.Duration {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxDuration(a, b time.Duration) time.Duration {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,368 @@
+package assemblyai
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// DetectedEntity is a unique entity along with every time it's mentioned.
+type DetectedEntity struct {
+	Type EntityType
+
+	// The text of the first mention of the entity.
+	Text string
+
+	// When the entity is mentioned, in chronological order.
+	Occurrences []TimeRange
+}
+
+// EntityGroup holds the unique entities of a single type.
+type EntityGroup struct {
+	Type EntityType
+
+	// The entities in order of first mention.
+	Entities []DetectedEntity
+}
+
+// EntityGroups groups the detected entities of the transcript by type.
+// Mentions that only differ by case, whitespace or surrounding punctuation are
+// deduplicated into a single entity.
+//
+// Groups are ordered like [EntityTypes], with unknown types last in
+// alphabetical order.
+func (t Transcript) EntityGroups() []EntityGroup {
+	entities := make([]Entity, len(t.Entities))
+	copy(entities, t.Entities)
+
+	sort.SliceStable(entities, func(i, j int) bool {
+		return ToInt64(entities[i].Start) < ToInt64(entities[j].Start)
+	})
+
+	var groups []EntityGroup
+
+	groupIndex := make(map[EntityType]int)
+	entityIndex := make(map[EntityType]map[string]int)
+
+	for _, e := range entities {
+		key := entityKey(ToString(e.Text))
+		if key == "" {
+			continue
+		}
+
+		gi, ok := groupIndex[e.EntityType]
+		if !ok {
+			gi = len(groups)
+			groupIndex[e.EntityType] = gi
+			entityIndex[e.EntityType] = make(map[string]int)
+			groups = append(groups, EntityGroup{Type: e.EntityType})
+		}
+
+		group := &groups[gi]
+
+		occurrence := TimeRange{Start: toDuration(e.Start), End: toDuration(e.End)}
+
+		if ei, ok := entityIndex[e.EntityType][key]; ok {
+			group.Entities[ei].Occurrences = append(group.Entities[ei].Occurrences, occurrence)
+			continue
+		}
+
+		entityIndex[e.EntityType][key] = len(group.Entities)
+
+		group.Entities = append(group.Entities, DetectedEntity{
+			Type:        e.EntityType,
+			Text:        strings.TrimSpace(ToString(e.Text)),
+			Occurrences: []TimeRange{occurrence},
+		})
+	}
+
+	order := make(map[EntityType]int)
+	for i, typ := range EntityTypes() {
+		order[typ] = i
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		oi, knownI := order[groups[i].Type]
+		oj, knownJ := order[groups[j].Type]
+
+		switch {
+		case knownI && knownJ:
+			return oi < oj
+		case knownI != knownJ:
+			return knownI
+		default:
+			return groups[i].Type < groups[j].Type
+		}
+	})
+
+	return groups
+}
+
+// EntitiesByType returns the unique entities of the given type, in order of
+// first mention.
+func (t Transcript) EntitiesByType(typ EntityType) []DetectedEntity {
+	for _, g := range t.EntityGroups() {
+		if g.Type == typ {
+			return g.Entities
+		}
+	}
+	return nil
+}
+
+// entityKey normalizes the text of an entity for deduplication.
+func entityKey(text string) string {
+	text = strings.TrimFunc(text, func(r rune) bool {
+		return unicode.IsSpace(r) || unicode.IsPunct(r)
+	})
+
+	return strings.ToLower(strings.Join(strings.Fields(text), " "))
+}
+
+// Topic is an IAB category detected in the transcript.
+type Topic struct {
+	// The full IAB label, for example "Science>Environment".
+	Label string
+
+	// The label split into its supertopics and subtopic.
+	Path []string
+
+	// The relevance of the topic to the entire transcript, from 0 to 1.
+	Relevance float64
+
+	// The parts of the transcript where the topic was detected, in
+	// chronological order.
+	Spans []TopicSpan
+}
+
+// TopicSpan is a part of the transcript in which a topic was detected.
+type TopicSpan struct {
+	TimeRange
+
+	Text string
+
+	// The relevance of the topic to this part of the transcript.
+	Relevance float64
+}
+
+// TopTopics returns up to n topics whose overall relevance is at least
+// minRelevance, from most to least relevant. If n is zero or negative, all
+// matching topics are returned.
+func (r TopicDetectionModelResult) TopTopics(n int, minRelevance float64) []Topic {
+	var topics []Topic
+
+	for label, relevance := range r.Summary {
+		if relevance < minRelevance {
+			continue
+		}
+
+		topics = append(topics, Topic{
+			Label:     label,
+			Path:      ParseTopicLabel(label),
+			Relevance: relevance,
+		})
+	}
+
+	sort.Slice(topics, func(i, j int) bool {
+		if topics[i].Relevance != topics[j].Relevance {
+			return topics[i].Relevance > topics[j].Relevance
+		}
+		return topics[i].Label < topics[j].Label
+	})
+
+	if n > 0 && len(topics) > n {
+		topics = topics[:n]
+	}
+
+	results := make([]TopicDetectionResult, len(r.Results))
+	copy(results, r.Results)
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return ToInt64(results[i].Timestamp.Start) < ToInt64(results[j].Timestamp.Start)
+	})
+
+	for i := range topics {
+		for _, result := range results {
+			for _, l := range result.Labels {
+				if ToString(l.Label) != topics[i].Label {
+					continue
+				}
+
+				topics[i].Spans = append(topics[i].Spans, TopicSpan{
+					TimeRange: TimeRange{
+						Start: toDuration(result.Timestamp.Start),
+						End:   toDuration(result.Timestamp.End),
+					},
+					Text:      ToString(result.Text),
+					Relevance: ToFloat64(l.Relevance),
+				})
+			}
+		}
+	}
+
+	return topics
+}
+
+// ParseTopicLabel splits an IAB label into its supertopics and subtopic.
+func ParseTopicLabel(label string) []string {
+	var path []string
+
+	for _, part := range strings.Split(label, ">") {
+		if part = strings.TrimSpace(part); part != "" {
+			path = append(path, part)
+		}
+	}
+
+	return path
+}
+
+// TopicNode is a node in the hierarchy of detected IAB categories.
+type TopicNode struct {
+	// The last part of the label, for example "Environment".
+	Name string
+
+	// The full label, for example "Science>Environment". Empty for the root.
+	Label string
+
+	// The relevance of the topic to the entire transcript, or zero if it
+	// wasn't detected itself but only some of its subtopics were.
+	Relevance float64
+
+	// The subtopics, in alphabetical order.
+	Children []*TopicNode
+}
+
+// TopicTree arranges all detected topics into a tree according to their IAB
+// labels. The returned root node has no name.
+func (r TopicDetectionModelResult) TopicTree() *TopicNode {
+	labels := make(map[string]bool)
+
+	for label := range r.Summary {
+		labels[label] = true
+	}
+
+	for _, result := range r.Results {
+		for _, l := range result.Labels {
+			if l.Label != nil {
+				labels[*l.Label] = true
+			}
+		}
+	}
+
+	root := &TopicNode{}
+
+	for label := range labels {
+		node := root
+		path := ParseTopicLabel(label)
+
+		for i, name := range path {
+			child := node.child(name)
+
+			if child == nil {
+				child = &TopicNode{Name: name, Label: strings.Join(path[:i+1], ">")}
+				node.Children = append(node.Children, child)
+			}
+
+			node = child
+		}
+
+		if node != root {
+			node.Relevance = r.Summary[label]
+		}
+	}
+
+	root.Walk(func(n *TopicNode, _ int) bool {
+		sort.Slice(n.Children, func(i, j int) bool {
+			return n.Children[i].Name < n.Children[j].Name
+		})
+		return true
+	})
+
+	return root
+}
+
+// Find returns the node with the given label, or nil if there's none.
+func (n *TopicNode) Find(label string) *TopicNode {
+	node := n
+
+	for _, name := range ParseTopicLabel(label) {
+		if node = node.child(name); node == nil {
+			return nil
+		}
+	}
+
+	return node
+}
+
+// Walk calls fn for the node and all of its descendants, depth-first, with
+// the depth relative to n. Children of a node are skipped if fn returns false.
+func (n *TopicNode) Walk(fn func(node *TopicNode, depth int) bool) {
+	n.walk(fn, 0)
+}
+
+func (n *TopicNode) walk(fn func(node *TopicNode, depth int) bool, depth int) {
+	if !fn(n, depth) {
+		return
+	}
+
+	for _, child := range n.Children {
+		child.walk(fn, depth+1)
+	}
+}
+
+func (n *TopicNode) child(name string) *TopicNode {
+	for _, c := range n.Children {
+		if c.Name == name {
+			return c
+		}
+	}
+	return nil
+}
+
+// Highlight is a key phrase detected in the transcript.
+type Highlight struct {
+	Text string
+
+	// The number of times the phrase is mentioned.
+	Count int64
+
+	// The relevance of the phrase to the entire transcript.
+	Rank float64
+
+	// When the phrase is mentioned, in chronological order.
+	Occurrences []TimeRange
+}
+
+// Ranked returns the key phrases from most to least relevant.
+func (r AutoHighlightsResult) Ranked() []Highlight {
+	highlights := make([]Highlight, 0, len(r.Results))
+
+	for _, result := range r.Results {
+		h := Highlight{
+			Text:  ToString(result.Text),
+			Count: ToInt64(result.Count),
+			Rank:  ToFloat64(result.Rank),
+		}
+
+		for _, ts := range result.Timestamps {
+			h.Occurrences = append(h.Occurrences, TimeRange{
+				Start: toDuration(ts.Start),
+				End:   toDuration(ts.End),
+			})
+		}
+
+		sort.SliceStable(h.Occurrences, func(i, j int) bool {
+			return h.Occurrences[i].Start < h.Occurrences[j].Start
+		})
+
+		highlights = append(highlights, h)
+	}
+
+	sort.SliceStable(highlights, func(i, j int) bool {
+		if highlights[i].Rank != highlights[j].Rank {
+			return highlights[i].Rank > highlights[j].Rank
+		}
+		return highlights[i].Count > highlights[j].Count
+	})
+
+	return highlights
+}
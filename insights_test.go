@@ -0,0 +1,167 @@
+package assemblyai
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTranscript_EntityGroups(t *testing.T) {
+	t.Parallel()
+
+	transcript := Transcript{
+		Entities: []Entity{
+			{EntityType: EntityTypeLocation, Text: String("Baltimore"), Start: Int64(5000), End: Int64(5600)},
+			{EntityType: EntityTypePersonName, Text: String("Peter DeCarlo"), Start: Int64(1000), End: Int64(1800)},
+			{EntityType: EntityTypeLocation, Text: String("Canada"), Start: Int64(2000), End: Int64(2400)},
+			{EntityType: EntityTypeLocation, Text: String("canada,"), Start: Int64(9000), End: Int64(9400)},
+			{EntityType: "starship", Text: String("Enterprise"), Start: Int64(100), End: Int64(400)},
+		},
+	}
+
+	groups := transcript.EntityGroups()
+
+	var types []EntityType
+	for _, g := range groups {
+		types = append(types, g.Type)
+	}
+
+	require.Equal(t, []EntityType{EntityTypeLocation, EntityTypePersonName, "starship"}, types)
+
+	require.Equal(t, []DetectedEntity{
+		{
+			Type: EntityTypeLocation,
+			Text: "Canada",
+			Occurrences: []TimeRange{
+				{Start: 2 * time.Second, End: 2400 * time.Millisecond},
+				{Start: 9 * time.Second, End: 9400 * time.Millisecond},
+			},
+		},
+		{
+			Type:        EntityTypeLocation,
+			Text:        "Baltimore",
+			Occurrences: []TimeRange{{Start: 5 * time.Second, End: 5600 * time.Millisecond}},
+		},
+	}, transcript.EntitiesByType(EntityTypeLocation))
+
+	require.Nil(t, transcript.EntitiesByType(EntityTypeDrug))
+}
+
+const fakeIABCategoriesResult = `{
+	"status": "success",
+	"results": [
+		{
+			"text": "Smoke from hundreds of wildfires in Canada is triggering air quality alerts.",
+			"labels": [
+				{"relevance": 0.98, "label": "Science>Environment"},
+				{"relevance": 0.41, "label": "Travel>TravelLocations"}
+			],
+			"timestamp": {"start": 250, "end": 6350}
+		},
+		{
+			"text": "The concentration of particulate matter is ten times the annual average.",
+			"labels": [
+				{"relevance": 0.87, "label": "Science>Environment"},
+				{"relevance": 0.62, "label": "Medical Health>Diseases and Conditions>Lung and Respiratory Health"}
+			],
+			"timestamp": {"start": 42000, "end": 51000}
+		}
+	],
+	"summary": {
+		"Science>Environment": 0.95,
+		"Medical Health>Diseases and Conditions>Lung and Respiratory Health": 0.6,
+		"Travel>TravelLocations": 0.1
+	}
+}`
+
+func TestTopicDetectionModelResult_TopTopics(t *testing.T) {
+	t.Parallel()
+
+	var result TopicDetectionModelResult
+	require.NoError(t, json.Unmarshal([]byte(fakeIABCategoriesResult), &result))
+
+	topics := result.TopTopics(0, 0.5)
+	require.Len(t, topics, 2)
+
+	require.Equal(t, "Science>Environment", topics[0].Label)
+	require.Equal(t, []string{"Science", "Environment"}, topics[0].Path)
+	require.Equal(t, 0.95, topics[0].Relevance)
+	require.Len(t, topics[0].Spans, 2)
+	require.Equal(t, 250*time.Millisecond, topics[0].Spans[0].Start)
+	require.Equal(t, 0.87, topics[0].Spans[1].Relevance)
+
+	require.Equal(t, []string{"Medical Health", "Diseases and Conditions", "Lung and Respiratory Health"}, topics[1].Path)
+	require.Len(t, topics[1].Spans, 1)
+	require.True(t, strings.HasPrefix(topics[1].Spans[0].Text, "The concentration"))
+
+	topics = result.TopTopics(1, 0)
+	require.Len(t, topics, 1)
+	require.Equal(t, "Science>Environment", topics[0].Label)
+}
+
+func TestTopicDetectionModelResult_TopicTree(t *testing.T) {
+	t.Parallel()
+
+	var result TopicDetectionModelResult
+	require.NoError(t, json.Unmarshal([]byte(fakeIABCategoriesResult), &result))
+
+	tree := result.TopicTree()
+
+	var lines []string
+
+	tree.Walk(func(n *TopicNode, depth int) bool {
+		if depth > 0 {
+			lines = append(lines, strings.Repeat("  ", depth-1)+n.Name)
+		}
+		return true
+	})
+
+	require.Equal(t, []string{
+		"Medical Health",
+		"  Diseases and Conditions",
+		"    Lung and Respiratory Health",
+		"Science",
+		"  Environment",
+		"Travel",
+		"  TravelLocations",
+	}, lines)
+
+	node := tree.Find("Science>Environment")
+	require.NotNil(t, node)
+	require.Equal(t, "Science>Environment", node.Label)
+	require.Equal(t, 0.95, node.Relevance)
+
+	require.Zero(t, tree.Find("Medical Health").Relevance)
+	require.Nil(t, tree.Find("Science>Physics"))
+}
+
+func TestAutoHighlightsResult_Ranked(t *testing.T) {
+	t.Parallel()
+
+	result := AutoHighlightsResult{
+		Results: []AutoHighlightResult{
+			{Text: String("smoke"), Count: Int64(2), Rank: Float64(0.05), Timestamps: []Timestamp{
+				{Start: Int64(9000), End: Int64(9300)},
+				{Start: Int64(1000), End: Int64(1300)},
+			}},
+			{Text: String("air quality alerts"), Count: Int64(1), Rank: Float64(0.08), Timestamps: []Timestamp{
+				{Start: Int64(3978), End: Int64(5114)},
+			}},
+			{Text: String("wildfires"), Count: Int64(3), Rank: Float64(0.05)},
+		},
+	}
+
+	ranked := result.Ranked()
+
+	require.Equal(t, "air quality alerts", ranked[0].Text)
+	require.Equal(t, []TimeRange{{Start: 3978 * time.Millisecond, End: 5114 * time.Millisecond}}, ranked[0].Occurrences)
+
+	// Ties are broken by the number of mentions.
+	require.Equal(t, "wildfires", ranked[1].Text)
+
+	require.Equal(t, "smoke", ranked[2].Text)
+	require.Equal(t, time.Second, ranked[2].Occurrences[0].Start)
+}
@@ -0,0 +1,56 @@
+package assemblyai
+
+import (
+	"context"
+	"io"
+)
+
+// Transcriber is the interface implemented by [TranscriptService]. Depend on
+// it instead of [TranscriptService] to replace the service in tests, for
+// example with [MockTranscriber].
+type Transcriber interface {
+	SubmitFromURL(ctx context.Context, audioURL string, opts *TranscriptOptionalParams) (Transcript, error)
+	SubmitFromReader(ctx context.Context, reader io.Reader, params *TranscriptOptionalParams) (Transcript, error)
+	TranscribeFromURL(ctx context.Context, audioURL string, opts *TranscriptOptionalParams) (Transcript, error)
+	TranscribeFromReader(ctx context.Context, reader io.Reader, opts *TranscriptOptionalParams) (Transcript, error)
+	Wait(ctx context.Context, transcriptID string) (Transcript, error)
+	Get(ctx context.Context, transcriptID string) (Transcript, error)
+	Delete(ctx context.Context, transcriptID string) (Transcript, error)
+	List(ctx context.Context, options ListTranscriptParams) (TranscriptList, error)
+	GetSentences(ctx context.Context, transcriptID string) (SentencesResponse, error)
+	GetParagraphs(ctx context.Context, transcriptID string) (ParagraphsResponse, error)
+	GetSubtitles(ctx context.Context, transcriptID string, format SubtitleFormat, opts *TranscriptGetSubtitlesOptions) ([]byte, error)
+	GetRedactedAudio(ctx context.Context, transcriptID string) (RedactedAudioResponse, error)
+	DownloadRedactedAudio(ctx context.Context, transcriptID string, w io.Writer, opts *DownloadRedactedAudioOptions) (RedactedAudioDownload, error)
+	WordSearch(ctx context.Context, transcriptID string, words []string) (WordSearchResponse, error)
+	Sync(ctx context.Context, store Store, opts *SyncOptions) (SyncResult, error)
+}
+
+// LeMURer is the interface implemented by [LeMURService]. Depend on it instead
+// of [LeMURService] to replace the service in tests, for example with
+// [MockLeMURer].
+type LeMURer interface {
+	Question(ctx context.Context, params LeMURQuestionAnswerParams) (LeMURQuestionAnswerResponse, error)
+	Summarize(ctx context.Context, params LeMURSummaryParams) (LeMURSummaryResponse, error)
+	ActionItems(ctx context.Context, params LeMURActionItemsParams) (LeMURActionItemsResponse, error)
+	Task(ctx context.Context, params LeMURTaskParams) (LeMURTaskResponse, error)
+	PurgeRequestData(ctx context.Context, requestID string) (PurgeLeMURRequestDataResponse, error)
+	GetResponseData(ctx context.Context, requestID string, response interface{}) error
+}
+
+// RealTimeStreamer is the interface implemented by [RealTimeClient]. Depend on
+// it instead of [RealTimeClient] to replace the client in tests, for example
+// with [MockRealTimeStreamer].
+type RealTimeStreamer interface {
+	Connect(ctx context.Context) error
+	Disconnect(ctx context.Context, waitForSessionTermination bool) error
+	Send(ctx context.Context, samples []byte) error
+	ForceEndUtterance(ctx context.Context) error
+	SetEndUtteranceSilenceThreshold(ctx context.Context, threshold int64) error
+}
+
+var (
+	_ Transcriber      = (*TranscriptService)(nil)
+	_ LeMURer          = (*LeMURService)(nil)
+	_ RealTimeStreamer = (*RealTimeClient)(nil)
+)
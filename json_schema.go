@@ -0,0 +1,321 @@
+package assemblyai
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// JSONSchema is a subset of JSON Schema, used to describe the structured
+// output expected from LeMUR. See [JSONSchemaFor].
+type JSONSchema struct {
+	// One of "object", "array", "string", "integer", "number" and "boolean",
+	// or empty for any value.
+	Type string `json:"type,omitempty"`
+
+	Format      string   `json:"format,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Enum        []string `json:"enum,omitempty"`
+
+	// Schema of the elements of an array.
+	Items *JSONSchema `json:"items,omitempty"`
+
+	// Schemas of the properties of an object, and the names of the ones that
+	// must be present.
+	Properties map[string]*JSONSchema `json:"properties,omitempty"`
+	Required   []string               `json:"required,omitempty"`
+
+	// Schema of the values of an object with arbitrary keys.
+	AdditionalProperties *JSONSchema `json:"additionalProperties,omitempty"`
+}
+
+// SchemaError describes a value that doesn't match a [JSONSchema].
+type SchemaError struct {
+	// Location of the value, such as "$.items[0].name".
+	Path string
+
+	// A description of the problem.
+	Message string
+}
+
+// Error returns the path of the value followed by the problem.
+func (e SchemaError) Error() string {
+	return e.Path + ": " + e.Message
+}
+
+var (
+	timeType            = reflect.TypeOf(time.Time{})
+	rawMessageType      = reflect.TypeOf(json.RawMessage{})
+	jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// JSONSchemaFor derives a schema from the type of v, which is usually a
+// pointer to a struct.
+//
+// Struct fields are named after their json tags, and are required unless the
+// tag has the omitempty option. The description tag describes a field, and
+// the enum tag lists the allowed values of a string field, separated by
+// commas. On a slice of strings, the enum tag applies to its elements:
+//
+//	type Ticket struct {
+//		Customer string   `json:"customer" description:"Full name of the customer"`
+//		Category string   `json:"category" enum:"billing,technical,other"`
+//		Labels   []string `json:"labels" enum:"urgent,vip"`
+//		Refund   float64  `json:"refund,omitempty"`
+//	}
+func JSONSchemaFor(v interface{}) (*JSONSchema, error) {
+	t := reflect.TypeOf(v)
+	if t == nil {
+		return nil, fmt.Errorf("can't derive a JSON schema for nil")
+	}
+
+	return schemaFor(t, map[reflect.Type]bool{})
+}
+
+func schemaFor(t reflect.Type, visiting map[reflect.Type]bool) (*JSONSchema, error) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch {
+	case t == timeType:
+		return &JSONSchema{Type: "string", Format: "date-time"}, nil
+	case t == rawMessageType:
+		return &JSONSchema{}, nil
+	case reflect.PtrTo(t).Implements(jsonUnmarshalerType):
+		return &JSONSchema{}, nil
+	case reflect.PtrTo(t).Implements(textUnmarshalerType):
+		return &JSONSchema{Type: "string"}, nil
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &JSONSchema{Type: "boolean"}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &JSONSchema{Type: "integer"}, nil
+	case reflect.Float32, reflect.Float64:
+		return &JSONSchema{Type: "number"}, nil
+	case reflect.String:
+		return &JSONSchema{Type: "string"}, nil
+	case reflect.Interface:
+		return &JSONSchema{}, nil
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &JSONSchema{Type: "string", Format: "byte"}, nil
+		}
+
+		items, err := schemaFor(t.Elem(), visiting)
+		if err != nil {
+			return nil, err
+		}
+
+		return &JSONSchema{Type: "array", Items: items}, nil
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("can't derive a JSON schema for %s: keys must be strings", t)
+		}
+
+		values, err := schemaFor(t.Elem(), visiting)
+		if err != nil {
+			return nil, err
+		}
+
+		return &JSONSchema{Type: "object", AdditionalProperties: values}, nil
+	case reflect.Struct:
+		// Recursive types can't be described without references, so allow
+		// any value where a type refers to itself.
+		if visiting[t] {
+			return &JSONSchema{}, nil
+		}
+
+		visiting[t] = true
+		defer delete(visiting, t)
+
+		schema := &JSONSchema{Type: "object", Properties: map[string]*JSONSchema{}}
+
+		if err := addFields(schema, t, visiting); err != nil {
+			return nil, err
+		}
+
+		sort.Strings(schema.Required)
+
+		return schema, nil
+	}
+
+	return nil, fmt.Errorf("can't derive a JSON schema for %s", t)
+}
+
+// addFields adds the fields of a struct to the properties of schema,
+// including the fields of embedded structs.
+func addFields(schema *JSONSchema, t reflect.Type, visiting map[reflect.Type]bool) error {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+
+		name, opts, _ := strings.Cut(tag, ",")
+
+		ft := f.Type
+		for ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+
+		if f.Anonymous && name == "" && ft.Kind() == reflect.Struct {
+			if err := addFields(schema, ft, visiting); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if !f.IsExported() {
+			continue
+		}
+
+		if name == "" {
+			name = f.Name
+		}
+
+		prop, err := schemaFor(f.Type, visiting)
+		if err != nil {
+			return err
+		}
+
+		prop.Description = f.Tag.Get("description")
+
+		if enum := f.Tag.Get("enum"); enum != "" {
+			switch {
+			case prop.Type == "string":
+				prop.Enum = strings.Split(enum, ",")
+			case prop.Type == "array" && prop.Items != nil && prop.Items.Type == "string":
+				prop.Items.Enum = strings.Split(enum, ",")
+			default:
+				return fmt.Errorf("can't use the enum tag on %s.%s: it must be a string or a slice of strings", t, f.Name)
+			}
+		}
+
+		schema.Properties[name] = prop
+
+		if !strings.Contains(","+opts+",", ",omitempty,") {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+
+	return nil
+}
+
+// Validate checks a JSON-encoded value against the schema, and returns every
+// problem found. Null is only allowed for properties that aren't required.
+func (s *JSONSchema) Validate(data []byte) []SchemaError {
+	dec := json.NewDecoder(strings.NewReader(string(data)))
+	dec.UseNumber()
+
+	var v interface{}
+
+	if err := dec.Decode(&v); err != nil {
+		return []SchemaError{{Path: "$", Message: "invalid JSON: " + err.Error()}}
+	}
+
+	var errs []SchemaError
+
+	s.validate("$", v, &errs)
+
+	return errs
+}
+
+func (s *JSONSchema) validate(path string, v interface{}, errs *[]SchemaError) {
+	add := func(format string, args ...interface{}) {
+		*errs = append(*errs, SchemaError{Path: path, Message: fmt.Sprintf(format, args...)})
+	}
+
+	switch s.Type {
+	case "":
+	case "object":
+		obj, ok := v.(map[string]interface{})
+		if !ok {
+			add("must be an object")
+			return
+		}
+
+		for _, name := range s.Required {
+			if value, ok := obj[name]; !ok || value == nil {
+				*errs = append(*errs, SchemaError{Path: path + "." + name, Message: "is required"})
+			}
+		}
+
+		keys := make([]string, 0, len(obj))
+		for key := range obj {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			prop, ok := s.Properties[key]
+			if !ok {
+				prop = s.AdditionalProperties
+			}
+
+			if prop != nil && obj[key] != nil {
+				prop.validate(path+"."+key, obj[key], errs)
+			}
+		}
+	case "array":
+		arr, ok := v.([]interface{})
+		if !ok {
+			add("must be an array")
+			return
+		}
+
+		if s.Items != nil {
+			for i, item := range arr {
+				s.Items.validate(path+"["+strconv.Itoa(i)+"]", item, errs)
+			}
+		}
+	case "string":
+		str, ok := v.(string)
+		if !ok {
+			add("must be a string")
+			return
+		}
+
+		if len(s.Enum) > 0 && !containsString(s.Enum, str) {
+			add("must be one of %s", strings.Join(s.Enum, ", "))
+		}
+	case "integer":
+		n, ok := v.(json.Number)
+		if !ok {
+			add("must be an integer")
+			return
+		}
+
+		if _, err := n.Int64(); err != nil {
+			add("must be an integer")
+		}
+	case "number":
+		if _, ok := v.(json.Number); !ok {
+			add("must be a number")
+		}
+	case "boolean":
+		if _, ok := v.(bool); !ok {
+			add("must be a boolean")
+		}
+	}
+}
+
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,93 @@
+package assemblyai
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type schemaTicket struct {
+	Customer string            `json:"customer" description:"Full name of the customer"`
+	Category string            `json:"category" enum:"billing,technical,other"`
+	Refund   *float64          `json:"refund,omitempty"`
+	Items    []schemaItem      `json:"items"`
+	Tags     map[string]string `json:"tags,omitempty"`
+	Created  time.Time         `json:"created,omitempty"`
+	Ignored  string            `json:"-"`
+
+	schemaBase
+}
+
+type schemaBase struct {
+	ID int `json:"id"`
+}
+
+type schemaItem struct {
+	Name string `json:"name"`
+}
+
+func TestJSONSchemaFor(t *testing.T) {
+	t.Parallel()
+
+	schema, err := JSONSchemaFor(&schemaTicket{})
+	require.NoError(t, err)
+
+	require.Equal(t, &JSONSchema{
+		Type: "object",
+		Properties: map[string]*JSONSchema{
+			"customer": {Type: "string", Description: "Full name of the customer"},
+			"category": {Type: "string", Enum: []string{"billing", "technical", "other"}},
+			"refund":   {Type: "number"},
+			"items": {Type: "array", Items: &JSONSchema{
+				Type:       "object",
+				Properties: map[string]*JSONSchema{"name": {Type: "string"}},
+				Required:   []string{"name"},
+			}},
+			"tags":    {Type: "object", AdditionalProperties: &JSONSchema{Type: "string"}},
+			"created": {Type: "string", Format: "date-time"},
+			"id":      {Type: "integer"},
+		},
+		Required: []string{"category", "customer", "id", "items"},
+	}, schema)
+
+	_, err = JSONSchemaFor(map[int]string{})
+	require.Error(t, err)
+
+	// Enums apply to the elements of slices, and only to strings.
+	schema, err = JSONSchemaFor(&struct {
+		Labels []string `json:"labels" enum:"urgent,vip"`
+	}{})
+	require.NoError(t, err)
+	require.Equal(t, []string{"urgent", "vip"}, schema.Properties["labels"].Items.Enum)
+	require.Nil(t, schema.Properties["labels"].Enum)
+	require.NotEmpty(t, schema.Validate([]byte(`{"labels": ["urgent", "other"]}`)))
+
+	_, err = JSONSchemaFor(&struct {
+		Priority int `json:"priority" enum:"1,2,3"`
+	}{})
+	require.Error(t, err)
+}
+
+func TestJSONSchema_Validate(t *testing.T) {
+	t.Parallel()
+
+	schema, err := JSONSchemaFor(&schemaTicket{})
+	require.NoError(t, err)
+
+	errs := schema.Validate([]byte(`{"customer": "Alice", "category": "billing", "items": [], "id": 1, "refund": null}`))
+	require.Empty(t, errs)
+
+	errs = schema.Validate([]byte(`{"customer": null, "category": "shipping", "items": [{"name": 1}], "id": 1.5, "refund": "10"}`))
+	require.Equal(t, []SchemaError{
+		{Path: "$.customer", Message: "is required"},
+		{Path: "$.category", Message: "must be one of billing, technical, other"},
+		{Path: "$.id", Message: "must be an integer"},
+		{Path: "$.items[0].name", Message: "must be a string"},
+		{Path: "$.refund", Message: "must be a number"},
+	}, errs)
+
+	errs = schema.Validate([]byte(`[`))
+	require.Len(t, errs, 1)
+	require.Equal(t, "$", errs[0].Path)
+}
@@ -2,6 +2,8 @@ package assemblyai
 
 import (
 	"context"
+	"encoding/json"
+	"unicode/utf8"
 )
 
 const (
@@ -55,12 +57,12 @@ type LeMURService struct {
 func (s *LeMURService) Question(ctx context.Context, params LeMURQuestionAnswerParams) (LeMURQuestionAnswerResponse, error) {
 	var response LeMURQuestionAnswerResponse
 
-	req, err := s.client.newJSONRequest(ctx, "POST", "/lemur/v3/generate/question-answer", params)
+	questions, err := json.Marshal(params.Questions)
 	if err != nil {
 		return LeMURQuestionAnswerResponse{}, err
 	}
 
-	if err := s.client.do(req, &response); err != nil {
+	if err := s.generate(ctx, "/lemur/v3/generate/question-answer", params, params.LeMURBaseParams, string(questions), &response, &response.LeMURBaseResponse); err != nil {
 		return LeMURQuestionAnswerResponse{}, err
 	}
 
@@ -71,14 +73,9 @@ func (s *LeMURService) Question(ctx context.Context, params LeMURQuestionAnswerP
 //
 // https://www.assemblyai.com/docs/Models/lemur#action-items
 func (s *LeMURService) Summarize(ctx context.Context, params LeMURSummaryParams) (LeMURSummaryResponse, error) {
-	req, err := s.client.newJSONRequest(ctx, "POST", "/lemur/v3/generate/summary", params)
-	if err != nil {
-		return LeMURSummaryResponse{}, err
-	}
-
 	var response LeMURSummaryResponse
 
-	if err := s.client.do(req, &response); err != nil {
+	if err := s.generate(ctx, "/lemur/v3/generate/summary", params, params.LeMURBaseParams, ToString(params.AnswerFormat), &response, &response.LeMURBaseResponse); err != nil {
 		return LeMURSummaryResponse{}, err
 	}
 
@@ -89,14 +86,9 @@ func (s *LeMURService) Summarize(ctx context.Context, params LeMURSummaryParams)
 //
 // https://www.assemblyai.com/docs/Models/lemur#action-items
 func (s *LeMURService) ActionItems(ctx context.Context, params LeMURActionItemsParams) (LeMURActionItemsResponse, error) {
-	req, err := s.client.newJSONRequest(ctx, "POST", "/lemur/v3/generate/action-items", params)
-	if err != nil {
-		return LeMURActionItemsResponse{}, err
-	}
-
 	var response LeMURActionItemsResponse
 
-	if err := s.client.do(req, &response); err != nil {
+	if err := s.generate(ctx, "/lemur/v3/generate/action-items", params, params.LeMURBaseParams, ToString(params.AnswerFormat), &response, &response.LeMURBaseResponse); err != nil {
 		return LeMURActionItemsResponse{}, err
 	}
 
@@ -107,14 +99,9 @@ func (s *LeMURService) ActionItems(ctx context.Context, params LeMURActionItemsP
 //
 // https://www.assemblyai.com/docs/Models/lemur#task
 func (s *LeMURService) Task(ctx context.Context, params LeMURTaskParams) (LeMURTaskResponse, error) {
-	req, err := s.client.newJSONRequest(ctx, "POST", "/lemur/v3/generate/task", params)
-	if err != nil {
-		return LeMURTaskResponse{}, err
-	}
-
 	var response LeMURTaskResponse
 
-	if err := s.client.do(req, &response); err != nil {
+	if err := s.generate(ctx, "/lemur/v3/generate/task", params, params.LeMURBaseParams, ToString(params.Prompt), &response, &response.LeMURBaseResponse); err != nil {
 		return LeMURTaskResponse{}, err
 	}
 
@@ -149,3 +136,50 @@ func (s *LeMURService) GetResponseData(ctx context.Context, requestID string, re
 
 	return nil
 }
+
+// generate sends a request to a LeMUR endpoint, and tracks its usage if the
+// client has a usage tracker.
+func (s *LeMURService) generate(ctx context.Context, path string, body interface{}, params LeMURBaseParams, text string, response interface{}, base *LeMURBaseResponse) error {
+	tracker := s.client.usageTracker
+	label := lemurUsageLabel(ctx)
+
+	// Without a final model, LeMUR uses its default one.
+	model := params.FinalModel
+	if model == "" {
+		model = LeMURModelDefault
+	}
+
+	if tracker == nil {
+		return s.send(ctx, path, body, response)
+	}
+
+	reservation, err := tracker.reserve(label, model, estimateLeMURUsage(params, text), len(params.TranscriptIDs))
+	if err != nil {
+		return err
+	}
+
+	if err := s.send(ctx, path, body, response); err != nil {
+		tracker.release(reservation)
+		return err
+	}
+
+	tracker.settle(reservation, model, base.Usage)
+
+	return nil
+}
+
+func (s *LeMURService) send(ctx context.Context, path string, body interface{}, response interface{}) error {
+	req, err := s.client.newJSONRequest(ctx, "POST", path, body)
+	if err != nil {
+		return err
+	}
+
+	return s.client.do(req, response)
+}
+
+// EstimateLeMURTokens estimates the number of tokens in a text, at about four
+// characters per token. It's only a rough estimate, for budgeting the size of
+// requests before sending them.
+func EstimateLeMURTokens(text string) int64 {
+	return int64(utf8.RuneCountInString(text)+3) / 4
+}
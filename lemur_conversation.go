@@ -0,0 +1,163 @@
+package assemblyai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+// DefaultLeMURHistoryTokens is the estimated number of tokens of previous
+// turns that a [LeMURConversation] includes in each prompt, unless it's
+// configured otherwise.
+var DefaultLeMURHistoryTokens int64 = 4000
+
+// LeMURTurn is a prompt and the response of LeMUR in a [LeMURConversation].
+type LeMURTurn struct {
+	Prompt   string `json:"prompt"`
+	Response string `json:"response"`
+
+	// The ID of the LeMUR request
+	RequestID string `json:"request_id,omitempty"`
+
+	// The usage numbers for the LeMUR request
+	Usage LeMURUsage `json:"usage,omitempty"`
+}
+
+// LeMURConversation asks LeMUR a series of follow-up prompts about the same
+// input. Each prompt includes the previous turns, so that LeMUR can refer
+// back to them:
+//
+//	conv := client.LeMUR.Conversation(assemblyai.LeMURBaseParams{
+//		TranscriptIDs: []string{transcriptID},
+//	})
+//
+//	turn, err := conv.Ask(ctx, "Who attended the meeting?")
+//
+//	turn, err = conv.Ask(ctx, "Which of them agreed to follow up?")
+//
+// The most recent turns that fit within [LeMURConversation.MaxHistoryTokens]
+// are included, and older ones are left out.
+//
+// A conversation can be encoded to JSON, and resumed with
+// [LeMURService.ResumeConversation]. It's not safe for concurrent use.
+type LeMURConversation struct {
+	// Input, model and other parameters of every request.
+	Params LeMURBaseParams `json:"params"`
+
+	// Estimated number of tokens of previous turns to include in each prompt.
+	// Defaults to [DefaultLeMURHistoryTokens].
+	MaxHistoryTokens int64 `json:"max_history_tokens,omitempty"`
+
+	// Turns so far, oldest first.
+	Turns []LeMURTurn `json:"turns,omitempty"`
+
+	lemur LeMURer
+}
+
+// Conversation starts a conversation with LeMUR about the input of params.
+func (s *LeMURService) Conversation(params LeMURBaseParams) *LeMURConversation {
+	return NewLeMURConversation(s, params)
+}
+
+// ResumeConversation resumes a conversation that was encoded to JSON.
+func (s *LeMURService) ResumeConversation(data []byte) (*LeMURConversation, error) {
+	return ResumeLeMURConversation(s, data)
+}
+
+// NewLeMURConversation starts a conversation with LeMUR using the given
+// service, for example a [MockLeMURer] in tests.
+func NewLeMURConversation(lemur LeMURer, params LeMURBaseParams) *LeMURConversation {
+	return &LeMURConversation{Params: params, lemur: lemur}
+}
+
+// ResumeLeMURConversation resumes a conversation that was encoded to JSON
+// using the given service.
+func ResumeLeMURConversation(lemur LeMURer, data []byte) (*LeMURConversation, error) {
+	conv := &LeMURConversation{lemur: lemur}
+
+	if err := json.Unmarshal(data, conv); err != nil {
+		return nil, err
+	}
+
+	return conv, nil
+}
+
+// Ask sends a prompt to LeMUR, along with the previous turns of the
+// conversation, and adds the response to the conversation.
+func (c *LeMURConversation) Ask(ctx context.Context, prompt string) (LeMURTurn, error) {
+	if c.lemur == nil {
+		return LeMURTurn{}, errors.New("conversation has no LeMUR service, use LeMURService.ResumeConversation to resume it")
+	}
+
+	response, err := c.lemur.Task(ctx, LeMURTaskParams{
+		Prompt:          String(c.prompt(prompt)),
+		LeMURBaseParams: c.Params,
+	})
+	if err != nil {
+		return LeMURTurn{}, err
+	}
+
+	turn := LeMURTurn{
+		Prompt:    prompt,
+		Response:  ToString(response.Response),
+		RequestID: ToString(response.RequestID),
+		Usage:     response.Usage,
+	}
+
+	c.Turns = append(c.Turns, turn)
+
+	return turn, nil
+}
+
+// History returns the previous turns that are included in the next prompt,
+// oldest first.
+func (c *LeMURConversation) History() []LeMURTurn {
+	budget := c.MaxHistoryTokens
+	if budget == 0 {
+		budget = DefaultLeMURHistoryTokens
+	}
+
+	first := len(c.Turns)
+
+	for first > 0 {
+		turn := c.Turns[first-1]
+
+		tokens := EstimateLeMURTokens(turn.Prompt) + EstimateLeMURTokens(turn.Response)
+		if tokens > budget {
+			break
+		}
+
+		budget -= tokens
+		first--
+	}
+
+	return c.Turns[first:]
+}
+
+// prompt returns the prompt sent to LeMUR, with the history of the
+// conversation.
+func (c *LeMURConversation) prompt(prompt string) string {
+	history := c.History()
+
+	if len(history) == 0 {
+		return prompt
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString("This is a conversation about the input. Previous prompts and your responses, oldest first:\n\n")
+
+	for _, turn := range history {
+		sb.WriteString("Prompt: ")
+		sb.WriteString(turn.Prompt)
+		sb.WriteString("\nResponse: ")
+		sb.WriteString(turn.Response)
+		sb.WriteString("\n\n")
+	}
+
+	sb.WriteString("Respond to the next prompt, taking the previous ones into account.\n\nPrompt: ")
+	sb.WriteString(prompt)
+
+	return sb.String()
+}
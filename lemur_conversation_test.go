@@ -0,0 +1,75 @@
+package assemblyai
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLeMURConversation(t *testing.T) {
+	t.Parallel()
+
+	var prompts []string
+
+	mock := &MockLeMURer{
+		TaskFunc: func(ctx context.Context, params LeMURTaskParams) (LeMURTaskResponse, error) {
+			require.Equal(t, []string{"transcript_id"}, params.TranscriptIDs)
+
+			prompts = append(prompts, ToString(params.Prompt))
+
+			return LeMURTaskResponse{
+				Response: String("Alice and Bob."),
+				LeMURBaseResponse: LeMURBaseResponse{
+					RequestID: String("request_id"),
+					Usage:     LeMURUsage{InputTokens: Int64(100), OutputTokens: Int64(5)},
+				},
+			}, nil
+		},
+	}
+
+	conv := NewLeMURConversation(mock, LeMURBaseParams{TranscriptIDs: []string{"transcript_id"}})
+
+	ctx := context.Background()
+
+	turn, err := conv.Ask(ctx, "Who attended?")
+	require.NoError(t, err)
+	require.Equal(t, LeMURTurn{
+		Prompt:    "Who attended?",
+		Response:  "Alice and Bob.",
+		RequestID: "request_id",
+		Usage:     LeMURUsage{InputTokens: Int64(100), OutputTokens: Int64(5)},
+	}, turn)
+
+	_, err = conv.Ask(ctx, "Who spoke first?")
+	require.NoError(t, err)
+
+	require.Equal(t, "Who attended?", prompts[0])
+	require.Contains(t, prompts[1], "Prompt: Who attended?\nResponse: Alice and Bob.")
+	require.Contains(t, prompts[1], "Prompt: Who spoke first?")
+
+	// Only the last turn fits.
+	conv.MaxHistoryTokens = 10
+	require.Equal(t, conv.Turns[1:], conv.History())
+
+	data, err := json.Marshal(conv)
+	require.NoError(t, err)
+
+	resumed, err := ResumeLeMURConversation(mock, data)
+	require.NoError(t, err)
+	require.Equal(t, conv.Params, resumed.Params)
+	require.Equal(t, conv.Turns, resumed.Turns)
+
+	_, err = resumed.Ask(ctx, "Who spoke last?")
+	require.NoError(t, err)
+	require.NotContains(t, prompts[2], "Who attended?")
+	require.Contains(t, prompts[2], "Prompt: Who spoke first?")
+	require.Len(t, resumed.Turns, 3)
+
+	var detached LeMURConversation
+	require.NoError(t, json.Unmarshal(data, &detached))
+
+	_, err = detached.Ask(ctx, "Who spoke last?")
+	require.Error(t, err)
+}
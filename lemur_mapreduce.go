@@ -0,0 +1,545 @@
+package assemblyai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// DefaultLeMURChunkTokens is the estimated number of tokens of input in each
+// request of a map-reduce, unless it's configured otherwise.
+var DefaultLeMURChunkTokens int64 = 50000
+
+// DefaultLeMURConcurrency is how many requests a map-reduce sends at once,
+// unless it's configured otherwise.
+var DefaultLeMURConcurrency = 4
+
+// ErrMapReduceNoInput is returned by a map-reduce that has neither input text
+// nor transcripts.
+var ErrMapReduceNoInput = errors.New("map-reduce has no input")
+
+// maxLeMURTranscripts is the most transcripts a single LeMUR request accepts.
+const maxLeMURTranscripts = 100
+
+// MapReduceOptions configures [LeMURMapReduceTask] and
+// [LeMURMapReduceSummary].
+type MapReduceOptions struct {
+	// Estimated number of tokens of input in each request, as estimated by
+	// [EstimateLeMURTokens]. Defaults to [DefaultLeMURChunkTokens].
+	ChunkTokens int64
+
+	// How many requests to send at once. Defaults to
+	// [DefaultLeMURConcurrency].
+	Concurrency int
+
+	// Prompt used to combine the partial responses. Defaults to a prompt
+	// derived from the task or summary.
+	ReducePrompt string
+
+	// If set, transcripts are grouped by count, up to this many in each
+	// request, instead of by their estimated size. Their text isn't fetched,
+	// and they're always sent as transcripts, so it's up to the caller to
+	// keep each group small enough for a request.
+	TranscriptsPerRequest int
+
+	// Service used to fetch the text of transcripts to group them by size.
+	// The methods of [LeMURService] default to the transcript service of
+	// their client. [LeMURMapReduceTask] and [LeMURMapReduceSummary] return
+	// an error if it's needed but not set.
+	Transcripts Transcriber
+}
+
+// LeMURMapReduceResponse is the result of a map-reduce.
+type LeMURMapReduceResponse struct {
+	// The combined response.
+	Response string
+
+	// The responses to each chunk of the input, in order.
+	Partials []string
+
+	// The IDs of every LeMUR request, in the order they were made.
+	RequestIDs []string
+
+	// The usage numbers of every LeMUR request combined.
+	Usage LeMURUsage
+}
+
+// MapReduceTask runs a task on input that may be too large for a single
+// request. The input text is split into chunks, and transcripts are grouped,
+// so that each request stays under [MapReduceOptions.ChunkTokens]. The task
+// runs on every chunk concurrently, and then another task combines the
+// partial responses into one.
+//
+// To group transcripts, their text is fetched to estimate their size, with one
+// request per transcript. Transcripts that are too large on their own are
+// split into chunks and sent as input text, so LeMUR doesn't get their
+// speakers, timestamps or other context for those chunks. Set
+// [MapReduceOptions.TranscriptsPerRequest] to group transcripts by count
+// instead, without fetching or splitting them.
+//
+// If a request fails, the error is returned along with the IDs and usage of
+// the requests that succeeded, so that they can still be accounted for.
+func (s *LeMURService) MapReduceTask(ctx context.Context, params LeMURTaskParams, opts *MapReduceOptions) (LeMURMapReduceResponse, error) {
+	return LeMURMapReduceTask(ctx, s, params, s.mapReduceOptions(opts))
+}
+
+// LeMURMapReduceTask is like [LeMURService.MapReduceTask], but sends the
+// requests using the given service, for example a [MockLeMURer] in tests.
+func LeMURMapReduceTask(ctx context.Context, lemur LeMURer, params LeMURTaskParams, opts *MapReduceOptions) (LeMURMapReduceResponse, error) {
+	m := newMapReduce(lemur, opts)
+
+	reducePrompt := m.opts.ReducePrompt
+	if reducePrompt == "" {
+		reducePrompt = "Each part of the input is a response to the prompt below, based on a different part of a larger input. " +
+			"Combine them into a single response to the prompt, as if it had been based on the whole input.\n\nPrompt: " + ToString(params.Prompt)
+	}
+
+	return m.run(ctx, params.LeMURBaseParams, reducePrompt, func(ctx context.Context, base LeMURBaseParams) (string, LeMURBaseResponse, error) {
+		p := params
+		p.LeMURBaseParams = base
+
+		response, err := lemur.Task(ctx, p)
+
+		return ToString(response.Response), response.LeMURBaseResponse, err
+	})
+}
+
+// MapReduceSummary summarizes input that may be too large for a single
+// request, like [LeMURService.MapReduceTask] does for tasks.
+func (s *LeMURService) MapReduceSummary(ctx context.Context, params LeMURSummaryParams, opts *MapReduceOptions) (LeMURMapReduceResponse, error) {
+	return LeMURMapReduceSummary(ctx, s, params, s.mapReduceOptions(opts))
+}
+
+// LeMURMapReduceSummary is like [LeMURService.MapReduceSummary], but sends
+// the requests using the given service.
+func LeMURMapReduceSummary(ctx context.Context, lemur LeMURer, params LeMURSummaryParams, opts *MapReduceOptions) (LeMURMapReduceResponse, error) {
+	m := newMapReduce(lemur, opts)
+
+	reducePrompt := m.opts.ReducePrompt
+	if reducePrompt == "" {
+		reducePrompt = "Each part of the input is a summary of a different part of a larger input. " +
+			"Combine them into a single summary of the whole input."
+
+		if params.AnswerFormat != nil {
+			reducePrompt += "\n\nFormat the summary as: " + *params.AnswerFormat
+		}
+	}
+
+	return m.run(ctx, params.LeMURBaseParams, reducePrompt, func(ctx context.Context, base LeMURBaseParams) (string, LeMURBaseResponse, error) {
+		p := params
+		p.LeMURBaseParams = base
+
+		response, err := lemur.Summarize(ctx, p)
+
+		return ToString(response.Response), response.LeMURBaseResponse, err
+	})
+}
+
+// mapReduceOptions returns a copy of opts that fetches transcripts with the
+// client of s, unless opts sets another service.
+func (s *LeMURService) mapReduceOptions(opts *MapReduceOptions) *MapReduceOptions {
+	var options MapReduceOptions
+	if opts != nil {
+		options = *opts
+	}
+
+	if options.Transcripts == nil {
+		options.Transcripts = s.client.Transcripts
+	}
+
+	return &options
+}
+
+type mapFunc func(ctx context.Context, params LeMURBaseParams) (string, LeMURBaseResponse, error)
+
+type mapReduce struct {
+	lemur LeMURer
+	opts  MapReduceOptions
+
+	mu       sync.Mutex
+	response LeMURMapReduceResponse
+}
+
+func newMapReduce(lemur LeMURer, opts *MapReduceOptions) *mapReduce {
+	m := &mapReduce{lemur: lemur}
+
+	if opts != nil {
+		m.opts = *opts
+	}
+
+	if m.opts.ChunkTokens <= 0 {
+		m.opts.ChunkTokens = DefaultLeMURChunkTokens
+	}
+
+	if m.opts.Concurrency <= 0 {
+		m.opts.Concurrency = DefaultLeMURConcurrency
+	}
+
+	return m
+}
+
+func (m *mapReduce) run(ctx context.Context, params LeMURBaseParams, reducePrompt string, fn mapFunc) (LeMURMapReduceResponse, error) {
+	if ToString(params.InputText) == "" && len(params.TranscriptIDs) == 0 {
+		return LeMURMapReduceResponse{}, ErrMapReduceNoInput
+	}
+
+	chunks, err := m.chunks(ctx, params)
+	if err != nil {
+		return LeMURMapReduceResponse{}, err
+	}
+
+	if len(chunks) == 0 {
+		return LeMURMapReduceResponse{}, ErrMapReduceNoInput
+	}
+
+	partials, err := m.apply(ctx, chunks, fn)
+	if err != nil {
+		return m.response, err
+	}
+
+	m.response.Partials = partials
+
+	// Only reduce the parameters that apply to the output.
+	reduceParams := LeMURBaseParams{
+		Context:       params.Context,
+		FinalModel:    params.FinalModel,
+		MaxOutputSize: params.MaxOutputSize,
+		Temperature:   params.Temperature,
+	}
+
+	for len(partials) > 1 {
+		groups := m.groupPartials(partials)
+
+		chunks := make([]LeMURBaseParams, len(groups))
+		for i, group := range groups {
+			chunks[i] = reduceParams
+			chunks[i].InputText = String(group)
+		}
+
+		partials, err = m.apply(ctx, chunks, func(ctx context.Context, base LeMURBaseParams) (string, LeMURBaseResponse, error) {
+			response, err := m.lemur.Task(ctx, LeMURTaskParams{Prompt: String(reducePrompt), LeMURBaseParams: base})
+
+			return ToString(response.Response), response.LeMURBaseResponse, err
+		})
+		if err != nil {
+			return m.response, err
+		}
+	}
+
+	m.response.Response = partials[0]
+
+	return m.response, nil
+}
+
+// chunks splits the input of params into chunks that fit in a request.
+func (m *mapReduce) chunks(ctx context.Context, params LeMURBaseParams) ([]LeMURBaseParams, error) {
+	withText := func(text string) LeMURBaseParams {
+		p := params
+		p.TranscriptIDs = nil
+		p.InputText = String(text)
+		return p
+	}
+
+	var chunks []LeMURBaseParams
+
+	if params.InputText != nil {
+		for _, text := range chunkText(*params.InputText, m.opts.ChunkTokens) {
+			chunks = append(chunks, withText(text))
+		}
+		return chunks, nil
+	}
+
+	if n := m.opts.TranscriptsPerRequest; n > 0 {
+		if n > maxLeMURTranscripts {
+			n = maxLeMURTranscripts
+		}
+
+		for i := 0; i < len(params.TranscriptIDs); i += n {
+			end := i + n
+			if end > len(params.TranscriptIDs) {
+				end = len(params.TranscriptIDs)
+			}
+
+			p := params
+			p.TranscriptIDs = params.TranscriptIDs[i:end]
+			chunks = append(chunks, p)
+		}
+
+		return chunks, nil
+	}
+
+	if m.opts.Transcripts == nil {
+		return nil, errors.New("grouping transcripts by size requires MapReduceOptions.Transcripts or MapReduceOptions.TranscriptsPerRequest")
+	}
+
+	texts := make([]string, len(params.TranscriptIDs))
+
+	err := m.forEach(ctx, len(texts), func(ctx context.Context, i int) error {
+		transcript, err := m.opts.Transcripts.Get(ctx, params.TranscriptIDs[i])
+		if err != nil {
+			return err
+		}
+
+		texts[i] = ToString(transcript.Text)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		group  []string
+		tokens int64
+	)
+
+	flush := func() {
+		if len(group) > 0 {
+			p := params
+			p.TranscriptIDs = group
+			chunks = append(chunks, p)
+		}
+
+		group, tokens = nil, 0
+	}
+
+	for i, id := range params.TranscriptIDs {
+		n := EstimateLeMURTokens(texts[i])
+
+		if n > m.opts.ChunkTokens {
+			flush()
+
+			for _, text := range chunkText(texts[i], m.opts.ChunkTokens) {
+				chunks = append(chunks, withText(text))
+			}
+
+			continue
+		}
+
+		if tokens+n > m.opts.ChunkTokens || len(group) == maxLeMURTranscripts {
+			flush()
+		}
+
+		group = append(group, id)
+		tokens += n
+	}
+
+	flush()
+
+	return chunks, nil
+}
+
+// groupPartials joins partial responses into inputs for the reduce step that
+// stay under the chunk size where possible. Every input has at least two
+// partial responses, so that each step reduces their number.
+func (m *mapReduce) groupPartials(partials []string) []string {
+	var (
+		groups [][]string
+		group  []string
+		tokens int64
+	)
+
+	for _, partial := range partials {
+		n := EstimateLeMURTokens(partial)
+
+		if len(group) >= 2 && tokens+n > m.opts.ChunkTokens {
+			groups = append(groups, group)
+			group, tokens = nil, 0
+		}
+
+		group = append(group, partial)
+		tokens += n
+	}
+
+	if len(group) == 1 && len(groups) > 0 {
+		groups[len(groups)-1] = append(groups[len(groups)-1], group[0])
+	} else {
+		groups = append(groups, group)
+	}
+
+	inputs := make([]string, len(groups))
+
+	for i, group := range groups {
+		var sb strings.Builder
+
+		for j, partial := range group {
+			if j > 0 {
+				sb.WriteString("\n\n")
+			}
+			fmt.Fprintf(&sb, "Part %d:\n%s", j+1, partial)
+		}
+
+		inputs[i] = sb.String()
+	}
+
+	return inputs
+}
+
+// apply runs fn on every chunk concurrently, and returns the responses in
+// order. The requests that succeeded are recorded even if others fail.
+func (m *mapReduce) apply(ctx context.Context, chunks []LeMURBaseParams, fn mapFunc) ([]string, error) {
+	texts := make([]string, len(chunks))
+	responses := make([]LeMURBaseResponse, len(chunks))
+
+	err := m.forEach(ctx, len(chunks), func(ctx context.Context, i int) error {
+		text, response, err := fn(ctx, chunks[i])
+		if err != nil {
+			return err
+		}
+
+		texts[i], responses[i] = text, response
+
+		return nil
+	})
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, response := range responses {
+		if response.RequestID != nil {
+			m.response.RequestIDs = append(m.response.RequestIDs, *response.RequestID)
+		}
+
+		m.response.Usage = addLeMURUsage(m.response.Usage, response.Usage)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return texts, nil
+}
+
+// forEach calls fn for each index up to n, with up to the configured number
+// of calls at once. It returns the first error, and cancels the remaining
+// calls.
+func (m *mapReduce) forEach(ctx context.Context, n int, fn func(ctx context.Context, i int) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+	)
+
+	sem := make(chan struct{}, m.opts.Concurrency)
+
+	for i := 0; i < n; i++ {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			if err := fn(ctx, i); err != nil {
+				once.Do(func() {
+					firstErr = err
+					cancel()
+				})
+			}
+		}(i)
+	}
+
+	wg.Wait()
+
+	if firstErr != nil {
+		return firstErr
+	}
+
+	return ctx.Err()
+}
+
+// chunkText splits text into chunks of up to maxTokens estimated tokens,
+// preferably between paragraphs, then between lines, then between words.
+func chunkText(text string, maxTokens int64) []string {
+	var chunks []string
+
+	// Rune counts of pieces are added up as they're appended, since
+	// estimating every joined chunk would take quadratic time.
+	estimate := func(runes int) int64 {
+		return int64(runes+3) / 4
+	}
+
+	var split func(text string, seps []string)
+
+	split = func(text string, seps []string) {
+		if EstimateLeMURTokens(text) <= maxTokens || len(seps) == 0 {
+			chunks = append(chunks, text)
+			return
+		}
+
+		sep := seps[0]
+		sepRunes := utf8.RuneCountInString(sep)
+
+		var (
+			chunk []string
+			runes int
+		)
+
+		flush := func() {
+			if len(chunk) > 0 {
+				chunks = append(chunks, strings.Join(chunk, sep))
+				chunk, runes = nil, 0
+			}
+		}
+
+		for _, piece := range strings.Split(text, sep) {
+			if piece == "" {
+				continue
+			}
+
+			n := utf8.RuneCountInString(piece)
+
+			if estimate(n) > maxTokens {
+				flush()
+				split(piece, seps[1:])
+				continue
+			}
+
+			if len(chunk) > 0 && estimate(runes+sepRunes+n) > maxTokens {
+				flush()
+			}
+
+			if len(chunk) > 0 {
+				runes += sepRunes
+			}
+
+			chunk = append(chunk, piece)
+			runes += n
+		}
+
+		flush()
+	}
+
+	split(text, []string{"\n\n", "\n", " "})
+
+	return chunks
+}
+
+// addLeMURUsage returns the sum of two usage numbers.
+func addLeMURUsage(a, b LeMURUsage) LeMURUsage {
+	add := func(x, y *int64) *int64 {
+		if x == nil && y == nil {
+			return nil
+		}
+		return Int64(ToInt64(x) + ToInt64(y))
+	}
+
+	return LeMURUsage{
+		InputTokens:  add(a.InputTokens, b.InputTokens),
+		OutputTokens: add(a.OutputTokens, b.OutputTokens),
+	}
+}
@@ -0,0 +1,266 @@
+package assemblyai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLeMUR_MapReduceTask(t *testing.T) {
+	t.Parallel()
+
+	client, handler, teardown := setup()
+	defer teardown()
+
+	var (
+		mu      sync.Mutex
+		inputs  []string
+		reduces []string
+	)
+
+	handler.HandleFunc("/lemur/v3/generate/task", func(w http.ResponseWriter, r *http.Request) {
+		var body LeMURTaskParams
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+
+		input := ToString(body.InputText)
+
+		response := "Answer about " + strings.Fields(input)[0] + "."
+
+		mu.Lock()
+		if strings.HasPrefix(ToString(body.Prompt), "Each part of the input") {
+			require.Contains(t, ToString(body.Prompt), "Prompt: Who is mentioned?")
+			reduces = append(reduces, input)
+			response = "Combined answer."
+		} else {
+			require.Equal(t, "Who is mentioned?", ToString(body.Prompt))
+			inputs = append(inputs, input)
+		}
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		require.NoError(t, json.NewEncoder(w).Encode(LeMURTaskResponse{
+			Response: String(response),
+			LeMURBaseResponse: LeMURBaseResponse{
+				RequestID: String("request_id"),
+				Usage:     LeMURUsage{InputTokens: Int64(10), OutputTokens: Int64(2)},
+			},
+		}))
+	})
+
+	text := strings.Join([]string{
+		"Alice said hello to everyone.",
+		"Bob talked about the budget.",
+		"Carol asked about the deadline.",
+	}, "\n\n")
+
+	response, err := client.LeMUR.MapReduceTask(context.Background(), LeMURTaskParams{
+		Prompt:          String("Who is mentioned?"),
+		LeMURBaseParams: LeMURBaseParams{InputText: String(text)},
+	}, &MapReduceOptions{ChunkTokens: 10, Concurrency: 2})
+	require.NoError(t, err)
+
+	require.Equal(t, "Combined answer.", response.Response)
+	require.Equal(t, []string{"Answer about Alice.", "Answer about Bob.", "Answer about Carol."}, response.Partials)
+	require.Len(t, response.RequestIDs, 4)
+	require.Equal(t, LeMURUsage{InputTokens: Int64(40), OutputTokens: Int64(8)}, response.Usage)
+
+	require.ElementsMatch(t, strings.Split(text, "\n\n"), inputs)
+	require.Equal(t, []string{"Part 1:\nAnswer about Alice.\n\nPart 2:\nAnswer about Bob.\n\nPart 3:\nAnswer about Carol."}, reduces)
+}
+
+func TestLeMUR_MapReduceSummary(t *testing.T) {
+	t.Parallel()
+
+	client, handler, teardown := setup()
+	defer teardown()
+
+	texts := map[string]string{
+		"short_1": "Short.",
+		"short_2": "Also short.",
+		"long":    strings.Repeat("word ", 30),
+	}
+
+	handler.HandleFunc("/v2/transcript/", func(w http.ResponseWriter, r *http.Request) {
+		id := strings.TrimPrefix(r.URL.Path, "/v2/transcript/")
+
+		w.Header().Set("Content-Type", "application/json")
+		require.NoError(t, json.NewEncoder(w).Encode(Transcript{ID: String(id), Text: String(texts[id])}))
+	})
+
+	var (
+		mu     sync.Mutex
+		groups [][]string
+		chunks int
+	)
+
+	handler.HandleFunc("/lemur/v3/generate/summary", func(w http.ResponseWriter, r *http.Request) {
+		var body LeMURSummaryParams
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+
+		require.Equal(t, "TLDR", ToString(body.AnswerFormat))
+
+		mu.Lock()
+		if body.InputText != nil {
+			chunks++
+		} else {
+			groups = append(groups, body.TranscriptIDs)
+		}
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		require.NoError(t, json.NewEncoder(w).Encode(LeMURSummaryResponse{Response: String("Partial summary.")}))
+	})
+
+	handler.HandleFunc("/lemur/v3/generate/task", func(w http.ResponseWriter, r *http.Request) {
+		var body LeMURTaskParams
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+
+		require.Contains(t, ToString(body.Prompt), "Format the summary as: TLDR")
+
+		w.Header().Set("Content-Type", "application/json")
+		require.NoError(t, json.NewEncoder(w).Encode(LeMURTaskResponse{Response: String("Summary.")}))
+	})
+
+	response, err := client.LeMUR.MapReduceSummary(context.Background(), LeMURSummaryParams{
+		LeMURBaseParams: LeMURBaseParams{TranscriptIDs: []string{"short_1", "short_2", "long"}},
+		AnswerFormat:    String("TLDR"),
+	}, &MapReduceOptions{ChunkTokens: 20})
+	require.NoError(t, err)
+
+	require.Equal(t, "Summary.", response.Response)
+	require.Equal(t, [][]string{{"short_1", "short_2"}}, groups)
+	require.Equal(t, 2, chunks)
+	require.Len(t, response.Partials, 3)
+}
+
+func TestChunkText(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, []string{"short text"}, chunkText("short text", 10))
+
+	text := "one two three four\nfive six\n\nseven"
+	require.Equal(t, []string{"one two three", "four", "five six", "seven"}, chunkText(text, 4))
+
+	for _, chunk := range chunkText(strings.Repeat("word ", 100), 5) {
+		require.LessOrEqual(t, EstimateLeMURTokens(chunk), int64(5))
+	}
+
+	// Long text without line breaks is split in linear time.
+	large := strings.Repeat("lorem ipsum ", 100000)
+
+	chunks := chunkText(large, 1000)
+	require.Equal(t, large, strings.Join(chunks, " ")+" ")
+
+	for _, chunk := range chunks {
+		require.LessOrEqual(t, EstimateLeMURTokens(chunk), int64(1000))
+	}
+}
+
+func TestMapReduce_TranscriptsPerRequest(t *testing.T) {
+	t.Parallel()
+
+	client, _, teardown := setup()
+	defer teardown()
+
+	// The transcripts aren't fetched, so the handler doesn't serve them.
+	m := newMapReduce(client.LeMUR, &MapReduceOptions{TranscriptsPerRequest: 2})
+
+	chunks, err := m.chunks(context.Background(), LeMURBaseParams{
+		TranscriptIDs: []string{"a", "b", "c"},
+		FinalModel:    LeMURModelDefault,
+	})
+	require.NoError(t, err)
+
+	require.Len(t, chunks, 2)
+	require.Equal(t, []string{"a", "b"}, chunks[0].TranscriptIDs)
+	require.Equal(t, []string{"c"}, chunks[1].TranscriptIDs)
+	require.Equal(t, LeMURModelDefault, chunks[1].FinalModel)
+	require.Nil(t, chunks[1].InputText)
+}
+
+func TestLeMURMapReduceTask_Mock(t *testing.T) {
+	t.Parallel()
+
+	mock := &MockLeMURer{
+		TaskFunc: func(ctx context.Context, params LeMURTaskParams) (LeMURTaskResponse, error) {
+			return LeMURTaskResponse{Response: String("ok")}, nil
+		},
+	}
+
+	ctx := context.Background()
+
+	response, err := LeMURMapReduceTask(ctx, mock, LeMURTaskParams{
+		Prompt:          String("Summarize."),
+		LeMURBaseParams: LeMURBaseParams{InputText: String("one two three four five six")},
+	}, &MapReduceOptions{ChunkTokens: 3})
+	require.NoError(t, err)
+	require.Equal(t, "ok", response.Response)
+	require.Greater(t, len(mock.CallsTo("Task")), 2)
+
+	// Grouping transcripts by size needs a service to fetch them.
+	_, err = LeMURMapReduceTask(ctx, mock, LeMURTaskParams{
+		Prompt:          String("Summarize."),
+		LeMURBaseParams: LeMURBaseParams{TranscriptIDs: []string{"a"}},
+	}, nil)
+	require.Error(t, err)
+
+	transcripts := &MockTranscriber{
+		GetFunc: func(ctx context.Context, transcriptID string) (Transcript, error) {
+			return Transcript{ID: String(transcriptID), Text: String("Hello.")}, nil
+		},
+	}
+
+	_, err = LeMURMapReduceTask(ctx, mock, LeMURTaskParams{
+		Prompt:          String("Summarize."),
+		LeMURBaseParams: LeMURBaseParams{TranscriptIDs: []string{"a"}},
+	}, &MapReduceOptions{Transcripts: transcripts})
+	require.NoError(t, err)
+	require.Len(t, transcripts.CallsTo("Get"), 1)
+}
+
+func TestLeMURMapReduceTask_Errors(t *testing.T) {
+	t.Parallel()
+
+	errFailed := errors.New("failed")
+
+	mock := &MockLeMURer{
+		TaskFunc: func(ctx context.Context, params LeMURTaskParams) (LeMURTaskResponse, error) {
+			input := ToString(params.InputText)
+			if strings.HasPrefix(input, "Bob") {
+				return LeMURTaskResponse{}, errFailed
+			}
+
+			return LeMURTaskResponse{
+				Response: String("ok"),
+				LeMURBaseResponse: LeMURBaseResponse{
+					RequestID: String(strings.Fields(input)[0]),
+					Usage:     LeMURUsage{InputTokens: Int64(10), OutputTokens: Int64(2)},
+				},
+			}, nil
+		},
+	}
+
+	ctx := context.Background()
+
+	for _, base := range []LeMURBaseParams{{}, {InputText: String("")}, {TranscriptIDs: []string{}}} {
+		_, err := LeMURMapReduceTask(ctx, mock, LeMURTaskParams{Prompt: String("Who is mentioned?"), LeMURBaseParams: base}, nil)
+		require.ErrorIs(t, err, ErrMapReduceNoInput)
+	}
+
+	// The requests made before the failure are still returned.
+	response, err := LeMURMapReduceTask(ctx, mock, LeMURTaskParams{
+		Prompt: String("Who is mentioned?"),
+		LeMURBaseParams: LeMURBaseParams{
+			InputText: String("Alice said hello.\n\nBob talked about the budget.\n\nCarol asked about the deadline."),
+		},
+	}, &MapReduceOptions{ChunkTokens: 10, Concurrency: 1})
+	require.ErrorIs(t, err, errFailed)
+	require.Equal(t, []string{"Alice"}, response.RequestIDs)
+	require.Equal(t, LeMURUsage{InputTokens: Int64(10), OutputTokens: Int64(2)}, response.Usage)
+}
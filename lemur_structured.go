@@ -0,0 +1,255 @@
+package assemblyai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// DefaultLeMURMaxRetries is how many times [LeMURTaskInto] prompts
+// LeMUR again when its response doesn't match the schema, unless it's
+// configured otherwise.
+var DefaultLeMURMaxRetries = 2
+
+// ErrInvalidLeMUROutput is matched by [errors.Is] for every
+// [LeMUROutputError].
+var ErrInvalidLeMUROutput = errors.New("invalid LeMUR output")
+
+// LeMUROutputError is returned by [LeMURService.TaskInto] when none of the
+// responses of LeMUR match the schema.
+type LeMUROutputError struct {
+	// Problems with the last response.
+	Errors []SchemaError
+
+	// The last response.
+	Response LeMURTaskResponse
+}
+
+// Error returns all problems separated by semicolons.
+func (e *LeMUROutputError) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		msgs[i] = err.Error()
+	}
+	return fmt.Sprintf("%s: %s", ErrInvalidLeMUROutput, strings.Join(msgs, "; "))
+}
+
+// Is reports whether target is [ErrInvalidLeMUROutput].
+func (e *LeMUROutputError) Is(target error) bool {
+	return target == ErrInvalidLeMUROutput
+}
+
+// TaskIntoOptions configures [LeMURService.TaskInto].
+type TaskIntoOptions struct {
+	// Schema of the output. Defaults to the schema derived from the output
+	// with [JSONSchemaFor].
+	Schema *JSONSchema
+
+	// How many times to prompt LeMUR again when its response doesn't match
+	// the schema. Defaults to [DefaultLeMURMaxRetries] if zero. Set it to a
+	// negative value to never retry.
+	MaxRetries int
+}
+
+// TaskInto submits a prompt to LeMUR and decodes the JSON in its response
+// into out, which must be a pointer:
+//
+//	var ticket struct {
+//		Customer string  `json:"customer"`
+//		Category string  `json:"category" enum:"billing,technical,other"`
+//		Refund   float64 `json:"refund,omitempty"`
+//	}
+//
+//	_, err := client.LeMUR.TaskInto(ctx, assemblyai.LeMURTaskParams{
+//		Prompt:          assemblyai.String("Extract the support ticket from the call."),
+//		LeMURBaseParams: assemblyai.LeMURBaseParams{TranscriptIDs: []string{transcriptID}},
+//	}, &ticket, nil)
+//
+// The schema of out is appended to the prompt. If the response doesn't
+// contain JSON that matches it, LeMUR is prompted again with the problems
+// found, and a [*LeMUROutputError] is returned once the retries run out.
+//
+// It returns the last response of LeMUR.
+func (s *LeMURService) TaskInto(ctx context.Context, params LeMURTaskParams, out interface{}, opts *TaskIntoOptions) (LeMURTaskResponse, error) {
+	return LeMURTaskInto(ctx, s, params, out, opts)
+}
+
+// LeMURTaskInto is like [LeMURService.TaskInto], but prompts LeMUR using the
+// given service, for example a [MockLeMURer] in tests.
+func LeMURTaskInto(ctx context.Context, lemur LeMURer, params LeMURTaskParams, out interface{}, opts *TaskIntoOptions) (LeMURTaskResponse, error) {
+	if v := reflect.ValueOf(out); v.Kind() != reflect.Ptr || v.IsNil() {
+		return LeMURTaskResponse{}, &json.InvalidUnmarshalError{Type: reflect.TypeOf(out)}
+	}
+
+	var options TaskIntoOptions
+	if opts != nil {
+		options = *opts
+	}
+
+	if options.Schema == nil {
+		schema, err := JSONSchemaFor(out)
+		if err != nil {
+			return LeMURTaskResponse{}, err
+		}
+		options.Schema = schema
+	}
+
+	if options.MaxRetries == 0 {
+		options.MaxRetries = DefaultLeMURMaxRetries
+	}
+
+	schema, err := json.MarshalIndent(options.Schema, "", "  ")
+	if err != nil {
+		return LeMURTaskResponse{}, err
+	}
+
+	prompt := ToString(params.Prompt) + "\n\nRespond only with a JSON value that matches the following JSON schema, without any other text or formatting:\n\n" + string(schema)
+
+	params.Prompt = String(prompt)
+
+	for attempt := 0; ; attempt++ {
+		response, err := lemur.Task(ctx, params)
+		if err != nil {
+			return response, err
+		}
+
+		errs := decodeOutput(ToString(response.Response), options.Schema, out)
+		if len(errs) == 0 {
+			return response, nil
+		}
+
+		if attempt >= options.MaxRetries {
+			return response, &LeMUROutputError{Errors: errs, Response: response}
+		}
+
+		var sb strings.Builder
+
+		sb.WriteString(prompt)
+		sb.WriteString("\n\nYour previous response was:\n\n")
+		sb.WriteString(ToString(response.Response))
+		sb.WriteString("\n\nIt was invalid for the following reasons:\n\n")
+
+		for _, err := range errs {
+			sb.WriteString("- ")
+			sb.WriteString(err.Error())
+			sb.WriteString("\n")
+		}
+
+		sb.WriteString("\nRespond again, fixing these problems.")
+
+		params.Prompt = String(sb.String())
+	}
+}
+
+// decodeOutput extracts the JSON value in a response, validates it and
+// decodes it into out.
+func decodeOutput(response string, schema *JSONSchema, out interface{}) []SchemaError {
+	data, ok := extractJSON(response, schema.Type)
+	if !ok {
+		return []SchemaError{{Path: "$", Message: "response doesn't contain a JSON value"}}
+	}
+
+	if errs := schema.Validate(data); len(errs) > 0 {
+		return errs
+	}
+
+	if err := json.Unmarshal(data, out); err != nil {
+		return []SchemaError{{Path: "$", Message: err.Error()}}
+	}
+
+	return nil
+}
+
+// extractJSON returns the first JSON object or array in text, skipping any
+// surrounding prose or code fences. If typ is "object" or "array", only
+// values of that type are considered.
+//
+// For scalar types, the whole response is used if it's a value of that type.
+// Otherwise a string is taken from the response as is, and a number or
+// boolean from the first word that is one.
+func extractJSON(text, typ string) (json.RawMessage, bool) {
+	switch typ {
+	case "string", "integer", "number", "boolean":
+		return extractScalar(text, typ)
+	}
+
+	starts := "{["
+	switch typ {
+	case "object":
+		starts = "{"
+	case "array":
+		starts = "["
+	}
+
+	for i := 0; i < len(text); i++ {
+		if !strings.ContainsRune(starts, rune(text[i])) {
+			continue
+		}
+
+		var raw json.RawMessage
+
+		if err := json.NewDecoder(strings.NewReader(text[i:])).Decode(&raw); err == nil {
+			return raw, true
+		}
+	}
+
+	return nil, false
+}
+
+func extractScalar(text, typ string) (json.RawMessage, bool) {
+	text = strings.TrimSpace(text)
+
+	if strings.HasPrefix(text, "```") && strings.HasSuffix(text, "```") && len(text) >= 6 {
+		text = strings.TrimSpace(text[3 : len(text)-3])
+		text = strings.TrimSpace(strings.TrimPrefix(text, "json"))
+	}
+
+	if isScalar(text, typ) {
+		return json.RawMessage(text), true
+	}
+
+	if typ == "string" {
+		b, err := json.Marshal(text)
+		return b, err == nil
+	}
+
+	for _, word := range strings.Fields(text) {
+		word = strings.Trim(word, ".,;:!?()\"'`")
+
+		if isScalar(word, typ) {
+			return json.RawMessage(word), true
+		}
+	}
+
+	return nil, false
+}
+
+// isScalar reports whether s is a JSON value of the given scalar type.
+func isScalar(s, typ string) bool {
+	dec := json.NewDecoder(strings.NewReader(s))
+	dec.UseNumber()
+
+	var v interface{}
+
+	if err := dec.Decode(&v); err != nil || dec.More() {
+		return false
+	}
+
+	switch v := v.(type) {
+	case string:
+		return typ == "string"
+	case bool:
+		return typ == "boolean"
+	case json.Number:
+		if typ == "integer" {
+			_, err := v.Int64()
+			return err == nil
+		}
+		return typ == "number"
+	}
+
+	return false
+}
@@ -0,0 +1,138 @@
+package assemblyai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLeMUR_TaskInto(t *testing.T) {
+	t.Parallel()
+
+	client, handler, teardown := setup()
+	defer teardown()
+
+	responses := []string{
+		"The customer is Alice.",
+		"```json\n{\"customer\": \"Alice\", \"category\": \"refund\"}\n```",
+		"Here you go: {\"customer\": \"Alice\", \"category\": \"billing\", \"refund\": 12.5}",
+	}
+
+	var prompts []string
+
+	handler.HandleFunc("/lemur/v3/generate/task", func(w http.ResponseWriter, r *http.Request) {
+		var body LeMURTaskParams
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+
+		require.Equal(t, []string{"transcript_id"}, body.TranscriptIDs)
+
+		prompts = append(prompts, ToString(body.Prompt))
+
+		w.Header().Set("Content-Type", "application/json")
+		require.NoError(t, json.NewEncoder(w).Encode(LeMURTaskResponse{
+			Response:          String(responses[len(prompts)-1]),
+			LeMURBaseResponse: LeMURBaseResponse{RequestID: String(fmt.Sprintf("request_%d", len(prompts)))},
+		}))
+	})
+
+	var ticket struct {
+		Customer string  `json:"customer"`
+		Category string  `json:"category" enum:"billing,technical,other"`
+		Refund   float64 `json:"refund,omitempty"`
+	}
+
+	params := LeMURTaskParams{
+		Prompt:          String("Extract the support ticket."),
+		LeMURBaseParams: LeMURBaseParams{TranscriptIDs: []string{"transcript_id"}},
+	}
+
+	ctx := context.Background()
+
+	response, err := client.LeMUR.TaskInto(ctx, params, &ticket, nil)
+	require.NoError(t, err)
+	require.Equal(t, "request_3", ToString(response.RequestID))
+
+	require.Equal(t, "Alice", ticket.Customer)
+	require.Equal(t, "billing", ticket.Category)
+	require.Equal(t, 12.5, ticket.Refund)
+
+	require.Len(t, prompts, 3)
+	require.Contains(t, prompts[0], "Extract the support ticket.")
+	require.Contains(t, prompts[0], `"enum": [`)
+	require.Contains(t, prompts[1], "- $: response doesn't contain a JSON value")
+	require.Contains(t, prompts[2], "- $.category: must be one of billing, technical, other")
+
+	prompts = nil
+
+	_, err = client.LeMUR.TaskInto(ctx, params, &ticket, &TaskIntoOptions{MaxRetries: -1})
+	require.True(t, errors.Is(err, ErrInvalidLeMUROutput))
+
+	var outputErr *LeMUROutputError
+	require.True(t, errors.As(err, &outputErr))
+	require.Equal(t, "The customer is Alice.", ToString(outputErr.Response.Response))
+	require.Len(t, prompts, 1)
+
+	_, err = client.LeMUR.TaskInto(ctx, params, ticket, nil)
+	require.Error(t, err)
+}
+
+func TestLeMURTaskInto_Mock(t *testing.T) {
+	t.Parallel()
+
+	mock := &MockLeMURer{
+		TaskFunc: func(ctx context.Context, params LeMURTaskParams) (LeMURTaskResponse, error) {
+			return LeMURTaskResponse{Response: String(`{"score": 4}`)}, nil
+		},
+	}
+
+	var rating struct {
+		Score int `json:"score"`
+	}
+
+	_, err := LeMURTaskInto(context.Background(), mock, LeMURTaskParams{Prompt: String("Rate the call.")}, &rating, nil)
+	require.NoError(t, err)
+	require.Equal(t, 4, rating.Score)
+	require.Len(t, mock.CallsTo("Task"), 1)
+}
+
+func TestExtractJSON(t *testing.T) {
+	t.Parallel()
+
+	for _, tt := range []struct {
+		text, typ, want string
+	}{
+		{"Here you go:\n```json\n{\"a\": 1}\n```", "object", `{"a": 1}`},
+		{"[1, 2] and {\"a\": 1}", "object", `{"a": 1}`},
+		{"[1, 2]", "", `[1, 2]`},
+		{`"billing"`, "string", `"billing"`},
+		{"billing", "string", `"billing"`},
+		{"```\nbilling\n```", "string", `"billing"`},
+		{"42", "integer", `42`},
+		{"The answer is 42.", "integer", `42`},
+		{"About 4.5 stars", "number", `4.5`},
+		{"4.5", "integer", ""},
+		{"Yes, it's true.", "boolean", `true`},
+		{"false", "boolean", `false`},
+		{"none", "boolean", ""},
+	} {
+		raw, ok := extractJSON(tt.text, tt.typ)
+		if tt.want == "" {
+			require.False(t, ok, tt.text)
+			continue
+		}
+		require.True(t, ok, tt.text)
+		require.Equal(t, tt.want, string(raw), tt.text)
+	}
+
+	var count int
+
+	schema, err := JSONSchemaFor(&count)
+	require.NoError(t, err)
+	require.Empty(t, decodeOutput("There were 3 calls.", schema, &count))
+	require.Equal(t, 3, count)
+}
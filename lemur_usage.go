@@ -0,0 +1,385 @@
+package assemblyai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// DefaultLeMURUsageWindow is the length of the time windows that a
+// [LeMURUsageTracker] reports usage for, unless it's configured otherwise.
+var DefaultLeMURUsageWindow = time.Hour
+
+// ErrLeMURBudgetExceeded is matched by [errors.Is] for every
+// [LeMURBudgetError].
+var ErrLeMURBudgetExceeded = errors.New("LeMUR budget exceeded")
+
+// LeMURBudget is a kind of budget enforced by a [LeMURUsageTracker].
+type LeMURBudget string
+
+const (
+	// The budget of all requests in a UTC calendar day.
+	LeMURBudgetDaily LeMURBudget = "daily"
+
+	// The budget of the requests with the same label.
+	LeMURBudgetJob LeMURBudget = "job"
+)
+
+// LeMURBudgetError is returned instead of sending a LeMUR request that would
+// exceed a budget.
+type LeMURBudgetError struct {
+	Budget LeMURBudget
+
+	// Label of the request, for job budgets.
+	Label string
+
+	// The budget, the cost of previous requests, including the estimated
+	// cost of requests still in flight, and the estimated cost of the
+	// rejected request.
+	Limit    float64
+	Spent    float64
+	Estimate float64
+
+	// Number of transcripts the rejected request would have sent. Their text
+	// isn't included in the estimate.
+	Transcripts int
+}
+
+// Error returns the budget that would be exceeded.
+func (e *LeMURBudgetError) Error() string {
+	budget := string(e.Budget)
+	if e.Label != "" {
+		budget += " " + e.Label
+	}
+	msg := fmt.Sprintf("%s: %s budget is %.4f, spent %.4f, request estimated at %.4f", ErrLeMURBudgetExceeded, budget, e.Limit, e.Spent, e.Estimate)
+	if e.Transcripts > 0 {
+		msg += fmt.Sprintf(" excluding %d transcripts", e.Transcripts)
+	}
+	return msg
+}
+
+// Is reports whether target is [ErrLeMURBudgetExceeded].
+func (e *LeMURBudgetError) Is(target error) bool {
+	return target == ErrLeMURBudgetExceeded
+}
+
+// LeMURPrice is the price of a LeMUR model, in any currency.
+type LeMURPrice struct {
+	// Price of 1,000 input tokens.
+	Input float64
+
+	// Price of 1,000 output tokens.
+	Output float64
+}
+
+// Cost returns the cost of the given usage.
+func (p LeMURPrice) Cost(usage LeMURUsage) float64 {
+	return (float64(ToInt64(usage.InputTokens))*p.Input + float64(ToInt64(usage.OutputTokens))*p.Output) / 1000
+}
+
+// LeMURUsageTrackerOptions configures a [LeMURUsageTracker].
+type LeMURUsageTrackerOptions struct {
+	// Prices of the models, used to estimate costs. Requests to models
+	// without a price are free.
+	Prices map[LeMURModel]LeMURPrice
+
+	// Length of the time windows that usage is reported for. Defaults to
+	// [DefaultLeMURUsageWindow].
+	Window time.Duration
+
+	// Most that requests can cost in a UTC calendar day. Zero means no
+	// limit.
+	DailyBudget float64
+
+	// Most that the requests with the same label can cost. Requests without
+	// a label aren't limited. Zero means no limit.
+	JobBudget float64
+
+	// Returns the current time. Defaults to [time.Now].
+	Now func() time.Time
+}
+
+// LeMURUsageTotals are the combined usage numbers of a set of requests.
+type LeMURUsageTotals struct {
+	Requests     int
+	InputTokens  int64
+	OutputTokens int64
+
+	// Estimated cost, according to the configured prices.
+	Cost float64
+}
+
+func (t *LeMURUsageTotals) add(usage LeMURUsage, cost float64) {
+	t.Requests++
+	t.InputTokens += ToInt64(usage.InputTokens)
+	t.OutputTokens += ToInt64(usage.OutputTokens)
+	t.Cost += cost
+}
+
+// LeMURUsageReport breaks down the usage of the requests tracked by a
+// [LeMURUsageTracker].
+type LeMURUsageReport struct {
+	Total LeMURUsageTotals
+
+	// Usage by final model. Requests that don't set
+	// [LeMURBaseParams.FinalModel] run on the default model of LeMUR, so
+	// they're reported under [LeMURModelDefault] and priced as such.
+	ByModel map[LeMURModel]LeMURUsageTotals
+
+	// Usage by label. Requests without a label are reported under the empty
+	// label.
+	ByLabel map[string]LeMURUsageTotals
+
+	// Usage by the start of the time window the requests completed in.
+	ByWindow map[time.Time]LeMURUsageTotals
+}
+
+// LeMURUsageTracker accumulates the usage of LeMUR requests, and optionally
+// rejects requests that would exceed a budget. Use [WithLeMURUsageTracker] to
+// track the requests of a client:
+//
+//	tracker := assemblyai.NewLeMURUsageTracker(&assemblyai.LeMURUsageTrackerOptions{
+//		Prices: map[assemblyai.LeMURModel]assemblyai.LeMURPrice{
+//			assemblyai.LeMURModelAnthropicClaude3_5_Sonnet: {Input: 0.003, Output: 0.015},
+//		},
+//		JobBudget: 5,
+//	})
+//
+//	client := assemblyai.NewClientWithOptions(assemblyai.WithLeMURUsageTracker(tracker))
+//
+//	ctx = assemblyai.WithLeMURUsageLabel(ctx, "weekly-report")
+//
+// Before a request is sent, its cost is estimated from its prompt, input
+// text, context and maximum output size, and reserved until the request
+// completes, so that concurrent requests can't exceed a budget together. The
+// text of transcripts isn't included in the estimate, so budgets are only
+// enforced approximately for requests with transcripts. The number of
+// transcripts is reported in [LeMURBudgetError.Transcripts].
+//
+// It's safe for concurrent use.
+type LeMURUsageTracker struct {
+	opts LeMURUsageTrackerOptions
+
+	mu     sync.Mutex
+	report LeMURUsageReport
+	daily  map[time.Time]float64
+
+	// Requests in flight, with their estimated costs.
+	reserved map[*lemurReservation]struct{}
+}
+
+// lemurReservation is the estimated cost of a request in flight.
+type lemurReservation struct {
+	label string
+	day   time.Time
+	cost  float64
+}
+
+// NewLeMURUsageTracker returns a tracker without any usage.
+func NewLeMURUsageTracker(opts *LeMURUsageTrackerOptions) *LeMURUsageTracker {
+	t := &LeMURUsageTracker{}
+
+	if opts != nil {
+		t.opts = *opts
+	}
+
+	if t.opts.Window <= 0 {
+		t.opts.Window = DefaultLeMURUsageWindow
+	}
+
+	if t.opts.Now == nil {
+		t.opts.Now = time.Now
+	}
+
+	t.Reset()
+
+	return t
+}
+
+// WithLeMURUsageTracker tracks the usage of the LeMUR requests of the client
+// with the given tracker. A tracker can be shared by several clients.
+func WithLeMURUsageTracker(tracker *LeMURUsageTracker) ClientOption {
+	return func(c *Client) {
+		c.usageTracker = tracker
+	}
+}
+
+type lemurUsageLabelKey struct{}
+
+// WithLeMURUsageLabel returns a context that labels the LeMUR requests made
+// with it, to report their usage and enforce job budgets.
+func WithLeMURUsageLabel(ctx context.Context, label string) context.Context {
+	return context.WithValue(ctx, lemurUsageLabelKey{}, label)
+}
+
+func lemurUsageLabel(ctx context.Context) string {
+	label, _ := ctx.Value(lemurUsageLabelKey{}).(string)
+	return label
+}
+
+// Record adds the usage of a request to the tracker. Requests made by a client
+// with the tracker are recorded automatically. An empty model is recorded as
+// [LeMURModelDefault], like requests without a final model.
+func (t *LeMURUsageTracker) Record(label string, model LeMURModel, usage LeMURUsage) {
+	if model == "" {
+		model = LeMURModelDefault
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.record(label, model, usage)
+}
+
+func (t *LeMURUsageTracker) record(label string, model LeMURModel, usage LeMURUsage) {
+	now := t.opts.Now()
+	cost := t.opts.Prices[model].Cost(usage)
+
+	add := func(totals LeMURUsageTotals) LeMURUsageTotals {
+		totals.add(usage, cost)
+		return totals
+	}
+
+	t.report.Total = add(t.report.Total)
+	t.report.ByModel[model] = add(t.report.ByModel[model])
+	t.report.ByLabel[label] = add(t.report.ByLabel[label])
+
+	window := now.Truncate(t.opts.Window)
+	t.report.ByWindow[window] = add(t.report.ByWindow[window])
+
+	t.daily[utcDay(now)] += cost
+}
+
+// Report returns the usage recorded so far.
+func (t *LeMURUsageTracker) Report() LeMURUsageReport {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	report := LeMURUsageReport{
+		Total:    t.report.Total,
+		ByModel:  make(map[LeMURModel]LeMURUsageTotals, len(t.report.ByModel)),
+		ByLabel:  make(map[string]LeMURUsageTotals, len(t.report.ByLabel)),
+		ByWindow: make(map[time.Time]LeMURUsageTotals, len(t.report.ByWindow)),
+	}
+
+	for k, v := range t.report.ByModel {
+		report.ByModel[k] = v
+	}
+
+	for k, v := range t.report.ByLabel {
+		report.ByLabel[k] = v
+	}
+
+	for k, v := range t.report.ByWindow {
+		report.ByWindow[k] = v
+	}
+
+	return report
+}
+
+// Reset discards the usage recorded so far, and resets the budgets.
+func (t *LeMURUsageTracker) Reset() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.report = LeMURUsageReport{
+		ByModel:  map[LeMURModel]LeMURUsageTotals{},
+		ByLabel:  map[string]LeMURUsageTotals{},
+		ByWindow: map[time.Time]LeMURUsageTotals{},
+	}
+
+	t.daily = map[time.Time]float64{}
+	t.reserved = map[*lemurReservation]struct{}{}
+}
+
+// reserve returns a [*LeMURBudgetError] if a request with the given estimated
+// usage would exceed a budget, and otherwise reserves its estimated cost until
+// it's passed to settle or release.
+func (t *LeMURUsageTracker) reserve(label string, model LeMURModel, estimate LeMURUsage, transcripts int) (*lemurReservation, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	r := &lemurReservation{
+		label: label,
+		day:   utcDay(t.opts.Now()),
+		cost:  t.opts.Prices[model].Cost(estimate),
+	}
+
+	if t.opts.JobBudget > 0 && label != "" {
+		spent := t.report.ByLabel[label].Cost
+
+		for other := range t.reserved {
+			if other.label == label {
+				spent += other.cost
+			}
+		}
+
+		if spent+r.cost > t.opts.JobBudget {
+			return nil, &LeMURBudgetError{Budget: LeMURBudgetJob, Label: label, Limit: t.opts.JobBudget, Spent: spent, Estimate: r.cost, Transcripts: transcripts}
+		}
+	}
+
+	if t.opts.DailyBudget > 0 {
+		spent := t.daily[r.day]
+
+		for other := range t.reserved {
+			if other.day.Equal(r.day) {
+				spent += other.cost
+			}
+		}
+
+		if spent+r.cost > t.opts.DailyBudget {
+			return nil, &LeMURBudgetError{Budget: LeMURBudgetDaily, Limit: t.opts.DailyBudget, Spent: spent, Estimate: r.cost, Transcripts: transcripts}
+		}
+	}
+
+	t.reserved[r] = struct{}{}
+
+	return r, nil
+}
+
+// settle replaces a reservation with the actual usage of the request.
+func (t *LeMURUsageTracker) settle(r *lemurReservation, model LeMURModel, usage LeMURUsage) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	delete(t.reserved, r)
+	t.record(r.label, model, usage)
+}
+
+// release discards the reservation of a request that failed.
+func (t *LeMURUsageTracker) release(r *lemurReservation) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	delete(t.reserved, r)
+}
+
+func utcDay(t time.Time) time.Time {
+	y, m, d := t.UTC().Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// estimateLeMURUsage estimates the usage of a request from its parameters and
+// any additional text it sends, such as a prompt.
+func estimateLeMURUsage(params LeMURBaseParams, text string) LeMURUsage {
+	input := EstimateLeMURTokens(text) + EstimateLeMURTokens(ToString(params.InputText))
+
+	switch v := params.Context.(type) {
+	case nil:
+	case string:
+		input += EstimateLeMURTokens(v)
+	default:
+		if b, err := json.Marshal(v); err == nil {
+			input += EstimateLeMURTokens(string(b))
+		}
+	}
+
+	return LeMURUsage{
+		InputTokens:  Int64(input),
+		OutputTokens: Int64(ToInt64(params.MaxOutputSize)),
+	}
+}
@@ -0,0 +1,185 @@
+package assemblyai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLeMURUsageTracker(t *testing.T) {
+	t.Parallel()
+
+	handler := http.NewServeMux()
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	var requests int
+
+	handler.HandleFunc("/lemur/v3/generate/task", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		w.Header().Set("Content-Type", "application/json")
+		require.NoError(t, json.NewEncoder(w).Encode(LeMURTaskResponse{
+			Response: String("Done."),
+			LeMURBaseResponse: LeMURBaseResponse{
+				Usage: LeMURUsage{InputTokens: Int64(1000), OutputTokens: Int64(100)},
+			},
+		}))
+	})
+
+	now := time.Date(2024, 1, 1, 10, 30, 0, 0, time.UTC)
+
+	tracker := NewLeMURUsageTracker(&LeMURUsageTrackerOptions{
+		Prices: map[LeMURModel]LeMURPrice{
+			LeMURModelAnthropicClaude3_5_Sonnet: {Input: 0.003, Output: 0.015},
+		},
+		JobBudget:   0.01,
+		DailyBudget: 0.015,
+		Now:         func() time.Time { return now },
+	})
+
+	client := NewClientWithOptions(WithBaseURL(server.URL), WithLeMURUsageTracker(tracker))
+
+	task := func(ctx context.Context, model LeMURModel) error {
+		_, err := client.LeMUR.Task(ctx, LeMURTaskParams{
+			Prompt:          String("Summarize the call."),
+			LeMURBaseParams: LeMURBaseParams{TranscriptIDs: []string{"transcript_id"}, FinalModel: model},
+		})
+		return err
+	}
+
+	job := WithLeMURUsageLabel(context.Background(), "job")
+
+	// Each request costs 0.0045.
+	require.NoError(t, task(job, LeMURModelAnthropicClaude3_5_Sonnet))
+	require.NoError(t, task(job, LeMURModelAnthropicClaude3_5_Sonnet))
+
+	// Unpriced models are free.
+	require.NoError(t, task(job, ""))
+
+	now = now.Add(time.Hour)
+
+	require.NoError(t, task(context.Background(), LeMURModelAnthropicClaude3_5_Sonnet))
+
+	report := tracker.Report()
+	require.Equal(t, 4, report.Total.Requests)
+	require.Equal(t, int64(4000), report.Total.InputTokens)
+	require.Equal(t, int64(400), report.Total.OutputTokens)
+	require.InDelta(t, 0.0135, report.Total.Cost, 1e-9)
+
+	require.Equal(t, 3, report.ByModel[LeMURModelAnthropicClaude3_5_Sonnet].Requests)
+	require.Equal(t, 1, report.ByModel[LeMURModelDefault].Requests)
+	require.Equal(t, 3, report.ByLabel["job"].Requests)
+	require.Equal(t, 1, report.ByLabel[""].Requests)
+	require.Equal(t, 3, report.ByWindow[time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)].Requests)
+	require.Equal(t, 1, report.ByWindow[time.Date(2024, 1, 1, 11, 0, 0, 0, time.UTC)].Requests)
+
+	// The job has spent 0.009, so a request with 1,000 tokens of output
+	// would exceed its budget.
+	_, err := client.LeMUR.Task(job, LeMURTaskParams{
+		Prompt:          String("Summarize the call."),
+		LeMURBaseParams: LeMURBaseParams{TranscriptIDs: []string{"transcript_id"}, FinalModel: LeMURModelAnthropicClaude3_5_Sonnet, MaxOutputSize: Int64(1000)},
+	})
+	require.True(t, errors.Is(err, ErrLeMURBudgetExceeded))
+
+	var budgetErr *LeMURBudgetError
+	require.True(t, errors.As(err, &budgetErr))
+	require.Equal(t, LeMURBudgetJob, budgetErr.Budget)
+	require.Equal(t, "job", budgetErr.Label)
+	require.Equal(t, 1, budgetErr.Transcripts)
+	require.Contains(t, err.Error(), "excluding 1 transcripts")
+
+	// The day has spent 0.0135.
+	require.NoError(t, task(context.Background(), LeMURModelAnthropicClaude3_5_Sonnet))
+
+	err = task(context.Background(), LeMURModelAnthropicClaude3_5_Sonnet)
+	require.True(t, errors.As(err, &budgetErr))
+	require.Equal(t, LeMURBudgetDaily, budgetErr.Budget)
+
+	require.Equal(t, 5, requests)
+
+	// Budgets reset the next day.
+	now = now.Add(24 * time.Hour)
+	require.NoError(t, task(context.Background(), LeMURModelAnthropicClaude3_5_Sonnet))
+
+	// Usage recorded without a model is filed under the default model too.
+	tracker.Record("manual", "", LeMURUsage{InputTokens: Int64(10)})
+	require.Equal(t, 2, tracker.Report().ByModel[LeMURModelDefault].Requests)
+	require.NotContains(t, tracker.Report().ByModel, LeMURModel(""))
+
+	tracker.Reset()
+	require.Zero(t, tracker.Report().Total.Requests)
+}
+
+func TestLeMURUsageTracker_Concurrent(t *testing.T) {
+	t.Parallel()
+
+	handler := http.NewServeMux()
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	release := make(chan struct{})
+
+	handler.HandleFunc("/lemur/v3/generate/task", func(w http.ResponseWriter, r *http.Request) {
+		<-release
+
+		w.Header().Set("Content-Type", "application/json")
+		require.NoError(t, json.NewEncoder(w).Encode(LeMURTaskResponse{
+			Response: String("Done."),
+			LeMURBaseResponse: LeMURBaseResponse{
+				Usage: LeMURUsage{InputTokens: Int64(10), OutputTokens: Int64(100)},
+			},
+		}))
+	})
+
+	tracker := NewLeMURUsageTracker(&LeMURUsageTrackerOptions{
+		Prices: map[LeMURModel]LeMURPrice{
+			LeMURModelDefault: {Input: 0.003, Output: 0.015},
+		},
+		JobBudget: 0.035,
+	})
+
+	client := NewClientWithOptions(WithBaseURL(server.URL), WithLeMURUsageTracker(tracker))
+
+	job := WithLeMURUsageLabel(context.Background(), "job")
+
+	// Each request is estimated at just over 0.015, so only two fit in the
+	// budget at once.
+	task := func() error {
+		_, err := client.LeMUR.Task(job, LeMURTaskParams{
+			Prompt:          String("Summarize the call."),
+			LeMURBaseParams: LeMURBaseParams{InputText: String("Hello."), MaxOutputSize: Int64(1000)},
+		})
+		return err
+	}
+
+	errs := make(chan error)
+
+	for i := 0; i < 4; i++ {
+		go func() { errs <- task() }()
+	}
+
+	// The requests in flight hold their reservations, so the others are
+	// rejected before any of them completes.
+	for i := 0; i < 2; i++ {
+		require.ErrorIs(t, <-errs, ErrLeMURBudgetExceeded)
+	}
+
+	close(release)
+
+	for i := 0; i < 2; i++ {
+		require.NoError(t, <-errs)
+	}
+
+	// Completed requests are charged their actual usage instead.
+	require.Equal(t, 2, tracker.Report().Total.Requests)
+	require.NoError(t, task())
+}
@@ -0,0 +1,411 @@
+package assemblyai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// ErrNotMocked is returned by mock methods whose function isn't set.
+var ErrNotMocked = errors.New("method not mocked")
+
+func notMocked(iface, method string) error {
+	return fmt.Errorf("%w: %s.%s", ErrNotMocked, iface, method)
+}
+
+// MockCall is a call to a mock method.
+type MockCall struct {
+	Method string
+
+	// Arguments of the call, starting with the context.
+	Args []interface{}
+}
+
+// mockCalls records the calls to a mock. It's safe for concurrent use.
+type mockCalls struct {
+	mu    sync.Mutex
+	calls []MockCall
+}
+
+func (m *mockCalls) record(method string, args ...interface{}) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.calls = append(m.calls, MockCall{Method: method, Args: args})
+}
+
+// Calls returns the calls to the mock, in order.
+func (m *mockCalls) Calls() []MockCall {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]MockCall(nil), m.calls...)
+}
+
+// CallsTo returns the calls to the given method of the mock, in order.
+func (m *mockCalls) CallsTo(method string) []MockCall {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var calls []MockCall
+
+	for _, call := range m.calls {
+		if call.Method == method {
+			calls = append(calls, call)
+		}
+	}
+
+	return calls
+}
+
+// ResetCalls forgets the calls to the mock.
+func (m *mockCalls) ResetCalls() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.calls = nil
+}
+
+// MockTranscriber is a mock implementation of [Transcriber] that records its calls.
+// Set the function of a method to configure its response. Calls to methods
+// without a function return an error wrapping [ErrNotMocked].
+type MockTranscriber struct {
+	SubmitFromURLFunc         func(ctx context.Context, audioURL string, opts *TranscriptOptionalParams) (Transcript, error)
+	SubmitFromReaderFunc      func(ctx context.Context, reader io.Reader, params *TranscriptOptionalParams) (Transcript, error)
+	TranscribeFromURLFunc     func(ctx context.Context, audioURL string, opts *TranscriptOptionalParams) (Transcript, error)
+	TranscribeFromReaderFunc  func(ctx context.Context, reader io.Reader, opts *TranscriptOptionalParams) (Transcript, error)
+	WaitFunc                  func(ctx context.Context, transcriptID string) (Transcript, error)
+	GetFunc                   func(ctx context.Context, transcriptID string) (Transcript, error)
+	DeleteFunc                func(ctx context.Context, transcriptID string) (Transcript, error)
+	ListFunc                  func(ctx context.Context, options ListTranscriptParams) (TranscriptList, error)
+	GetSentencesFunc          func(ctx context.Context, transcriptID string) (SentencesResponse, error)
+	GetParagraphsFunc         func(ctx context.Context, transcriptID string) (ParagraphsResponse, error)
+	GetSubtitlesFunc          func(ctx context.Context, transcriptID string, format SubtitleFormat, opts *TranscriptGetSubtitlesOptions) ([]byte, error)
+	GetRedactedAudioFunc      func(ctx context.Context, transcriptID string) (RedactedAudioResponse, error)
+	DownloadRedactedAudioFunc func(ctx context.Context, transcriptID string, w io.Writer, opts *DownloadRedactedAudioOptions) (RedactedAudioDownload, error)
+	WordSearchFunc            func(ctx context.Context, transcriptID string, words []string) (WordSearchResponse, error)
+	SyncFunc                  func(ctx context.Context, store Store, opts *SyncOptions) (SyncResult, error)
+
+	mockCalls
+}
+
+// SubmitFromURL records the call and calls SubmitFromURLFunc.
+func (m *MockTranscriber) SubmitFromURL(ctx context.Context, audioURL string, opts *TranscriptOptionalParams) (Transcript, error) {
+	m.record("SubmitFromURL", ctx, audioURL, opts)
+
+	if m.SubmitFromURLFunc == nil {
+		return Transcript{}, notMocked("Transcriber", "SubmitFromURL")
+	}
+
+	return m.SubmitFromURLFunc(ctx, audioURL, opts)
+}
+
+// SubmitFromReader records the call and calls SubmitFromReaderFunc.
+func (m *MockTranscriber) SubmitFromReader(ctx context.Context, reader io.Reader, params *TranscriptOptionalParams) (Transcript, error) {
+	m.record("SubmitFromReader", ctx, reader, params)
+
+	if m.SubmitFromReaderFunc == nil {
+		return Transcript{}, notMocked("Transcriber", "SubmitFromReader")
+	}
+
+	return m.SubmitFromReaderFunc(ctx, reader, params)
+}
+
+// TranscribeFromURL records the call and calls TranscribeFromURLFunc.
+func (m *MockTranscriber) TranscribeFromURL(ctx context.Context, audioURL string, opts *TranscriptOptionalParams) (Transcript, error) {
+	m.record("TranscribeFromURL", ctx, audioURL, opts)
+
+	if m.TranscribeFromURLFunc == nil {
+		return Transcript{}, notMocked("Transcriber", "TranscribeFromURL")
+	}
+
+	return m.TranscribeFromURLFunc(ctx, audioURL, opts)
+}
+
+// TranscribeFromReader records the call and calls TranscribeFromReaderFunc.
+func (m *MockTranscriber) TranscribeFromReader(ctx context.Context, reader io.Reader, opts *TranscriptOptionalParams) (Transcript, error) {
+	m.record("TranscribeFromReader", ctx, reader, opts)
+
+	if m.TranscribeFromReaderFunc == nil {
+		return Transcript{}, notMocked("Transcriber", "TranscribeFromReader")
+	}
+
+	return m.TranscribeFromReaderFunc(ctx, reader, opts)
+}
+
+// Wait records the call and calls WaitFunc.
+func (m *MockTranscriber) Wait(ctx context.Context, transcriptID string) (Transcript, error) {
+	m.record("Wait", ctx, transcriptID)
+
+	if m.WaitFunc == nil {
+		return Transcript{}, notMocked("Transcriber", "Wait")
+	}
+
+	return m.WaitFunc(ctx, transcriptID)
+}
+
+// Get records the call and calls GetFunc.
+func (m *MockTranscriber) Get(ctx context.Context, transcriptID string) (Transcript, error) {
+	m.record("Get", ctx, transcriptID)
+
+	if m.GetFunc == nil {
+		return Transcript{}, notMocked("Transcriber", "Get")
+	}
+
+	return m.GetFunc(ctx, transcriptID)
+}
+
+// Delete records the call and calls DeleteFunc.
+func (m *MockTranscriber) Delete(ctx context.Context, transcriptID string) (Transcript, error) {
+	m.record("Delete", ctx, transcriptID)
+
+	if m.DeleteFunc == nil {
+		return Transcript{}, notMocked("Transcriber", "Delete")
+	}
+
+	return m.DeleteFunc(ctx, transcriptID)
+}
+
+// List records the call and calls ListFunc.
+func (m *MockTranscriber) List(ctx context.Context, options ListTranscriptParams) (TranscriptList, error) {
+	m.record("List", ctx, options)
+
+	if m.ListFunc == nil {
+		return TranscriptList{}, notMocked("Transcriber", "List")
+	}
+
+	return m.ListFunc(ctx, options)
+}
+
+// GetSentences records the call and calls GetSentencesFunc.
+func (m *MockTranscriber) GetSentences(ctx context.Context, transcriptID string) (SentencesResponse, error) {
+	m.record("GetSentences", ctx, transcriptID)
+
+	if m.GetSentencesFunc == nil {
+		return SentencesResponse{}, notMocked("Transcriber", "GetSentences")
+	}
+
+	return m.GetSentencesFunc(ctx, transcriptID)
+}
+
+// GetParagraphs records the call and calls GetParagraphsFunc.
+func (m *MockTranscriber) GetParagraphs(ctx context.Context, transcriptID string) (ParagraphsResponse, error) {
+	m.record("GetParagraphs", ctx, transcriptID)
+
+	if m.GetParagraphsFunc == nil {
+		return ParagraphsResponse{}, notMocked("Transcriber", "GetParagraphs")
+	}
+
+	return m.GetParagraphsFunc(ctx, transcriptID)
+}
+
+// GetSubtitles records the call and calls GetSubtitlesFunc.
+func (m *MockTranscriber) GetSubtitles(ctx context.Context, transcriptID string, format SubtitleFormat, opts *TranscriptGetSubtitlesOptions) ([]byte, error) {
+	m.record("GetSubtitles", ctx, transcriptID, format, opts)
+
+	if m.GetSubtitlesFunc == nil {
+		return nil, notMocked("Transcriber", "GetSubtitles")
+	}
+
+	return m.GetSubtitlesFunc(ctx, transcriptID, format, opts)
+}
+
+// GetRedactedAudio records the call and calls GetRedactedAudioFunc.
+func (m *MockTranscriber) GetRedactedAudio(ctx context.Context, transcriptID string) (RedactedAudioResponse, error) {
+	m.record("GetRedactedAudio", ctx, transcriptID)
+
+	if m.GetRedactedAudioFunc == nil {
+		return RedactedAudioResponse{}, notMocked("Transcriber", "GetRedactedAudio")
+	}
+
+	return m.GetRedactedAudioFunc(ctx, transcriptID)
+}
+
+// DownloadRedactedAudio records the call and calls DownloadRedactedAudioFunc.
+func (m *MockTranscriber) DownloadRedactedAudio(ctx context.Context, transcriptID string, w io.Writer, opts *DownloadRedactedAudioOptions) (RedactedAudioDownload, error) {
+	m.record("DownloadRedactedAudio", ctx, transcriptID, w, opts)
+
+	if m.DownloadRedactedAudioFunc == nil {
+		return RedactedAudioDownload{}, notMocked("Transcriber", "DownloadRedactedAudio")
+	}
+
+	return m.DownloadRedactedAudioFunc(ctx, transcriptID, w, opts)
+}
+
+// WordSearch records the call and calls WordSearchFunc.
+func (m *MockTranscriber) WordSearch(ctx context.Context, transcriptID string, words []string) (WordSearchResponse, error) {
+	m.record("WordSearch", ctx, transcriptID, words)
+
+	if m.WordSearchFunc == nil {
+		return WordSearchResponse{}, notMocked("Transcriber", "WordSearch")
+	}
+
+	return m.WordSearchFunc(ctx, transcriptID, words)
+}
+
+// Sync records the call and calls SyncFunc.
+func (m *MockTranscriber) Sync(ctx context.Context, store Store, opts *SyncOptions) (SyncResult, error) {
+	m.record("Sync", ctx, store, opts)
+
+	if m.SyncFunc == nil {
+		return SyncResult{}, notMocked("Transcriber", "Sync")
+	}
+
+	return m.SyncFunc(ctx, store, opts)
+}
+
+var _ Transcriber = (*MockTranscriber)(nil)
+
+// MockLeMURer is a mock implementation of [LeMURer] that records its calls.
+// Set the function of a method to configure its response. Calls to methods
+// without a function return an error wrapping [ErrNotMocked].
+type MockLeMURer struct {
+	QuestionFunc         func(ctx context.Context, params LeMURQuestionAnswerParams) (LeMURQuestionAnswerResponse, error)
+	SummarizeFunc        func(ctx context.Context, params LeMURSummaryParams) (LeMURSummaryResponse, error)
+	ActionItemsFunc      func(ctx context.Context, params LeMURActionItemsParams) (LeMURActionItemsResponse, error)
+	TaskFunc             func(ctx context.Context, params LeMURTaskParams) (LeMURTaskResponse, error)
+	PurgeRequestDataFunc func(ctx context.Context, requestID string) (PurgeLeMURRequestDataResponse, error)
+	GetResponseDataFunc  func(ctx context.Context, requestID string, response interface{}) error
+
+	mockCalls
+}
+
+// Question records the call and calls QuestionFunc.
+func (m *MockLeMURer) Question(ctx context.Context, params LeMURQuestionAnswerParams) (LeMURQuestionAnswerResponse, error) {
+	m.record("Question", ctx, params)
+
+	if m.QuestionFunc == nil {
+		return LeMURQuestionAnswerResponse{}, notMocked("LeMURer", "Question")
+	}
+
+	return m.QuestionFunc(ctx, params)
+}
+
+// Summarize records the call and calls SummarizeFunc.
+func (m *MockLeMURer) Summarize(ctx context.Context, params LeMURSummaryParams) (LeMURSummaryResponse, error) {
+	m.record("Summarize", ctx, params)
+
+	if m.SummarizeFunc == nil {
+		return LeMURSummaryResponse{}, notMocked("LeMURer", "Summarize")
+	}
+
+	return m.SummarizeFunc(ctx, params)
+}
+
+// ActionItems records the call and calls ActionItemsFunc.
+func (m *MockLeMURer) ActionItems(ctx context.Context, params LeMURActionItemsParams) (LeMURActionItemsResponse, error) {
+	m.record("ActionItems", ctx, params)
+
+	if m.ActionItemsFunc == nil {
+		return LeMURActionItemsResponse{}, notMocked("LeMURer", "ActionItems")
+	}
+
+	return m.ActionItemsFunc(ctx, params)
+}
+
+// Task records the call and calls TaskFunc.
+func (m *MockLeMURer) Task(ctx context.Context, params LeMURTaskParams) (LeMURTaskResponse, error) {
+	m.record("Task", ctx, params)
+
+	if m.TaskFunc == nil {
+		return LeMURTaskResponse{}, notMocked("LeMURer", "Task")
+	}
+
+	return m.TaskFunc(ctx, params)
+}
+
+// PurgeRequestData records the call and calls PurgeRequestDataFunc.
+func (m *MockLeMURer) PurgeRequestData(ctx context.Context, requestID string) (PurgeLeMURRequestDataResponse, error) {
+	m.record("PurgeRequestData", ctx, requestID)
+
+	if m.PurgeRequestDataFunc == nil {
+		return PurgeLeMURRequestDataResponse{}, notMocked("LeMURer", "PurgeRequestData")
+	}
+
+	return m.PurgeRequestDataFunc(ctx, requestID)
+}
+
+// GetResponseData records the call and calls GetResponseDataFunc.
+func (m *MockLeMURer) GetResponseData(ctx context.Context, requestID string, response interface{}) error {
+	m.record("GetResponseData", ctx, requestID, response)
+
+	if m.GetResponseDataFunc == nil {
+		return notMocked("LeMURer", "GetResponseData")
+	}
+
+	return m.GetResponseDataFunc(ctx, requestID, response)
+}
+
+var _ LeMURer = (*MockLeMURer)(nil)
+
+// MockRealTimeStreamer is a mock implementation of [RealTimeStreamer] that records its calls.
+// Set the function of a method to configure its response. Calls to methods
+// without a function return an error wrapping [ErrNotMocked].
+type MockRealTimeStreamer struct {
+	ConnectFunc                         func(ctx context.Context) error
+	DisconnectFunc                      func(ctx context.Context, waitForSessionTermination bool) error
+	SendFunc                            func(ctx context.Context, samples []byte) error
+	ForceEndUtteranceFunc               func(ctx context.Context) error
+	SetEndUtteranceSilenceThresholdFunc func(ctx context.Context, threshold int64) error
+
+	mockCalls
+}
+
+// Connect records the call and calls ConnectFunc.
+func (m *MockRealTimeStreamer) Connect(ctx context.Context) error {
+	m.record("Connect", ctx)
+
+	if m.ConnectFunc == nil {
+		return notMocked("RealTimeStreamer", "Connect")
+	}
+
+	return m.ConnectFunc(ctx)
+}
+
+// Disconnect records the call and calls DisconnectFunc.
+func (m *MockRealTimeStreamer) Disconnect(ctx context.Context, waitForSessionTermination bool) error {
+	m.record("Disconnect", ctx, waitForSessionTermination)
+
+	if m.DisconnectFunc == nil {
+		return notMocked("RealTimeStreamer", "Disconnect")
+	}
+
+	return m.DisconnectFunc(ctx, waitForSessionTermination)
+}
+
+// Send records the call and calls SendFunc.
+func (m *MockRealTimeStreamer) Send(ctx context.Context, samples []byte) error {
+	m.record("Send", ctx, samples)
+
+	if m.SendFunc == nil {
+		return notMocked("RealTimeStreamer", "Send")
+	}
+
+	return m.SendFunc(ctx, samples)
+}
+
+// ForceEndUtterance records the call and calls ForceEndUtteranceFunc.
+func (m *MockRealTimeStreamer) ForceEndUtterance(ctx context.Context) error {
+	m.record("ForceEndUtterance", ctx)
+
+	if m.ForceEndUtteranceFunc == nil {
+		return notMocked("RealTimeStreamer", "ForceEndUtterance")
+	}
+
+	return m.ForceEndUtteranceFunc(ctx)
+}
+
+// SetEndUtteranceSilenceThreshold records the call and calls SetEndUtteranceSilenceThresholdFunc.
+func (m *MockRealTimeStreamer) SetEndUtteranceSilenceThreshold(ctx context.Context, threshold int64) error {
+	m.record("SetEndUtteranceSilenceThreshold", ctx, threshold)
+
+	if m.SetEndUtteranceSilenceThresholdFunc == nil {
+		return notMocked("RealTimeStreamer", "SetEndUtteranceSilenceThreshold")
+	}
+
+	return m.SetEndUtteranceSilenceThresholdFunc(ctx, threshold)
+}
+
+var _ RealTimeStreamer = (*MockRealTimeStreamer)(nil)
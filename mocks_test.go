@@ -0,0 +1,117 @@
+package assemblyai
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMockTranscriber(t *testing.T) {
+	t.Parallel()
+
+	mock := &MockTranscriber{
+		GetFunc: func(ctx context.Context, transcriptID string) (Transcript, error) {
+			return Transcript{ID: String(transcriptID), Status: TranscriptStatusCompleted}, nil
+		},
+	}
+
+	var transcriber Transcriber = mock
+
+	ctx := context.Background()
+
+	transcript, err := transcriber.Get(ctx, fakeTranscriptID)
+	require.NoError(t, err)
+	require.Equal(t, fakeTranscriptID, ToString(transcript.ID))
+
+	_, err = transcriber.SubmitFromURL(ctx, fakeAudioURL, nil)
+	require.True(t, errors.Is(err, ErrNotMocked))
+	require.EqualError(t, err, "method not mocked: Transcriber.SubmitFromURL")
+
+	require.Len(t, mock.Calls(), 2)
+	require.Equal(t, []MockCall{{Method: "Get", Args: []interface{}{ctx, fakeTranscriptID}}}, mock.CallsTo("Get"))
+
+	mock.ResetCalls()
+	require.Empty(t, mock.Calls())
+}
+
+func TestMockLeMURer(t *testing.T) {
+	t.Parallel()
+
+	mock := &MockLeMURer{
+		TaskFunc: func(ctx context.Context, params LeMURTaskParams) (LeMURTaskResponse, error) {
+			return LeMURTaskResponse{Response: String("Done.")}, nil
+		},
+	}
+
+	var lemur LeMURer = mock
+
+	params := LeMURTaskParams{Prompt: String("Do it.")}
+
+	response, err := lemur.Task(context.Background(), params)
+	require.NoError(t, err)
+	require.Equal(t, "Done.", ToString(response.Response))
+
+	calls := mock.CallsTo("Task")
+	require.Len(t, calls, 1)
+	require.Equal(t, params, calls[0].Args[1])
+}
+
+func TestInterfaces_MatchServices(t *testing.T) {
+	t.Parallel()
+
+	// LeMURer only has the methods that call an endpoint, so that adding
+	// helpers to LeMURService doesn't break other implementations.
+	helpers := map[string]bool{
+		"Conversation":       true,
+		"ResumeConversation": true,
+		"TaskInto":           true,
+		"MapReduceTask":      true,
+		"MapReduceSummary":   true,
+	}
+
+	for _, tt := range []struct {
+		service, iface reflect.Type
+	}{
+		{reflect.TypeOf((*TranscriptService)(nil)), reflect.TypeOf((*Transcriber)(nil)).Elem()},
+		{reflect.TypeOf((*LeMURService)(nil)), reflect.TypeOf((*LeMURer)(nil)).Elem()},
+		{reflect.TypeOf((*RealTimeClient)(nil)), reflect.TypeOf((*RealTimeStreamer)(nil)).Elem()},
+	} {
+		for i := 0; i < tt.service.NumMethod(); i++ {
+			name := tt.service.Method(i).Name
+			if tt.service.Elem() == reflect.TypeOf(LeMURService{}) && helpers[name] {
+				continue
+			}
+
+			_, ok := tt.iface.MethodByName(name)
+			require.True(t, ok, "%s is missing %s", tt.iface.Name(), name)
+		}
+	}
+}
+
+func TestMockRealTimeStreamer(t *testing.T) {
+	t.Parallel()
+
+	var sent [][]byte
+
+	mock := &MockRealTimeStreamer{
+		ConnectFunc: func(ctx context.Context) error { return nil },
+		SendFunc: func(ctx context.Context, samples []byte) error {
+			sent = append(sent, samples)
+			return nil
+		},
+	}
+
+	var session RealTimeStreamer = mock
+
+	ctx := context.Background()
+
+	require.NoError(t, session.Connect(ctx))
+	require.NoError(t, session.Send(ctx, []byte("foo")))
+	require.True(t, errors.Is(session.Disconnect(ctx, true), ErrNotMocked))
+
+	require.Equal(t, [][]byte{[]byte("foo")}, sent)
+	require.Equal(t, true, mock.CallsTo("Disconnect")[0].Args[1])
+}
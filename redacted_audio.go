@@ -0,0 +1,240 @@
+package assemblyai
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/cenkalti/backoff"
+)
+
+const (
+	// The redacted audio file is ready to download.
+	RedactedAudioStatusReady RedactedAudioStatus = "redacted_audio_ready"
+)
+
+var (
+	// ErrRedactedAudioNotRequested is returned when downloading the redacted
+	// audio of a transcript that was created without RedactPIIAudio.
+	ErrRedactedAudioNotRequested = errors.New("redacted audio was not requested for this transcript")
+
+	// ErrRedactedAudioFailed is returned when the redacted audio couldn't be
+	// generated.
+	ErrRedactedAudioFailed = errors.New("redacted audio failed")
+)
+
+// DownloadRedactedAudioOptions configures [TranscriptService.DownloadRedactedAudio].
+type DownloadRedactedAudioOptions struct {
+	// Maximum time to wait for the redacted audio to be ready and downloaded.
+	// Zero means no limit other than the context.
+	Timeout time.Duration
+
+	// Time to wait before checking the status again. The interval grows
+	// exponentially with each check. Defaults to 3 seconds.
+	PollInterval time.Duration
+
+	// Number of times the redacted audio of a completed transcript may be
+	// reported as missing before giving up with [ErrRedactedAudioFailed].
+	// Defaults to 10.
+	MaxNotReady int
+
+	// OnProgress is called while downloading with the number of bytes written
+	// so far, and the total size if known, or -1 otherwise.
+	OnProgress func(written, total int64)
+}
+
+// RedactedAudioDownload describes a downloaded redacted audio file.
+type RedactedAudioDownload struct {
+	// The URL the file was downloaded from.
+	URL string
+
+	// The number of bytes written.
+	Size int64
+
+	// The hex-encoded SHA-256 checksum of the file.
+	SHA256 string
+}
+
+// DownloadRedactedAudio waits until the redacted audio of a transcript is ready
+// and writes it to w.
+//
+// https://www.assemblyai.com/docs/Models/pii_redaction#create-a-redacted-audio-file
+func (s *TranscriptService) DownloadRedactedAudio(ctx context.Context, transcriptID string, w io.Writer, opts *DownloadRedactedAudioOptions) (RedactedAudioDownload, error) {
+	var options DownloadRedactedAudioOptions
+
+	if opts != nil {
+		options = *opts
+	}
+
+	if options.PollInterval <= 0 {
+		options.PollInterval = 3 * time.Second
+	}
+
+	if options.MaxNotReady <= 0 {
+		options.MaxNotReady = 10
+	}
+
+	if options.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, options.Timeout)
+		defer cancel()
+	}
+
+	audioURL, err := s.waitForRedactedAudio(ctx, transcriptID, options.PollInterval, options.MaxNotReady)
+	if err != nil {
+		return RedactedAudioDownload{}, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", audioURL, nil)
+	if err != nil {
+		return RedactedAudioDownload{}, err
+	}
+
+	req.Header.Set("User-Agent", s.client.userAgent)
+
+	resp, err := s.client.httpClient.Do(req)
+	if err != nil {
+		return RedactedAudioDownload{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return RedactedAudioDownload{}, fmt.Errorf("%w: downloading %s: %s", ErrRedactedAudioFailed, audioURL, resp.Status)
+	}
+
+	hash := sha256.New()
+
+	pw := &progressWriter{
+		total:      resp.ContentLength,
+		onProgress: options.OnProgress,
+	}
+
+	n, err := io.Copy(io.MultiWriter(w, hash, pw), resp.Body)
+	if err != nil {
+		return RedactedAudioDownload{}, err
+	}
+
+	return RedactedAudioDownload{
+		URL:    audioURL,
+		Size:   n,
+		SHA256: hex.EncodeToString(hash.Sum(nil)),
+	}, nil
+}
+
+// waitForRedactedAudio polls the transcript and its redacted audio until the
+// audio is ready, and returns its URL. Network errors and server errors are
+// retried until the context is done, and redacted audio that isn't ready yet
+// up to maxNotReady times.
+func (s *TranscriptService) waitForRedactedAudio(ctx context.Context, transcriptID string, interval time.Duration, maxNotReady int) (string, error) {
+	b := backoff.NewExponentialBackOff()
+
+	b.InitialInterval = interval
+	b.MaxElapsedTime = 0
+
+	ticker := backoff.NewTicker(b)
+	defer ticker.Stop()
+
+	var (
+		completed bool
+		notReady  int
+
+		// The last error that was retried.
+		lastErr error
+	)
+
+	for {
+		select {
+		case <-ticker.C:
+			if !completed {
+				transcript, err := s.Get(ctx, transcriptID)
+				if err != nil {
+					if ctx.Err() == nil && isTransient(err) {
+						lastErr = err
+						continue
+					}
+					return "", err
+				}
+
+				if !ToBool(transcript.RedactPIIAudio) {
+					return "", ErrRedactedAudioNotRequested
+				}
+
+				switch transcript.Status {
+				case TranscriptStatusError:
+					return "", fmt.Errorf("%w: %s", ErrRedactedAudioFailed, ToString(transcript.Error))
+				case TranscriptStatusCompleted:
+					completed = true
+				default:
+					continue
+				}
+			}
+
+			audio, err := s.GetRedactedAudio(ctx, transcriptID)
+			if err != nil {
+				var apierr APIError
+
+				// The redacted audio of a completed transcript may not be
+				// generated yet, which the API reports as a bad request.
+				if ctx.Err() == nil && errors.As(err, &apierr) &&
+					(apierr.Status == http.StatusBadRequest || apierr.Status == http.StatusNotFound) {
+					notReady++
+					if notReady >= maxNotReady {
+						return "", fmt.Errorf("%w: %v", ErrRedactedAudioFailed, err)
+					}
+					lastErr = err
+					continue
+				}
+
+				if ctx.Err() == nil && isTransient(err) {
+					lastErr = err
+					continue
+				}
+				return "", err
+			}
+
+			if audio.Status == RedactedAudioStatusReady && ToString(audio.RedactedAudioURL) != "" {
+				return ToString(audio.RedactedAudioURL), nil
+			}
+		case <-ctx.Done():
+			if lastErr != nil {
+				return "", fmt.Errorf("%w: last error: %v", ctx.Err(), lastErr)
+			}
+			return "", ctx.Err()
+		}
+	}
+}
+
+// isTransient reports whether a request may succeed if it's sent again, for
+// network errors, server errors and rate limiting.
+func isTransient(err error) bool {
+	var apierr APIError
+	if !errors.As(err, &apierr) {
+		return !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+	}
+
+	return apierr.Status >= 500 ||
+		apierr.Status == http.StatusTooManyRequests ||
+		apierr.Status == http.StatusRequestTimeout
+}
+
+// progressWriter reports the number of bytes written to it.
+type progressWriter struct {
+	written    int64
+	total      int64
+	onProgress func(written, total int64)
+}
+
+func (pw *progressWriter) Write(p []byte) (int, error) {
+	pw.written += int64(len(p))
+
+	if pw.onProgress != nil {
+		pw.onProgress(pw.written, pw.total)
+	}
+
+	return len(p), nil
+}
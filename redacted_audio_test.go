@@ -0,0 +1,167 @@
+package assemblyai
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTranscripts_DownloadRedactedAudio(t *testing.T) {
+	t.Parallel()
+
+	client, handler, teardown := setup()
+	defer teardown()
+
+	var polls, gets int32
+
+	audio := []byte("redacted audio")
+
+	handler.HandleFunc("/v2/transcript/"+fakeTranscriptID, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "GET", r.Method)
+
+		w.Header().Set("Content-Type", "application/json")
+
+		// Server errors are retried.
+		if atomic.AddInt32(&gets, 1) < 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			fmt.Fprint(w, `{"error": "service unavailable"}`)
+			return
+		}
+
+		fmt.Fprintf(w, `{"id": %q, "status": "completed", "redact_pii_audio": true}`, fakeTranscriptID)
+	})
+
+	handler.HandleFunc("/v2/transcript/"+fakeTranscriptID+"/redacted-audio", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "GET", r.Method)
+
+		w.Header().Set("Content-Type", "application/json")
+
+		switch atomic.AddInt32(&polls, 1) {
+		case 1:
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"error": "redacted audio file is not ready yet"}`)
+			return
+		case 2:
+			fmt.Fprint(w, `{"status": "redacted_audio_processing"}`)
+			return
+		}
+
+		fmt.Fprintf(w, `{"status": "redacted_audio_ready", "redacted_audio_url": "http://%s/audio.mp3"}`, r.Host)
+	})
+
+	handler.HandleFunc("/audio.mp3", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "audio/mpeg")
+		_, _ = w.Write(audio)
+	})
+
+	ctx := context.Background()
+
+	var (
+		buf      bytes.Buffer
+		progress []int64
+	)
+
+	download, err := client.Transcripts.DownloadRedactedAudio(ctx, fakeTranscriptID, &buf, &DownloadRedactedAudioOptions{
+		PollInterval: 10 * time.Millisecond,
+		Timeout:      testTimeout,
+		OnProgress: func(written, total int64) {
+			progress = append(progress, written)
+			require.Equal(t, int64(len(audio)), total)
+		},
+	})
+	require.NoError(t, err)
+
+	sum := sha256.Sum256(audio)
+
+	require.Equal(t, audio, buf.Bytes())
+	require.Equal(t, int64(len(audio)), download.Size)
+	require.Equal(t, hex.EncodeToString(sum[:]), download.SHA256)
+	require.Equal(t, int64(len(audio)), progress[len(progress)-1])
+	require.Equal(t, int32(3), atomic.LoadInt32(&polls))
+}
+
+func TestTranscripts_DownloadRedactedAudioErrors(t *testing.T) {
+	t.Parallel()
+
+	client, handler, teardown := setup()
+	defer teardown()
+
+	handler.HandleFunc("/v2/transcript/not-requested", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"id": "not-requested", "status": "completed", "redact_pii_audio": false}`)
+	})
+
+	handler.HandleFunc("/v2/transcript/failed", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"id": "failed", "status": "error", "redact_pii_audio": true, "error": "audio too short"}`)
+	})
+
+	handler.HandleFunc("/v2/transcript/processing", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"id": "processing", "status": "processing", "redact_pii_audio": true}`)
+	})
+
+	handler.HandleFunc("/v2/transcript/missing", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"id": "missing", "status": "completed", "redact_pii_audio": true}`)
+	})
+
+	var missing int32
+
+	handler.HandleFunc("/v2/transcript/missing/redacted-audio", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&missing, 1)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"error": "redacted audio failed"}`)
+	})
+
+	handler.HandleFunc("/v2/transcript/unauthorized", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(w, `{"error": "invalid API key"}`)
+	})
+
+	ctx := context.Background()
+
+	opts := &DownloadRedactedAudioOptions{PollInterval: 10 * time.Millisecond}
+
+	var buf bytes.Buffer
+
+	_, err := client.Transcripts.DownloadRedactedAudio(ctx, "not-requested", &buf, opts)
+	require.ErrorIs(t, err, ErrRedactedAudioNotRequested)
+
+	_, err = client.Transcripts.DownloadRedactedAudio(ctx, "failed", &buf, opts)
+	require.ErrorIs(t, err, ErrRedactedAudioFailed)
+	require.Contains(t, err.Error(), "audio too short")
+
+	// Redacted audio that's never ready gives up without a deadline.
+	_, err = client.Transcripts.DownloadRedactedAudio(ctx, "missing", &buf, &DownloadRedactedAudioOptions{
+		PollInterval: time.Millisecond,
+		MaxNotReady:  3,
+	})
+	require.ErrorIs(t, err, ErrRedactedAudioFailed)
+	require.Equal(t, int32(3), atomic.LoadInt32(&missing))
+
+	_, err = client.Transcripts.DownloadRedactedAudio(ctx, "unauthorized", &buf, opts)
+
+	var apierr APIError
+	require.ErrorAs(t, err, &apierr)
+	require.Equal(t, http.StatusUnauthorized, apierr.Status)
+	require.NotErrorIs(t, err, ErrRedactedAudioFailed)
+
+	opts.Timeout = 50 * time.Millisecond
+
+	_, err = client.Transcripts.DownloadRedactedAudio(ctx, "processing", &buf, opts)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	require.Zero(t, buf.Len())
+}
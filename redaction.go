@@ -0,0 +1,482 @@
+package assemblyai
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
+
+// RedactionRule describes text to redact on the client.
+type RedactionRule struct {
+	// Name of the rule, for example "account_number". Used as replacement
+	// with [SubstitutionPolicyEntityName] and in the [RedactionReport].
+	Name string
+
+	// Pattern matches the text to redact.
+	Pattern *regexp.Regexp
+
+	// Terms are words or phrases to redact, matched regardless of case. A
+	// term that starts or ends with a letter, digit or underscore only
+	// matches on a word boundary at that end, so "cat" doesn't match in
+	// "concatenate", while "C++" and "+1 555" match wherever they appear.
+	Terms []string
+}
+
+// Redactor redacts custom entities from transcripts on the client, for
+// example internal account numbers that PII Redaction doesn't cover, or
+// transcripts that were created before a policy changed. The rules must not be
+// modified once the Redactor is in use.
+type Redactor struct {
+	// Rules to apply. If two matches overlap, the one that starts first is
+	// redacted. If they start at the same position, the earlier rule wins.
+	Rules []RedactionRule
+
+	// How to replace redacted text. Defaults to [SubstitutionPolicyHash].
+	Substitution SubstitutionPolicy
+
+	once    sync.Once
+	regexps [][]*regexp.Regexp
+}
+
+// RedactedSpan is a redacted stretch of the transcript.
+type RedactedSpan struct {
+	TimeRange
+
+	// Name of the rule that matched.
+	Rule string
+
+	// The original text.
+	Text string
+
+	// The text that replaced it.
+	Replacement string
+
+	// Speaker of the redacted text, if speaker diarization was enabled.
+	Speaker string
+}
+
+// RedactionReport lists what was redacted, for auditing.
+type RedactionReport struct {
+	Spans []RedactedSpan
+}
+
+// redactionMatch is the byte range of a match within a string.
+type redactionMatch struct {
+	start, end int
+	rule       *RedactionRule
+}
+
+// Redact returns a copy of the transcript with every text field redacted:
+// the text, words, utterances, summary, chapters, entities, highlights,
+// content safety and topic results, and sentiment analysis results. Word
+// timestamps are kept, so the redacted transcript still lines up with the
+// audio. Fields that the SDK doesn't know about can't be redacted, so Extra
+// is dropped.
+//
+// The report lists the redactions in the words of the transcript. Without
+// words, it lists those in the utterances, with the times of the utterances,
+// and without utterances, those in the text, without times.
+func (r *Redactor) Redact(t Transcript) (Transcript, RedactionReport) {
+	var report RedactionReport
+
+	if t.Words == nil && t.Utterances == nil && t.Text != nil {
+		report.Spans = r.textSpans(*t.Text, TimeRange{}, "")
+	}
+
+	if t.Text != nil {
+		t.Text = String(r.RedactText(*t.Text))
+	}
+
+	t.Words, report.Spans = r.redactWords(t.Words, report.Spans)
+
+	utterances := make([]TranscriptUtterance, len(t.Utterances))
+	for i, u := range t.Utterances {
+		var spans []RedactedSpan
+
+		if len(u.Words) == 0 && u.Text != nil {
+			spans = r.textSpans(*u.Text, TimeRange{Start: toDuration(u.Start), End: toDuration(u.End)}, ToString(u.Speaker))
+		}
+
+		if u.Text != nil {
+			u.Text = String(r.RedactText(*u.Text))
+		}
+		u.Words, spans = r.redactWords(u.Words, spans)
+		utterances[i] = u
+
+		if t.Words == nil {
+			report.Spans = append(report.Spans, spans...)
+		}
+	}
+	if t.Utterances != nil {
+		t.Utterances = utterances
+	}
+
+	sentiments := make([]SentimentAnalysisResult, len(t.SentimentAnalysisResults))
+	for i, s := range t.SentimentAnalysisResults {
+		s.Text = r.redactString(s.Text)
+		sentiments[i] = s
+	}
+	if t.SentimentAnalysisResults != nil {
+		t.SentimentAnalysisResults = sentiments
+	}
+
+	t.Summary = r.redactString(t.Summary)
+
+	chapters := make([]Chapter, len(t.Chapters))
+	for i, c := range t.Chapters {
+		c.Gist = r.redactString(c.Gist)
+		c.Headline = r.redactString(c.Headline)
+		c.Summary = r.redactString(c.Summary)
+		chapters[i] = c
+	}
+	if t.Chapters != nil {
+		t.Chapters = chapters
+	}
+
+	entities := make([]Entity, len(t.Entities))
+	for i, e := range t.Entities {
+		e.Text = r.redactString(e.Text)
+		entities[i] = e
+	}
+	if t.Entities != nil {
+		t.Entities = entities
+	}
+
+	highlights := make([]AutoHighlightResult, len(t.AutoHighlightsResult.Results))
+	for i, h := range t.AutoHighlightsResult.Results {
+		h.Text = r.redactString(h.Text)
+		highlights[i] = h
+	}
+	if t.AutoHighlightsResult.Results != nil {
+		t.AutoHighlightsResult.Results = highlights
+	}
+
+	labels := make([]ContentSafetyLabelResult, len(t.ContentSafetyLabels.Results))
+	for i, l := range t.ContentSafetyLabels.Results {
+		l.Text = r.redactString(l.Text)
+		labels[i] = l
+	}
+	if t.ContentSafetyLabels.Results != nil {
+		t.ContentSafetyLabels.Results = labels
+	}
+
+	topics := make([]TopicDetectionResult, len(t.IABCategoriesResult.Results))
+	for i, topic := range t.IABCategoriesResult.Results {
+		topic.Text = r.redactString(topic.Text)
+		topics[i] = topic
+	}
+	if t.IABCategoriesResult.Results != nil {
+		t.IABCategoriesResult.Results = topics
+	}
+
+	t.Extra = nil
+
+	return t, report
+}
+
+// RedactSentences returns a copy of the sentences with the text and words
+// redacted.
+func (r *Redactor) RedactSentences(resp SentencesResponse) SentencesResponse {
+	sentences := make([]TranscriptSentence, len(resp.Sentences))
+
+	for i, s := range resp.Sentences {
+		if s.Text != nil {
+			s.Text = String(r.RedactText(*s.Text))
+		}
+		s.Words, _ = r.redactWords(s.Words, nil)
+		sentences[i] = s
+	}
+
+	if resp.Sentences != nil {
+		resp.Sentences = sentences
+	}
+
+	return resp
+}
+
+// RedactParagraphs returns a copy of the paragraphs with the text and words
+// redacted.
+func (r *Redactor) RedactParagraphs(resp ParagraphsResponse) ParagraphsResponse {
+	paragraphs := make([]TranscriptParagraph, len(resp.Paragraphs))
+
+	for i, p := range resp.Paragraphs {
+		if p.Text != nil {
+			p.Text = String(r.RedactText(*p.Text))
+		}
+		p.Words, _ = r.redactWords(p.Words, nil)
+		paragraphs[i] = p
+	}
+
+	if resp.Paragraphs != nil {
+		resp.Paragraphs = paragraphs
+	}
+
+	return resp
+}
+
+// RedactText redacts a string.
+func (r *Redactor) RedactText(text string) string {
+	matches := r.matches(text)
+
+	var (
+		b    strings.Builder
+		last int
+	)
+
+	for _, m := range matches {
+		b.WriteString(text[last:m.start])
+		b.WriteString(r.replacement(m.rule, text[m.start:m.end], true))
+		last = m.end
+	}
+
+	b.WriteString(text[last:])
+
+	return b.String()
+}
+
+// redactString redacts an optional string.
+func (r *Redactor) redactString(s *string) *string {
+	if s == nil {
+		return nil
+	}
+	return String(r.RedactText(*s))
+}
+
+// textSpans returns the redactions in a text, all with the same time range and
+// speaker.
+func (r *Redactor) textSpans(text string, tr TimeRange, speaker string) []RedactedSpan {
+	var spans []RedactedSpan
+
+	for _, m := range r.matches(text) {
+		spans = append(spans, RedactedSpan{
+			TimeRange:   tr,
+			Rule:        m.rule.Name,
+			Text:        text[m.start:m.end],
+			Replacement: r.replacement(m.rule, text[m.start:m.end], true),
+			Speaker:     speaker,
+		})
+	}
+
+	return spans
+}
+
+// redactWords redacts a sequence of words as if they were a single text, so
+// that matches can span multiple words, and appends the redactions to spans.
+// With [SubstitutionPolicyEntityName], the words of a match are collapsed into
+// the first one, which keeps the start time of the first word and the end
+// time of the last.
+func (r *Redactor) redactWords(words []TranscriptWord, spans []RedactedSpan) ([]TranscriptWord, []RedactedSpan) {
+	if words == nil {
+		return nil, spans
+	}
+
+	offsets := make([]int, len(words))
+
+	var b strings.Builder
+
+	for i, w := range words {
+		if i > 0 {
+			b.WriteString(" ")
+		}
+		offsets[i] = b.Len()
+		b.WriteString(ToString(w.Text))
+	}
+
+	text := b.String()
+	matches := r.matches(text)
+
+	result := make([]TranscriptWord, 0, len(words))
+
+	var (
+		next int
+
+		// Index of the last match added to spans.
+		reported = -1
+	)
+
+	for i, w := range words {
+		if next >= len(matches) {
+			result = append(result, w)
+			continue
+		}
+
+		wordStart := offsets[i]
+		wordEnd := wordStart + len(ToString(w.Text))
+
+		var (
+			redacted  strings.Builder
+			pos       = wordStart
+			collapsed bool
+		)
+
+		for j := next; j < len(matches) && matches[j].start < wordEnd; j++ {
+			m := matches[j]
+
+			if m.end <= wordStart {
+				continue
+			}
+
+			from, to := m.start, m.end
+			if from < wordStart {
+				from = wordStart
+			}
+			if to > wordEnd {
+				to = wordEnd
+			}
+
+			isFirst := j != reported
+			reported = j
+
+			if !isFirst && r.Substitution == SubstitutionPolicyEntityName {
+				collapsed = true
+			}
+
+			redacted.WriteString(text[pos:from])
+			redacted.WriteString(r.replacement(m.rule, text[from:to], isFirst))
+			pos = to
+
+			if isFirst {
+				spans = append(spans, RedactedSpan{
+					TimeRange:   TimeRange{Start: toDuration(w.Start), End: toDuration(w.End)},
+					Rule:        m.rule.Name,
+					Text:        text[m.start:m.end],
+					Replacement: r.replacement(m.rule, text[m.start:m.end], true),
+					Speaker:     ToString(w.Speaker),
+				})
+			} else {
+				spans[len(spans)-1].End = toDuration(w.End)
+			}
+		}
+
+		redacted.WriteString(text[pos:wordEnd])
+
+		for next < len(matches) && matches[next].end <= wordEnd {
+			next++
+		}
+
+		if collapsed && len(result) > 0 {
+			// The word continues an entity that was replaced in an earlier
+			// word, so whatever is left of it belongs to that word.
+			prev := &result[len(result)-1]
+			prev.Text = String(ToString(prev.Text) + redacted.String())
+			prev.End = w.End
+			continue
+		}
+
+		w.Text = String(redacted.String())
+		result = append(result, w)
+	}
+
+	return result, spans
+}
+
+// matches returns the non-overlapping matches of all rules in text, ordered by
+// position.
+func (r *Redactor) matches(text string) []redactionMatch {
+	r.once.Do(func() {
+		r.regexps = make([][]*regexp.Regexp, len(r.Rules))
+		for i := range r.Rules {
+			r.regexps[i] = r.Rules[i].regexps()
+		}
+	})
+
+	var all []redactionMatch
+
+	for i := range r.Rules {
+		rule := &r.Rules[i]
+
+		for _, re := range r.regexps[i] {
+			for _, loc := range re.FindAllStringIndex(text, -1) {
+				if loc[0] < loc[1] {
+					all = append(all, redactionMatch{start: loc[0], end: loc[1], rule: rule})
+				}
+			}
+		}
+	}
+
+	// Sorting by position is stable, so the earlier rule wins when two
+	// matches start at the same position.
+	sort.SliceStable(all, func(i, j int) bool {
+		return all[i].start < all[j].start
+	})
+
+	var result []redactionMatch
+
+	for _, m := range all {
+		if n := len(result); n > 0 && m.start < result[n-1].end {
+			continue
+		}
+		result = append(result, m)
+	}
+
+	return result
+}
+
+func (r *Redactor) replacement(rule *RedactionRule, text string, first bool) string {
+	if r.Substitution == SubstitutionPolicyEntityName {
+		if !first {
+			return ""
+		}
+		return "[" + strings.ToUpper(rule.Name) + "]"
+	}
+
+	return strings.Map(func(c rune) rune {
+		if unicode.IsSpace(c) {
+			return c
+		}
+		return '#'
+	}, text)
+}
+
+func (rule *RedactionRule) regexps() []*regexp.Regexp {
+	var res []*regexp.Regexp
+
+	if rule.Pattern != nil {
+		res = append(res, rule.Pattern)
+	}
+
+	if len(rule.Terms) > 0 {
+		quoted := make([]string, len(rule.Terms))
+		for i, term := range rule.Terms {
+			quoted[i] = regexp.QuoteMeta(term)
+
+			// Only require a word boundary where the term itself has one,
+			// since \b next to a symbol would need a word character on the
+			// other side.
+			if isWordByte(term, 0) {
+				quoted[i] = `\b` + quoted[i]
+			}
+			if isWordByte(term, len(term)-1) {
+				quoted[i] += `\b`
+			}
+		}
+
+		res = append(res, regexp.MustCompile(`(?i)(?:`+strings.Join(quoted, "|")+`)`))
+	}
+
+	return res
+}
+
+// isWordByte reports whether the byte at i of s is an ASCII word character,
+// as matched by \w.
+func isWordByte(s string, i int) bool {
+	if i < 0 || i >= len(s) {
+		return false
+	}
+
+	c := s[i]
+
+	return c == '_' || '0' <= c && c <= '9' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+}
+
+// Duration returns the total time of the redacted spans.
+func (r RedactionReport) Duration() time.Duration {
+	var d time.Duration
+	for _, s := range r.Spans {
+		d += s.Duration()
+	}
+	return d
+}
@@ -0,0 +1,206 @@
+package assemblyai
+
+import (
+	"encoding/json"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func wordTexts(words []TranscriptWord) string {
+	texts := make([]string, len(words))
+	for i, w := range words {
+		texts[i] = ToString(w.Text)
+	}
+	return strings.Join(texts, " ")
+}
+
+func TestRedactor_Redact(t *testing.T) {
+	t.Parallel()
+
+	transcript := readTranscript(t, "testdata/transcript/redaction.json")
+
+	redactor := &Redactor{
+		Rules: []RedactionRule{
+			{Name: "account_number", Pattern: regexp.MustCompile(`AC-\d+`)},
+			{Name: "codename", Terms: []string{"project falcon"}},
+		},
+	}
+
+	redacted, report := redactor.Redact(transcript)
+
+	want := "My account is ####### and I use ####### ###### daily."
+
+	require.Equal(t, want, *redacted.Text)
+	require.Equal(t, want, wordTexts(redacted.Words))
+	require.Equal(t, want, *redacted.Utterances[0].Text)
+	require.Equal(t, want, wordTexts(redacted.Utterances[0].Words))
+	require.Len(t, redacted.Words, len(transcript.Words))
+
+	require.Equal(t, []RedactedSpan{
+		{
+			TimeRange:   TimeRange{Start: 3 * time.Second, End: 3800 * time.Millisecond},
+			Rule:        "account_number",
+			Text:        "AC-1234",
+			Replacement: "#######",
+			Speaker:     "A",
+		},
+		{
+			TimeRange:   TimeRange{Start: 7 * time.Second, End: 8800 * time.Millisecond},
+			Rule:        "codename",
+			Text:        "Project Falcon",
+			Replacement: "####### ######",
+			Speaker:     "A",
+		},
+	}, report.Spans)
+
+	// The original transcript is left untouched.
+	require.Equal(t, "My account is AC-1234 and I use Project Falcon daily.", *transcript.Text)
+	require.Equal(t, "AC-1234", *transcript.Words[3].Text)
+}
+
+func TestRedactor_EntityName(t *testing.T) {
+	t.Parallel()
+
+	text := "Ask Project Falcon, not Project Heron."
+
+	redactor := &Redactor{
+		Substitution: SubstitutionPolicyEntityName,
+		Rules: []RedactionRule{
+			{Name: "codename", Pattern: regexp.MustCompile(`Project (Falcon|Heron)`)},
+		},
+	}
+
+	b, err := os.ReadFile("testdata/transcript/redaction-sentences.json")
+	require.NoError(t, err)
+
+	var resp SentencesResponse
+	require.NoError(t, json.Unmarshal(b, &resp))
+
+	sentences := redactor.RedactSentences(resp)
+
+	want := "Ask [CODENAME], not [CODENAME]."
+
+	require.Equal(t, want, *sentences.Sentences[0].Text)
+	require.Equal(t, want, wordTexts(sentences.Sentences[0].Words))
+
+	// Collapsed words keep the timestamps of the entity.
+	words := sentences.Sentences[0].Words
+	require.Len(t, words, 4)
+	require.Equal(t, int64(1000), *words[1].Start)
+	require.Equal(t, int64(2800), *words[1].End)
+
+	paragraphs := redactor.RedactParagraphs(ParagraphsResponse{
+		Paragraphs: []TranscriptParagraph{{Text: String(text)}},
+	})
+
+	require.Equal(t, want, *paragraphs.Paragraphs[0].Text)
+}
+
+func TestRedactor_TermBoundaries(t *testing.T) {
+	t.Parallel()
+
+	redactor := &Redactor{
+		Rules: []RedactionRule{
+			{Name: "term", Terms: []string{"+1 555", "C++", "cat"}},
+		},
+	}
+
+	require.Equal(t, "Call ## ### 0100 about ### and concatenate.", redactor.RedactText("Call +1 555 0100 about c++ and concatenate."))
+	require.Equal(t, "The ### sat.", redactor.RedactText("The cat sat."))
+}
+
+func TestRedactor_Precedence(t *testing.T) {
+	t.Parallel()
+
+	redactor := &Redactor{
+		Rules: []RedactionRule{
+			{Name: "number", Pattern: regexp.MustCompile(`\d{4}`)},
+			{Name: "account", Pattern: regexp.MustCompile(`AC-\d+`)},
+		},
+		Substitution: SubstitutionPolicyEntityName,
+	}
+
+	// The match that starts first wins, whatever the order of the rules.
+	require.Equal(t, "Account [ACCOUNT].", redactor.RedactText("Account AC-1234."))
+}
+
+func TestRedactor_ReportWithoutWords(t *testing.T) {
+	t.Parallel()
+
+	redactor := &Redactor{
+		Rules: []RedactionRule{
+			{Name: "account_number", Pattern: regexp.MustCompile(`AC-\d+`)},
+		},
+	}
+
+	_, report := redactor.Redact(Transcript{
+		Text: String("My account is AC-1234."),
+		Utterances: []TranscriptUtterance{
+			{Speaker: String("A"), Start: Int64(1000), End: Int64(3000), Text: String("My account is AC-1234.")},
+		},
+	})
+
+	require.Equal(t, []RedactedSpan{
+		{
+			TimeRange:   TimeRange{Start: time.Second, End: 3 * time.Second},
+			Rule:        "account_number",
+			Text:        "AC-1234",
+			Replacement: "#######",
+			Speaker:     "A",
+		},
+	}, report.Spans)
+
+	_, report = redactor.Redact(Transcript{Text: String("My account is AC-1234.")})
+
+	require.Equal(t, []RedactedSpan{
+		{Rule: "account_number", Text: "AC-1234", Replacement: "#######"},
+	}, report.Spans)
+}
+
+func TestRedactor_RedactFields(t *testing.T) {
+	t.Parallel()
+
+	transcript := readTranscript(t, "testdata/transcript/redaction-fields.json")
+
+	redactor := &Redactor{
+		Rules: []RedactionRule{
+			{Name: "account_number", Pattern: regexp.MustCompile(`AC-\d+`)},
+		},
+	}
+
+	redacted, _ := redactor.Redact(transcript)
+
+	for name, text := range map[string]*string{
+		"Text":                          redacted.Text,
+		"Summary":                       redacted.Summary,
+		"Chapters.Gist":                 redacted.Chapters[0].Gist,
+		"Chapters.Headline":             redacted.Chapters[0].Headline,
+		"Chapters.Summary":              redacted.Chapters[0].Summary,
+		"Entities.Text":                 redacted.Entities[0].Text,
+		"AutoHighlightsResult.Text":     redacted.AutoHighlightsResult.Results[0].Text,
+		"ContentSafetyLabels.Text":      redacted.ContentSafetyLabels.Results[0].Text,
+		"IABCategoriesResult.Text":      redacted.IABCategoriesResult.Results[0].Text,
+		"SentimentAnalysisResults.Text": redacted.SentimentAnalysisResults[0].Text,
+	} {
+		text := text
+
+		t.Run(name, func(t *testing.T) {
+			require.NotNil(t, text)
+			require.NotContains(t, *text, "AC-1234")
+			require.Contains(t, *text, "#######")
+		})
+	}
+
+	// Unknown fields can't be redacted, so they're dropped.
+	require.Contains(t, transcript.Extra, "account_note")
+	require.Nil(t, redacted.Extra)
+
+	// The original transcript is left untouched.
+	require.Equal(t, "AC-1234", *transcript.Entities[0].Text)
+	require.Equal(t, "Account AC-1234", *transcript.Chapters[0].Gist)
+}
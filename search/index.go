@@ -0,0 +1,459 @@
+// Package search provides a local full-text index over AssemblyAI
+// transcripts.
+//
+// Unlike [assemblyai.TranscriptService.WordSearch], which searches a single
+// transcript through the API, an [Index] answers phrase, prefix and boolean
+// queries across any number of transcripts without a network round trip.
+package search
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/AssemblyAI/assemblyai-go-sdk"
+)
+
+// DefaultContextWords is the number of words on each side of a match that are
+// included in the snippet of a [Hit].
+var DefaultContextWords = 5
+
+// ErrMissingID is returned when adding a transcript without an ID.
+var ErrMissingID = errors.New("transcript has no ID")
+
+// Word is an indexed word of a transcript.
+type Word struct {
+	Text    string `json:"text"`
+	Start   int64  `json:"start"`
+	End     int64  `json:"end"`
+	Speaker string `json:"speaker,omitempty"`
+	Channel string `json:"channel,omitempty"`
+}
+
+// document is the indexed representation of a single transcript.
+type document struct {
+	ID    string `json:"id"`
+	Words []Word `json:"words"`
+
+	tokens []string
+}
+
+// Hit is a single match of a query.
+type Hit struct {
+	// The ID of the transcript that matched.
+	TranscriptID string
+
+	// Position of the first matched word in the transcript.
+	Index int
+
+	// Time range of the matched words.
+	Start time.Duration
+	End   time.Duration
+
+	// Speaker of the first matched word, if speaker diarization was enabled.
+	Speaker string
+
+	// The matched words with some surrounding context.
+	Snippet string
+}
+
+// SearchOptions configures a search.
+type SearchOptions struct {
+	// Maximum number of hits to return. Zero means no limit.
+	Limit int
+
+	// Only return hits spoken by this speaker.
+	Speaker string
+
+	// Number of words on each side of a match to include in the snippet.
+	// Defaults to [DefaultContextWords].
+	ContextWords int
+}
+
+// Index is an inverted index of transcript words. It's safe for concurrent
+// use.
+type Index struct {
+	mtx sync.RWMutex
+
+	docs map[string]*document
+
+	// postings maps a normalized token to the positions where it occurs in
+	// each transcript.
+	postings map[string]map[string][]int
+}
+
+// NewIndex returns an empty index.
+func NewIndex() *Index {
+	return &Index{
+		docs:     make(map[string]*document),
+		postings: make(map[string]map[string][]int),
+	}
+}
+
+// Open loads an index previously written with [Index.Save]. If the file
+// doesn't exist, Open returns an empty index.
+func Open(path string) (*Index, error) {
+	idx := NewIndex()
+
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return idx, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var docs []*document
+
+	if err := json.NewDecoder(f).Decode(&docs); err != nil {
+		return nil, err
+	}
+
+	for _, doc := range docs {
+		idx.add(doc)
+	}
+
+	return idx, nil
+}
+
+// Save writes the index to path. The file is replaced atomically so that a
+// crash never leaves a partially written index behind.
+func (idx *Index) Save(path string) error {
+	idx.mtx.RLock()
+	defer idx.mtx.RUnlock()
+
+	docs := make([]*document, 0, len(idx.docs))
+	for _, doc := range idx.docs {
+		docs = append(docs, doc)
+	}
+
+	sort.Slice(docs, func(i, j int) bool {
+		return docs[i].ID < docs[j].ID
+	})
+
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	if err := json.NewEncoder(f).Encode(docs); err != nil {
+		f.Close()
+		return err
+	}
+
+	// The data must be on disk before the rename is.
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	if err := os.Rename(f.Name(), path); err != nil {
+		return err
+	}
+
+	return syncDir(filepath.Dir(path))
+}
+
+// syncDir flushes the entries of a directory to disk, so that a rename within
+// it survives a crash.
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+
+	return d.Sync()
+}
+
+// Add indexes the words of a transcript, replacing any earlier version of the
+// same transcript.
+func (idx *Index) Add(transcript assemblyai.Transcript) error {
+	id := assemblyai.ToString(transcript.ID)
+	if id == "" {
+		return ErrMissingID
+	}
+
+	doc := &document{ID: id}
+
+	for _, w := range transcript.Words {
+		doc.Words = append(doc.Words, Word{
+			Text:    assemblyai.ToString(w.Text),
+			Start:   assemblyai.ToInt64(w.Start),
+			End:     assemblyai.ToInt64(w.End),
+			Speaker: assemblyai.ToString(w.Speaker),
+			Channel: assemblyai.ToString(w.Channel),
+		})
+	}
+
+	idx.mtx.Lock()
+	defer idx.mtx.Unlock()
+
+	idx.remove(id)
+	idx.add(doc)
+
+	return nil
+}
+
+// Remove deletes a transcript from the index.
+func (idx *Index) Remove(transcriptID string) {
+	idx.mtx.Lock()
+	defer idx.mtx.Unlock()
+
+	idx.remove(transcriptID)
+}
+
+// Len returns the number of indexed transcripts.
+func (idx *Index) Len() int {
+	idx.mtx.RLock()
+	defer idx.mtx.RUnlock()
+
+	return len(idx.docs)
+}
+
+// Search returns the hits for a query, ordered by transcript ID and time.
+//
+// A query is a list of terms that must all match. Terms can be single words,
+// quoted phrases such as "air quality", or prefixes such as wild*. Prefix a
+// term with - or NOT to exclude transcripts that contain it, and separate
+// groups of terms with OR to match any of them.
+func (idx *Index) Search(q string, opts *SearchOptions) ([]Hit, error) {
+	query, err := parseQuery(q)
+	if err != nil {
+		return nil, err
+	}
+
+	var options SearchOptions
+
+	if opts != nil {
+		options = *opts
+	}
+
+	if options.ContextWords <= 0 {
+		options.ContextWords = DefaultContextWords
+	}
+
+	idx.mtx.RLock()
+	defer idx.mtx.RUnlock()
+
+	type span struct {
+		doc        string
+		start, end int
+	}
+
+	matched := make(map[span]bool)
+
+	for _, group := range query {
+		for id, spans := range idx.matchGroup(group) {
+			for _, s := range spans {
+				matched[span{doc: id, start: s[0], end: s[1]}] = true
+			}
+		}
+	}
+
+	var hits []Hit
+
+	for s := range matched {
+		doc := idx.docs[s.doc]
+		first, last := doc.Words[s.start], doc.Words[s.end-1]
+
+		if options.Speaker != "" && first.Speaker != options.Speaker {
+			continue
+		}
+
+		hits = append(hits, Hit{
+			TranscriptID: s.doc,
+			Index:        s.start,
+			Start:        time.Duration(first.Start) * time.Millisecond,
+			End:          time.Duration(last.End) * time.Millisecond,
+			Speaker:      first.Speaker,
+			Snippet:      doc.snippet(s.start, s.end, options.ContextWords),
+		})
+	}
+
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].TranscriptID != hits[j].TranscriptID {
+			return hits[i].TranscriptID < hits[j].TranscriptID
+		}
+		return hits[i].Index < hits[j].Index
+	})
+
+	if options.Limit > 0 && len(hits) > options.Limit {
+		hits = hits[:options.Limit]
+	}
+
+	return hits, nil
+}
+
+// matchGroup returns the matched word spans for each transcript that satisfies
+// every term in the group.
+func (idx *Index) matchGroup(group []term) map[string][][2]int {
+	var result map[string][][2]int
+
+	for _, t := range group {
+		if t.negate {
+			continue
+		}
+
+		spans := idx.matchTerm(t)
+
+		if result == nil {
+			result = spans
+			continue
+		}
+
+		for id := range result {
+			if _, ok := spans[id]; !ok {
+				delete(result, id)
+				continue
+			}
+			result[id] = append(result[id], spans[id]...)
+		}
+	}
+
+	for _, t := range group {
+		if !t.negate {
+			continue
+		}
+
+		for id := range idx.matchTerm(t) {
+			delete(result, id)
+		}
+	}
+
+	return result
+}
+
+// matchTerm returns the matched word spans of a single term per transcript.
+func (idx *Index) matchTerm(t term) map[string][][2]int {
+	result := make(map[string][][2]int)
+
+	for id, positions := range idx.positions(t.words[0], t.prefix && len(t.words) == 1) {
+		doc := idx.docs[id]
+
+	next:
+		for _, pos := range positions {
+			if pos+len(t.words) > len(doc.tokens) {
+				continue
+			}
+
+			for i, w := range t.words[1:] {
+				tok := doc.tokens[pos+i+1]
+
+				isLast := i == len(t.words)-2
+				if tok != w && !(isLast && t.prefix && strings.HasPrefix(tok, w)) {
+					continue next
+				}
+			}
+
+			result[id] = append(result[id], [2]int{pos, pos + len(t.words)})
+		}
+	}
+
+	return result
+}
+
+// positions returns the positions of a token per transcript.
+func (idx *Index) positions(token string, prefix bool) map[string][]int {
+	if !prefix {
+		return idx.postings[token]
+	}
+
+	result := make(map[string][]int)
+
+	for tok, docs := range idx.postings {
+		if !strings.HasPrefix(tok, token) {
+			continue
+		}
+
+		for id, positions := range docs {
+			result[id] = append(result[id], positions...)
+		}
+	}
+
+	for id := range result {
+		sort.Ints(result[id])
+	}
+
+	return result
+}
+
+func (idx *Index) add(doc *document) {
+	doc.tokens = make([]string, len(doc.Words))
+
+	for i, w := range doc.Words {
+		tok := normalize(w.Text)
+		doc.tokens[i] = tok
+
+		if tok == "" {
+			continue
+		}
+
+		docs, ok := idx.postings[tok]
+		if !ok {
+			docs = make(map[string][]int)
+			idx.postings[tok] = docs
+		}
+
+		docs[doc.ID] = append(docs[doc.ID], i)
+	}
+
+	idx.docs[doc.ID] = doc
+}
+
+func (idx *Index) remove(id string) {
+	doc, ok := idx.docs[id]
+	if !ok {
+		return
+	}
+
+	for _, tok := range doc.tokens {
+		if docs, ok := idx.postings[tok]; ok {
+			delete(docs, id)
+
+			if len(docs) == 0 {
+				delete(idx.postings, tok)
+			}
+		}
+	}
+
+	delete(idx.docs, id)
+}
+
+func (doc *document) snippet(start, end, context int) string {
+	from := start - context
+	if from < 0 {
+		from = 0
+	}
+
+	to := end + context
+	if to > len(doc.Words) {
+		to = len(doc.Words)
+	}
+
+	words := make([]string, 0, to-from)
+	for _, w := range doc.Words[from:to] {
+		words = append(words, w.Text)
+	}
+
+	return strings.Join(words, " ")
+}
+
+// normalize lowercases a word and strips any surrounding punctuation.
+func normalize(s string) string {
+	return strings.ToLower(strings.TrimFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}))
+}
@@ -0,0 +1,99 @@
+package search
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/AssemblyAI/assemblyai-go-sdk"
+	"github.com/stretchr/testify/require"
+)
+
+func loadTranscript(t *testing.T, filename string) assemblyai.Transcript {
+	t.Helper()
+
+	b, err := os.ReadFile(filepath.Join("../testdata/transcript", filename))
+	require.NoError(t, err)
+
+	var transcript assemblyai.Transcript
+	require.NoError(t, json.Unmarshal(b, &transcript))
+
+	return transcript
+}
+
+func TestIndex_Search(t *testing.T) {
+	t.Parallel()
+
+	idx := NewIndex()
+
+	require.NoError(t, idx.Add(loadTranscript(t, "completed.json")))
+	require.NoError(t, idx.Add(loadTranscript(t, "search/denver.json")))
+
+	hits, err := idx.Search(`"air quality" -denver`, nil)
+	require.NoError(t, err)
+	require.NotEmpty(t, hits)
+
+	for _, hit := range hits {
+		require.Equal(t, "TRANSCRIPT_ID", hit.TranscriptID)
+		require.Contains(t, strings.ToLower(hit.Snippet), "air quality")
+	}
+
+	require.Equal(t, 9, hits[0].Index)
+	require.Equal(t, 3978*time.Millisecond, hits[0].Start)
+	require.Equal(t, "wildfires in Canada is triggering air quality alerts throughout the US. Skylines", hits[0].Snippet)
+
+	hits, err = idx.Search("denver OR hopkins", nil)
+	require.NoError(t, err)
+	require.Len(t, hits, 3)
+	require.Equal(t, "OTHER", hits[0].TranscriptID)
+
+	hits, err = idx.Search("wildfire*", &SearchOptions{Limit: 2, ContextWords: 1})
+	require.NoError(t, err)
+	require.Len(t, hits, 2)
+	require.Equal(t, "of wildfires in", hits[0].Snippet)
+
+	hits, err = idx.Search("air", &SearchOptions{Speaker: "A"})
+	require.NoError(t, err)
+	require.Len(t, hits, 1)
+
+	_, err = idx.Search("-denver", nil)
+	require.ErrorIs(t, err, ErrInvalidQuery)
+
+	_, err = idx.Search(`"air quality`, nil)
+	require.ErrorIs(t, err, ErrInvalidQuery)
+}
+
+func TestIndex_Persistence(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "index.json")
+
+	idx, err := Open(path)
+	require.NoError(t, err)
+	require.Equal(t, 0, idx.Len())
+
+	require.NoError(t, idx.Add(loadTranscript(t, "search/hello-world.json")))
+	require.NoError(t, idx.Add(loadTranscript(t, "search/goodbye-world.json")))
+	require.NoError(t, idx.Add(loadTranscript(t, "search/hello-again.json")))
+	require.ErrorIs(t, idx.Add(assemblyai.Transcript{}), ErrMissingID)
+
+	require.NoError(t, idx.Save(path))
+
+	idx, err = Open(path)
+	require.NoError(t, err)
+	require.Equal(t, 2, idx.Len())
+
+	hits, err := idx.Search("world", nil)
+	require.NoError(t, err)
+	require.Len(t, hits, 1)
+	require.Equal(t, "B", hits[0].TranscriptID)
+
+	idx.Remove("B")
+
+	hits, err = idx.Search("world", nil)
+	require.NoError(t, err)
+	require.Empty(t, hits)
+}
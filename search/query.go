@@ -0,0 +1,136 @@
+package search
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// ErrInvalidQuery is returned when a query can't be parsed.
+var ErrInvalidQuery = errors.New("invalid query")
+
+// term is a word, phrase or prefix to match.
+type term struct {
+	// Normalized words of the term. Holds more than one word for phrases.
+	words []string
+
+	// Whether the last word matches any word starting with it.
+	prefix bool
+
+	// Whether transcripts containing the term are excluded.
+	negate bool
+}
+
+// parseQuery parses a query into groups of terms. A transcript matches the
+// query if it matches every term of any group.
+func parseQuery(q string) ([][]term, error) {
+	tokens, err := splitQuery(q)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		groups [][]term
+		group  []term
+		negate bool
+	)
+
+	flush := func() error {
+		positive := false
+		for _, t := range group {
+			if !t.negate {
+				positive = true
+			}
+		}
+
+		if !positive {
+			return fmt.Errorf("%w: %q needs at least one term that isn't excluded", ErrInvalidQuery, q)
+		}
+
+		groups = append(groups, group)
+		group = nil
+
+		return nil
+	}
+
+	for _, tok := range tokens {
+		switch {
+		case tok == "OR":
+			if err := flush(); err != nil {
+				return nil, err
+			}
+			continue
+		case tok == "AND":
+			continue
+		case tok == "NOT":
+			negate = true
+			continue
+		}
+
+		if strings.HasPrefix(tok, "-") && len(tok) > 1 {
+			negate = true
+			tok = tok[1:]
+		}
+
+		t := term{negate: negate}
+		negate = false
+
+		if strings.HasSuffix(tok, "*") {
+			t.prefix = true
+			tok = strings.TrimSuffix(tok, "*")
+		}
+
+		for _, w := range strings.FieldsFunc(strings.Trim(tok, `"`), unicode.IsSpace) {
+			if w = normalize(w); w != "" {
+				t.words = append(t.words, w)
+			}
+		}
+
+		if len(t.words) == 0 {
+			continue
+		}
+
+		group = append(group, t)
+	}
+
+	if err := flush(); err != nil {
+		return nil, err
+	}
+
+	return groups, nil
+}
+
+// splitQuery splits a query on whitespace, keeping quoted phrases together.
+func splitQuery(q string) ([]string, error) {
+	var (
+		tokens []string
+		buf    strings.Builder
+		quoted bool
+	)
+
+	for _, r := range q {
+		switch {
+		case r == '"':
+			quoted = !quoted
+			buf.WriteRune(r)
+		case unicode.IsSpace(r) && !quoted:
+			if buf.Len() > 0 {
+				tokens = append(tokens, buf.String())
+				buf.Reset()
+			}
+		default:
+			buf.WriteRune(r)
+		}
+	}
+
+	if quoted {
+		return nil, fmt.Errorf("%w: unterminated phrase in %q", ErrInvalidQuery, q)
+	}
+
+	if buf.Len() > 0 {
+		tokens = append(tokens, buf.String())
+	}
+
+	return tokens, nil
+}
@@ -0,0 +1,304 @@
+package assemblyai
+
+import (
+	"sort"
+	"time"
+)
+
+// Defaults for [Transcript.SentimentTimeline].
+var (
+	DefaultSentimentWindow         = 30 * time.Second
+	DefaultSentimentShiftThreshold = 0.5
+)
+
+// SentimentOptions configures [Transcript.SentimentTimeline].
+type SentimentOptions struct {
+	// Size of the windows in [SentimentTimeline.Windows]. Defaults to
+	// [DefaultSentimentWindow].
+	Window time.Duration
+
+	// Group results by channel instead of by speaker.
+	ByChannel bool
+
+	// Smallest change in score between two thirds of the conversation that
+	// counts as a shift. Defaults to [DefaultSentimentShiftThreshold].
+	ShiftThreshold float64
+}
+
+// SentimentScore summarizes a set of sentiment analysis results.
+type SentimentScore struct {
+	// Confidence-weighted mean of the results, where positive counts as 1,
+	// neutral as 0 and negative as -1.
+	Score float64
+
+	// Number of results by sentiment.
+	Positive int
+	Neutral  int
+	Negative int
+
+	weight float64
+	sum    float64
+}
+
+// Count returns the number of results in the score.
+func (s SentimentScore) Count() int {
+	return s.Positive + s.Neutral + s.Negative
+}
+
+// Sentiment returns the overall sentiment: positive if the score is above
+// 0.25, negative if it's below -0.25 and neutral otherwise, or when there are
+// no results.
+func (s SentimentScore) Sentiment() Sentiment {
+	switch {
+	case s.Count() == 0:
+		return SentimentNeutral
+	case s.Score > 0.25:
+		return SentimentPositive
+	case s.Score < -0.25:
+		return SentimentNegative
+	default:
+		return SentimentNeutral
+	}
+}
+
+func (s *SentimentScore) add(r SentimentAnalysisResult) {
+	var value float64
+
+	switch r.Sentiment {
+	case SentimentPositive:
+		s.Positive++
+		value = 1
+	case SentimentNegative:
+		s.Negative++
+		value = -1
+	case SentimentNeutral:
+		s.Neutral++
+	default:
+		return
+	}
+
+	confidence := ToFloat64(r.Confidence)
+	if r.Confidence == nil {
+		confidence = 1
+	}
+
+	s.weight += confidence
+	s.sum += confidence * value
+
+	if s.weight > 0 {
+		s.Score = s.sum / s.weight
+	}
+}
+
+// SentimentWindow is the sentiment over a fixed window of the conversation.
+type SentimentWindow struct {
+	TimeRange
+
+	// Sentiment of all results that start in the window.
+	Overall SentimentScore
+
+	// Sentiment per speaker or channel. Speakers without results in the
+	// window are omitted.
+	Speakers map[string]SentimentScore
+}
+
+// SentimentShift is a change in sentiment between consecutive thirds of a
+// conversation.
+type SentimentShift struct {
+	// Speaker label or channel, or empty for the whole conversation.
+	Speaker string
+
+	// The third of the conversation that the sentiment shifted to.
+	TimeRange
+
+	// Score before and after the shift.
+	From float64
+	To   float64
+}
+
+// Sentiment returns positive if the sentiment improved, and negative
+// otherwise.
+func (s SentimentShift) Sentiment() Sentiment {
+	if s.To > s.From {
+		return SentimentPositive
+	}
+	return SentimentNegative
+}
+
+// SentimentTimeline describes how sentiment evolves over a conversation.
+type SentimentTimeline struct {
+	// Length of the conversation: the duration of the audio, or the end of
+	// the last result if it's later, as in [ConversationStats].
+	Duration time.Duration
+
+	// Sentiment over fixed windows, from the start of the conversation.
+	Windows []SentimentWindow
+
+	// Sentiment of the whole conversation.
+	Overall SentimentScore
+
+	// Sentiment of the whole conversation per speaker or channel.
+	Speakers map[string]SentimentScore
+
+	// Sentiment in the first and last third of the conversation.
+	Opening SentimentScore
+	Closing SentimentScore
+
+	// Shifts between the opening, middle and closing thirds, for the whole
+	// conversation and for each speaker, sorted by time.
+	Shifts []SentimentShift
+}
+
+// SentimentTimeline buckets the sentiment analysis results of the transcript
+// over fixed windows, and detects how the sentiment shifts over the course of
+// the conversation.
+//
+// Results are assigned to the window and third in which they start.
+func (t Transcript) SentimentTimeline(opts *SentimentOptions) SentimentTimeline {
+	options := SentimentOptions{
+		Window:         DefaultSentimentWindow,
+		ShiftThreshold: DefaultSentimentShiftThreshold,
+	}
+
+	if opts != nil {
+		options.ByChannel = opts.ByChannel
+
+		if opts.Window > 0 {
+			options.Window = opts.Window
+		}
+
+		if opts.ShiftThreshold > 0 {
+			options.ShiftThreshold = opts.ShiftThreshold
+		}
+	}
+
+	timeline := SentimentTimeline{
+		Speakers: make(map[string]SentimentScore),
+	}
+
+	timeline.Duration = t.audioDuration()
+
+	for _, r := range t.SentimentAnalysisResults {
+		timeline.Duration = maxDuration(timeline.Duration, toDuration(r.End))
+	}
+
+	if len(t.SentimentAnalysisResults) == 0 {
+		return timeline
+	}
+
+	windows := int((timeline.Duration + options.Window - 1) / options.Window)
+	if windows == 0 {
+		windows = 1
+	}
+
+	for i := 0; i < windows; i++ {
+		timeline.Windows = append(timeline.Windows, SentimentWindow{
+			TimeRange: TimeRange{
+				Start: time.Duration(i) * options.Window,
+				End:   minDuration(time.Duration(i+1)*options.Window, timeline.Duration),
+			},
+			Speakers: make(map[string]SentimentScore),
+		})
+	}
+
+	third := timeline.Duration / 3
+
+	thirds := [3]TimeRange{
+		{Start: 0, End: third},
+		{Start: third, End: 2 * third},
+		{Start: 2 * third, End: timeline.Duration},
+	}
+
+	var overallThirds [3]SentimentScore
+
+	speakerThirds := make(map[string]*[3]SentimentScore)
+
+	for _, r := range t.SentimentAnalysisResults {
+		speaker := ToString(r.Speaker)
+		if options.ByChannel {
+			speaker = ToString(r.Channel)
+		}
+
+		start := toDuration(r.Start)
+
+		wi := int(start / options.Window)
+		if wi >= windows {
+			wi = windows - 1
+		}
+
+		w := &timeline.Windows[wi]
+		w.Overall.add(r)
+		addSentiment(w.Speakers, speaker, r)
+
+		timeline.Overall.add(r)
+		addSentiment(timeline.Speakers, speaker, r)
+
+		i := 0
+		for i < 2 && start >= thirds[i+1].Start {
+			i++
+		}
+
+		overallThirds[i].add(r)
+
+		if speakerThirds[speaker] == nil {
+			speakerThirds[speaker] = &[3]SentimentScore{}
+		}
+		speakerThirds[speaker][i].add(r)
+	}
+
+	timeline.Opening = overallThirds[0]
+	timeline.Closing = overallThirds[2]
+
+	timeline.Shifts = sentimentShifts("", overallThirds, thirds, options.ShiftThreshold)
+
+	for speaker, scores := range speakerThirds {
+		timeline.Shifts = append(timeline.Shifts, sentimentShifts(speaker, *scores, thirds, options.ShiftThreshold)...)
+	}
+
+	sort.Slice(timeline.Shifts, func(i, j int) bool {
+		si, sj := timeline.Shifts[i], timeline.Shifts[j]
+		if si.Start != sj.Start {
+			return si.Start < sj.Start
+		}
+		return si.Speaker < sj.Speaker
+	})
+
+	return timeline
+}
+
+func addSentiment(scores map[string]SentimentScore, speaker string, r SentimentAnalysisResult) {
+	score := scores[speaker]
+	score.add(r)
+	scores[speaker] = score
+}
+
+// sentimentShifts compares each third of the conversation that has results
+// with the previous third that has results.
+func sentimentShifts(speaker string, scores [3]SentimentScore, thirds [3]TimeRange, threshold float64) []SentimentShift {
+	var shifts []SentimentShift
+
+	prev := -1
+
+	for i, score := range scores {
+		if score.Count() == 0 {
+			continue
+		}
+
+		if prev >= 0 {
+			from := scores[prev].Score
+
+			if diff := score.Score - from; diff >= threshold || -diff >= threshold {
+				shifts = append(shifts, SentimentShift{
+					Speaker:   speaker,
+					TimeRange: thirds[i],
+					From:      from,
+					To:        score.Score,
+				})
+			}
+		}
+
+		prev = i
+	}
+
+	return shifts
+}
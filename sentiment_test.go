@@ -0,0 +1,70 @@
+package assemblyai
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTranscript_SentimentTimeline(t *testing.T) {
+	t.Parallel()
+
+	transcript := readTranscript(t, "testdata/transcript/sentiment.json")
+
+	timeline := transcript.SentimentTimeline(nil)
+
+	require.Equal(t, time.Minute, timeline.Duration)
+	require.Len(t, timeline.Windows, 2)
+	require.Equal(t, TimeRange{Start: 30 * time.Second, End: time.Minute}, timeline.Windows[1].TimeRange)
+	require.Equal(t, 4, timeline.Windows[0].Overall.Count())
+	require.Equal(t, 3, timeline.Windows[1].Speakers["B"].Negative+timeline.Windows[0].Speakers["B"].Negative)
+
+	// Confidence-weighted score of the first window: (0.9 + 0.6 - 0.5) / 2.8.
+	require.InDelta(t, 1/2.8, timeline.Windows[0].Overall.Score, 1e-9)
+
+	require.Equal(t, 8, timeline.Overall.Count())
+	require.Equal(t, SentimentNeutral, timeline.Speakers["A"].Sentiment())
+	require.Equal(t, SentimentNegative, timeline.Speakers["B"].Sentiment())
+
+	require.Equal(t, SentimentPositive, timeline.Opening.Sentiment())
+	require.Equal(t, SentimentNegative, timeline.Closing.Sentiment())
+	require.Equal(t, 2, timeline.Opening.Count())
+	require.Equal(t, 4, timeline.Closing.Count())
+
+	var shifts []string
+	for _, s := range timeline.Shifts {
+		shifts = append(shifts, s.Speaker+":"+string(s.Sentiment())+"@"+s.Start.String())
+	}
+
+	require.Equal(t, []string{
+		":NEGATIVE@20s",
+		"A:NEGATIVE@20s",
+		"B:NEGATIVE@20s",
+	}, shifts)
+
+	byChannel := transcript.SentimentTimeline(&SentimentOptions{ByChannel: true, Window: 10 * time.Second})
+	require.Len(t, byChannel.Windows, 6)
+	require.Contains(t, byChannel.Speakers, "1")
+	require.Contains(t, byChannel.Speakers, "2")
+	require.Equal(t, 4, byChannel.Speakers["2"].Count())
+
+	// The duration of the audio is used when it's known.
+	transcript.AudioDuration = Float64(90)
+
+	timeline = transcript.SentimentTimeline(nil)
+	require.Equal(t, 90*time.Second, timeline.Duration)
+	require.Equal(t, transcript.ConversationStats(nil).Duration, timeline.Duration)
+	require.Len(t, timeline.Windows, 3)
+}
+
+func TestTranscript_SentimentTimelineEmpty(t *testing.T) {
+	t.Parallel()
+
+	timeline := Transcript{}.SentimentTimeline(nil)
+
+	require.Zero(t, timeline.Duration)
+	require.Empty(t, timeline.Windows)
+	require.Empty(t, timeline.Shifts)
+	require.Equal(t, SentimentNeutral, timeline.Overall.Sentiment())
+}
@@ -0,0 +1,611 @@
+package assemblyai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrTranscriptNotFound is returned by a [Store] when a transcript hasn't
+	// been stored.
+	ErrTranscriptNotFound = errors.New("transcript not found")
+
+	// ErrMissingTranscriptID is returned by a [Store] when saving a
+	// transcript without an ID.
+	ErrMissingTranscriptID = errors.New("transcript has no ID")
+)
+
+// StoredTranscript is a transcript saved to a [Store], along with its
+// sentences, paragraphs and any client-side tags and metadata.
+type StoredTranscript struct {
+	Transcript Transcript `json:"transcript"`
+
+	Sentences  []TranscriptSentence  `json:"sentences,omitempty"`
+	Paragraphs []TranscriptParagraph `json:"paragraphs,omitempty"`
+
+	// When the transcript was created.
+	Created time.Time `json:"created"`
+
+	// Client-side tags and metadata. These are never sent to the API.
+	Tags     []string          `json:"tags,omitempty"`
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+// ID returns the ID of the stored transcript.
+func (st StoredTranscript) ID() string {
+	return ToString(st.Transcript.ID)
+}
+
+// HasTag returns true if the stored transcript has the given tag.
+func (st StoredTranscript) HasTag(tag string) bool {
+	for _, t := range st.Tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// StoreQuery filters stored transcripts. Zero values match any transcript.
+type StoreQuery struct {
+	Status       TranscriptStatus
+	LanguageCode TranscriptLanguageCode
+	SpeechModel  SpeechModel
+
+	// Only match transcripts created at or after this time.
+	CreatedAfter time.Time
+
+	// Only match transcripts created before this time.
+	CreatedBefore time.Time
+
+	// Only match transcripts with all of these tags.
+	Tags []string
+}
+
+// Match returns true if the stored transcript matches the query.
+func (q StoreQuery) Match(st StoredTranscript) bool {
+	switch {
+	case q.Status != "" && st.Transcript.Status != q.Status:
+		return false
+	case q.LanguageCode != "" && st.Transcript.LanguageCode != q.LanguageCode:
+		return false
+	case q.SpeechModel != "" && st.Transcript.SpeechModel != q.SpeechModel:
+		return false
+	case !q.CreatedAfter.IsZero() && st.Created.Before(q.CreatedAfter):
+		return false
+	case !q.CreatedBefore.IsZero() && !st.Created.Before(q.CreatedBefore):
+		return false
+	}
+
+	for _, tag := range q.Tags {
+		if !st.HasTag(tag) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Store persists transcripts locally, for example for offline analytics.
+type Store interface {
+	// Get returns a stored transcript, or [ErrTranscriptNotFound].
+	Get(ctx context.Context, transcriptID string) (StoredTranscript, error)
+
+	// Put saves a transcript, replacing any earlier version.
+	Put(ctx context.Context, st StoredTranscript) error
+
+	// Query returns the stored transcripts matching the query, from oldest to
+	// newest.
+	Query(ctx context.Context, q StoreQuery) ([]StoredTranscript, error)
+
+	// Cursor returns the ID of the transcript after which
+	// [TranscriptService.Sync] lists transcripts, or an empty string if the
+	// store has never been synced.
+	Cursor(ctx context.Context) (string, error)
+
+	// SetCursor saves the ID of the transcript after which the next sync
+	// lists transcripts.
+	SetCursor(ctx context.Context, transcriptID string) error
+}
+
+// FileStore is a [Store] that saves each transcript as a JSON file in a
+// directory.
+type FileStore struct {
+	dir string
+
+	mtx sync.Mutex
+
+	// index holds every stored transcript without its words, sentences and
+	// paragraphs, so that queries don't need to read every file.
+	index map[string]StoredTranscript
+
+	// Number of entries appended to the index log since the index was last
+	// saved.
+	logged int
+}
+
+// NewFileStore returns a store that saves transcripts in dir. The directory is
+// created if it doesn't exist.
+func NewFileStore(dir string) (*FileStore, error) {
+	if err := os.MkdirAll(filepath.Join(dir, "transcripts"), 0o755); err != nil {
+		return nil, err
+	}
+
+	s := &FileStore{
+		dir:   dir,
+		index: make(map[string]StoredTranscript),
+	}
+
+	b, err := os.ReadFile(s.indexPath())
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	if err == nil {
+		if err := json.Unmarshal(b, &s.index); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.replayLog(); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// Get returns a stored transcript.
+func (s *FileStore) Get(ctx context.Context, transcriptID string) (StoredTranscript, error) {
+	s.mtx.Lock()
+	_, ok := s.index[transcriptID]
+	s.mtx.Unlock()
+
+	if !ok {
+		return StoredTranscript{}, ErrTranscriptNotFound
+	}
+
+	return s.read(transcriptID)
+}
+
+// Put saves a transcript.
+func (s *FileStore) Put(ctx context.Context, st StoredTranscript) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	return s.put(st)
+}
+
+// put saves a transcript while holding the lock.
+func (s *FileStore) put(st StoredTranscript) error {
+	id := st.ID()
+	if id == "" {
+		return ErrMissingTranscriptID
+	}
+
+	b, err := json.Marshal(st)
+	if err != nil {
+		return err
+	}
+
+	if err := writeFileAtomic(s.transcriptPath(id), b); err != nil {
+		return err
+	}
+
+	s.index[id] = indexEntry(st)
+
+	return s.appendLog(s.index[id])
+}
+
+// Query returns the stored transcripts matching the query.
+func (s *FileStore) Query(ctx context.Context, q StoreQuery) ([]StoredTranscript, error) {
+	s.mtx.Lock()
+
+	var matches []StoredTranscript
+
+	for _, st := range s.index {
+		if q.Match(st) {
+			matches = append(matches, st)
+		}
+	}
+
+	s.mtx.Unlock()
+
+	sort.Slice(matches, func(i, j int) bool {
+		if !matches[i].Created.Equal(matches[j].Created) {
+			return matches[i].Created.Before(matches[j].Created)
+		}
+		return matches[i].ID() < matches[j].ID()
+	})
+
+	result := make([]StoredTranscript, 0, len(matches))
+
+	for _, m := range matches {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		st, err := s.read(m.ID())
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, st)
+	}
+
+	return result, nil
+}
+
+// Cursor returns the ID of the transcript after which the next sync lists
+// transcripts.
+func (s *FileStore) Cursor(ctx context.Context) (string, error) {
+	b, err := os.ReadFile(filepath.Join(s.dir, "cursor"))
+	if errors.Is(err, os.ErrNotExist) {
+		return "", nil
+	}
+	return string(b), err
+}
+
+// SetCursor saves the ID of the transcript after which the next sync lists
+// transcripts.
+func (s *FileStore) SetCursor(ctx context.Context, transcriptID string) error {
+	return writeFileAtomic(filepath.Join(s.dir, "cursor"), []byte(transcriptID))
+}
+
+// Tag adds tags and metadata to a stored transcript.
+func (s *FileStore) Tag(ctx context.Context, transcriptID string, tags []string, metadata map[string]string) error {
+	// Hold the lock from reading to writing, so that concurrent tags aren't
+	// lost.
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if _, ok := s.index[transcriptID]; !ok {
+		return ErrTranscriptNotFound
+	}
+
+	st, err := s.read(transcriptID)
+	if err != nil {
+		return err
+	}
+
+	for _, tag := range tags {
+		if !st.HasTag(tag) {
+			st.Tags = append(st.Tags, tag)
+		}
+	}
+
+	for k, v := range metadata {
+		if st.Metadata == nil {
+			st.Metadata = make(map[string]string)
+		}
+		st.Metadata[k] = v
+	}
+
+	return s.put(st)
+}
+
+func (s *FileStore) read(transcriptID string) (StoredTranscript, error) {
+	b, err := os.ReadFile(s.transcriptPath(transcriptID))
+	if errors.Is(err, os.ErrNotExist) {
+		return StoredTranscript{}, ErrTranscriptNotFound
+	}
+	if err != nil {
+		return StoredTranscript{}, err
+	}
+
+	var st StoredTranscript
+
+	if err := json.Unmarshal(b, &st); err != nil {
+		return StoredTranscript{}, err
+	}
+
+	return st, nil
+}
+
+// appendLog appends an index entry to the index log, so that a put doesn't
+// rewrite the whole index. The index is saved and the log truncated once the
+// log has as many entries as the index, which keeps puts amortized constant
+// time.
+func (s *FileStore) appendLog(entry StoredTranscript) error {
+	b, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(s.logPath(), os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+
+	if _, err := f.Write(append(b, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	s.logged++
+
+	if s.logged < len(s.index) {
+		return nil
+	}
+
+	return s.saveIndex()
+}
+
+// replayLog applies the entries of the index log to the index. A partially
+// written last entry, left by a crash, is ignored.
+func (s *FileStore) replayLog() error {
+	b, err := os.ReadFile(s.logPath())
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, line := range bytes.Split(b, []byte("\n")) {
+		if len(line) == 0 {
+			continue
+		}
+
+		var entry StoredTranscript
+
+		if err := json.Unmarshal(line, &entry); err != nil {
+			break
+		}
+
+		s.index[entry.ID()] = entry
+		s.logged++
+	}
+
+	return nil
+}
+
+// saveIndex writes the whole index and truncates the index log.
+func (s *FileStore) saveIndex() error {
+	b, err := json.Marshal(s.index)
+	if err != nil {
+		return err
+	}
+
+	if err := writeFileAtomic(s.indexPath(), b); err != nil {
+		return err
+	}
+
+	if err := os.Remove(s.logPath()); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	s.logged = 0
+
+	return nil
+}
+
+func (s *FileStore) indexPath() string {
+	return filepath.Join(s.dir, "index.json")
+}
+
+func (s *FileStore) logPath() string {
+	return filepath.Join(s.dir, "index.log")
+}
+
+func (s *FileStore) transcriptPath(transcriptID string) string {
+	return filepath.Join(s.dir, "transcripts", filepath.Base(transcriptID)+".json")
+}
+
+// indexEntry returns the fields of a stored transcript needed for queries.
+func indexEntry(st StoredTranscript) StoredTranscript {
+	return StoredTranscript{
+		Transcript: Transcript{
+			ID:           st.Transcript.ID,
+			Status:       st.Transcript.Status,
+			LanguageCode: st.Transcript.LanguageCode,
+			SpeechModel:  st.Transcript.SpeechModel,
+		},
+		Created:  st.Created,
+		Tags:     st.Tags,
+		Metadata: st.Metadata,
+	}
+}
+
+// writeFileAtomic replaces the file at path, so that readers never see a
+// partially written file.
+func writeFileAtomic(path string, data []byte) error {
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(f.Name(), path)
+}
+
+// SyncOptions configures [TranscriptService.Sync].
+type SyncOptions struct {
+	// Number of transcripts to list per request. Defaults to 100.
+	PageSize int64
+
+	// Don't fetch the sentences and paragraphs of each transcript.
+	SkipSentences  bool
+	SkipParagraphs bool
+
+	// Tags and metadata to attach to every newly synced transcript.
+	Tags     []string
+	Metadata map[string]string
+}
+
+// SyncResult describes the outcome of [TranscriptService.Sync].
+type SyncResult struct {
+	// IDs of the transcripts that were added to the store.
+	Added []string
+}
+
+// listCreatedLayout is the format of the created time of transcripts in
+// [TranscriptListItem].
+const listCreatedLayout = "2006-01-02T15:04:05.999999"
+
+// Sync saves completed transcripts that aren't in the store yet. The first
+// sync walks the entire transcript history. Later syncs page forward from the
+// cursor of the previous sync until they reach the newest transcript.
+//
+// The cursor never moves past a transcript that is still queued or
+// processing, so that it's stored by a later sync once it completes.
+// Transcripts that failed are never stored.
+func (s *TranscriptService) Sync(ctx context.Context, store Store, opts *SyncOptions) (SyncResult, error) {
+	var options SyncOptions
+
+	if opts != nil {
+		options = *opts
+	}
+
+	if options.PageSize <= 0 {
+		options.PageSize = 100
+	}
+
+	cursor, err := store.Cursor(ctx)
+	if err != nil {
+		return SyncResult{}, err
+	}
+
+	var (
+		result SyncResult
+
+		// Every listed transcript, from oldest to newest.
+		listed []TranscriptListItem
+	)
+
+	params := ListTranscriptParams{
+		Limit: Int64(options.PageSize),
+	}
+
+	if cursor != "" {
+		params.AfterID = String(cursor)
+	}
+
+	for {
+		list, err := s.List(ctx, params)
+		if err != nil {
+			return result, err
+		}
+
+		if len(list.Transcripts) == 0 {
+			break
+		}
+
+		// Items are sorted from newest to oldest.
+		page := make([]TranscriptListItem, len(list.Transcripts))
+		for i, item := range list.Transcripts {
+			page[len(page)-1-i] = item
+		}
+
+		for _, item := range list.Transcripts {
+			if item.Status != TranscriptStatusCompleted {
+				continue
+			}
+
+			added, err := s.syncItem(ctx, store, item, options)
+			if err != nil {
+				return result, err
+			}
+
+			if added {
+				result.Added = append(result.Added, ToString(item.ID))
+			}
+		}
+
+		if cursor != "" {
+			// Page forward from the cursor, through the newest transcript.
+			listed = append(listed, page...)
+			params.AfterID = page[len(page)-1].ID
+		} else {
+			// Walk back through the entire history.
+			listed = append(page, listed...)
+			params.BeforeID = page[0].ID
+		}
+	}
+
+	// Move the cursor to the newest transcript that is older than every
+	// unfinished one. If the oldest listed transcript is unfinished, the
+	// cursor stays where it was.
+	next := len(listed) - 1
+
+	for i, item := range listed {
+		if item.Status != TranscriptStatusCompleted && item.Status != TranscriptStatusError {
+			next = i - 1
+			break
+		}
+	}
+
+	if next >= 0 {
+		if err := store.SetCursor(ctx, ToString(listed[next].ID)); err != nil {
+			return result, err
+		}
+	}
+
+	return result, nil
+}
+
+func (s *TranscriptService) syncItem(ctx context.Context, store Store, item TranscriptListItem, options SyncOptions) (bool, error) {
+	id := ToString(item.ID)
+
+	if _, err := store.Get(ctx, id); err == nil {
+		return false, nil
+	} else if !errors.Is(err, ErrTranscriptNotFound) {
+		return false, err
+	}
+
+	transcript, err := s.Get(ctx, id)
+	if err != nil {
+		return false, err
+	}
+
+	st := StoredTranscript{
+		Transcript: transcript,
+		Tags:       options.Tags,
+		Metadata:   options.Metadata,
+	}
+
+	if created, err := time.Parse(listCreatedLayout, ToString(item.Created)); err == nil {
+		st.Created = created
+	}
+
+	if !options.SkipSentences {
+		sentences, err := s.GetSentences(ctx, id)
+		if err != nil {
+			return false, err
+		}
+		st.Sentences = sentences.Sentences
+	}
+
+	if !options.SkipParagraphs {
+		paragraphs, err := s.GetParagraphs(ctx, id)
+		if err != nil {
+			return false, err
+		}
+		st.Paragraphs = paragraphs.Paragraphs
+	}
+
+	if err := store.Put(ctx, st); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
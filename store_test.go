@@ -0,0 +1,241 @@
+package assemblyai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileStore(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	dir := t.TempDir()
+
+	store, err := NewFileStore(dir)
+	require.NoError(t, err)
+
+	_, err = store.Get(ctx, "missing")
+	require.ErrorIs(t, err, ErrTranscriptNotFound)
+
+	day := func(d int) time.Time {
+		return time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC)
+	}
+
+	for i, st := range []StoredTranscript{
+		{Transcript: Transcript{ID: String("a"), Status: TranscriptStatusCompleted, LanguageCode: "en_us", SpeechModel: SpeechModelBest}, Created: day(1)},
+		{Transcript: Transcript{ID: String("b"), Status: TranscriptStatusCompleted, LanguageCode: "de", SpeechModel: SpeechModelNano}, Created: day(2)},
+		{Transcript: Transcript{ID: String("c"), Status: TranscriptStatusError, LanguageCode: "en_us"}, Created: day(3)},
+	} {
+		st.Sentences = []TranscriptSentence{{Text: String(strconv.Itoa(i))}}
+		require.NoError(t, store.Put(ctx, st))
+	}
+
+	// Puts are appended to a log, which is folded into the index once it's
+	// as long as the index.
+	_, err = os.Stat(filepath.Join(dir, "index.log"))
+	require.NoError(t, err)
+
+	// Reopening the store replays the log.
+	store, err = NewFileStore(dir)
+	require.NoError(t, err)
+
+	require.NoError(t, store.Tag(ctx, "b", []string{"reviewed"}, map[string]string{"team": "support"}))
+
+	_, err = os.Stat(filepath.Join(dir, "index.log"))
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	// Reopen the store to check that everything was persisted.
+	store, err = NewFileStore(dir)
+	require.NoError(t, err)
+
+	st, err := store.Get(ctx, "b")
+	require.NoError(t, err)
+	require.Equal(t, []string{"reviewed"}, st.Tags)
+	require.Equal(t, "support", st.Metadata["team"])
+	require.Equal(t, "1", *st.Sentences[0].Text)
+
+	ids := func(q StoreQuery) []string {
+		results, err := store.Query(ctx, q)
+		require.NoError(t, err)
+
+		var ids []string
+		for _, r := range results {
+			ids = append(ids, r.ID())
+		}
+		return ids
+	}
+
+	require.Equal(t, []string{"a", "b", "c"}, ids(StoreQuery{}))
+	require.Equal(t, []string{"a", "b"}, ids(StoreQuery{Status: TranscriptStatusCompleted}))
+	require.Equal(t, []string{"a", "c"}, ids(StoreQuery{LanguageCode: "en_us"}))
+	require.Equal(t, []string{"b"}, ids(StoreQuery{SpeechModel: SpeechModelNano}))
+	require.Equal(t, []string{"b", "c"}, ids(StoreQuery{CreatedAfter: day(2)}))
+	require.Equal(t, []string{"a"}, ids(StoreQuery{CreatedBefore: day(2)}))
+	require.Equal(t, []string{"b"}, ids(StoreQuery{Tags: []string{"reviewed"}}))
+
+	require.ErrorIs(t, store.Put(ctx, StoredTranscript{}), ErrMissingTranscriptID)
+	require.ErrorIs(t, store.Tag(ctx, "missing", []string{"reviewed"}, nil), ErrTranscriptNotFound)
+
+	// Concurrent tags are all kept.
+	var wg sync.WaitGroup
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+			require.NoError(t, store.Tag(ctx, "a", []string{strconv.Itoa(i)}, nil))
+		}(i)
+	}
+
+	wg.Wait()
+
+	st, err = store.Get(ctx, "a")
+	require.NoError(t, err)
+	require.Len(t, st.Tags, 10)
+}
+
+func TestTranscripts_Sync(t *testing.T) {
+	t.Parallel()
+
+	client, handler, teardown := setup()
+	defer teardown()
+
+	var mtx sync.Mutex
+
+	// Transcripts from oldest to newest.
+	ids := []string{"T1", "T2", "T3", "T4"}
+
+	statuses := map[string]TranscriptStatus{
+		"T1": TranscriptStatusCompleted,
+		"T2": TranscriptStatusCompleted,
+		"T3": TranscriptStatusCompleted,
+		"T4": TranscriptStatusError,
+	}
+
+	handler.HandleFunc("/v2/transcript", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "GET", r.Method)
+		require.Empty(t, r.URL.Query().Get("status"))
+
+		mtx.Lock()
+		defer mtx.Unlock()
+
+		limit, err := strconv.Atoi(r.URL.Query().Get("limit"))
+		require.NoError(t, err)
+
+		var items []TranscriptListItem
+
+		// With after_id, the page holds the transcripts right after the
+		// cursor, from newest to oldest, like the API.
+		after := r.URL.Query().Get("after_id")
+
+		for i := range ids {
+			id := ids[len(ids)-1-i]
+			if after != "" {
+				id = ids[i]
+			}
+
+			if len(items) == limit {
+				break
+			}
+
+			if before := r.URL.Query().Get("before_id"); before != "" && id >= before {
+				continue
+			}
+
+			if after != "" && id <= after {
+				continue
+			}
+
+			items = append(items, TranscriptListItem{
+				ID:      String(id),
+				Status:  statuses[id],
+				Created: String(fmt.Sprintf("2024-01-0%sT10:00:00.000000", id[1:])),
+			})
+		}
+
+		if after != "" {
+			for i, j := 0, len(items)-1; i < j; i, j = i+1, j-1 {
+				items[i], items[j] = items[j], items[i]
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		require.NoError(t, json.NewEncoder(w).Encode(TranscriptList{Transcripts: items}))
+	})
+
+	handler.HandleFunc("/v2/transcript/", func(w http.ResponseWriter, r *http.Request) {
+		id := r.URL.Path[len("/v2/transcript/"):]
+
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case len(id) > 10 && id[len(id)-10:] == "/sentences":
+			fmt.Fprint(w, `{"sentences": [{"text": "Hello."}]}`)
+		case len(id) > 11 && id[len(id)-11:] == "/paragraphs":
+			fmt.Fprint(w, `{"paragraphs": [{"text": "Hello."}]}`)
+		default:
+			fmt.Fprintf(w, `{"id": %q, "status": "completed", "text": "Hello."}`, id)
+		}
+	})
+
+	ctx := context.Background()
+
+	store, err := NewFileStore(t.TempDir())
+	require.NoError(t, err)
+
+	result, err := client.Transcripts.Sync(ctx, store, &SyncOptions{PageSize: 2, Tags: []string{"synced"}})
+	require.NoError(t, err)
+	require.Equal(t, []string{"T3", "T2", "T1"}, result.Added)
+
+	cursor, err := store.Cursor(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "T4", cursor)
+
+	st, err := store.Get(ctx, "T2")
+	require.NoError(t, err)
+	require.Equal(t, "Hello.", *st.Transcript.Text)
+	require.Equal(t, "Hello.", *st.Sentences[0].Text)
+	require.Equal(t, "Hello.", *st.Paragraphs[0].Text)
+	require.Equal(t, []string{"synced"}, st.Tags)
+	require.Equal(t, time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC), st.Created)
+
+	mtx.Lock()
+	ids = append(ids, "T5", "T6", "T7")
+	statuses["T5"] = TranscriptStatusCompleted
+	statuses["T6"] = TranscriptStatusProcessing
+	statuses["T7"] = TranscriptStatusCompleted
+	mtx.Unlock()
+
+	result, err = client.Transcripts.Sync(ctx, store, &SyncOptions{PageSize: 2})
+	require.NoError(t, err)
+	require.Equal(t, []string{"T5", "T7"}, result.Added)
+
+	// The cursor doesn't move past the unfinished transcript.
+	cursor, err = store.Cursor(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "T5", cursor)
+
+	mtx.Lock()
+	statuses["T6"] = TranscriptStatusCompleted
+	mtx.Unlock()
+
+	result, err = client.Transcripts.Sync(ctx, store, &SyncOptions{PageSize: 2})
+	require.NoError(t, err)
+	require.Equal(t, []string{"T6"}, result.Added)
+
+	cursor, err = store.Cursor(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "T7", cursor)
+}
@@ -0,0 +1,562 @@
+package assemblyai
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// ErrInvalidSubtitles is returned when subtitles can't be parsed.
+var ErrInvalidSubtitles = errors.New("invalid subtitles")
+
+// Cue is a single caption in a set of subtitles.
+type Cue struct {
+	// Position of the cue within the subtitles, starting at 1.
+	Index int
+
+	// Optional cue identifier. Only used by WebVTT.
+	ID string
+
+	// Time when the cue appears.
+	Start time.Duration
+
+	// Time when the cue disappears.
+	End time.Duration
+
+	// Text of the cue, without any voice tag.
+	Text string
+
+	// Speaker of the cue, from a WebVTT voice tag such as <v Speaker A>.
+	Speaker string
+
+	// WebVTT cue settings, for example "align:start position:10%".
+	Settings string
+}
+
+// Duration returns how long the cue is displayed.
+func (c Cue) Duration() time.Duration {
+	return c.End - c.Start
+}
+
+// Note is a WebVTT NOTE block.
+type Note struct {
+	Text string
+
+	// Number of cues that precede the note. Notes before the first cue have a
+	// position of 0.
+	Position int
+}
+
+// Subtitles holds a parsed set of subtitle cues.
+type Subtitles struct {
+	// Text following the WEBVTT signature, including any header lines. The
+	// first line is the text on the line of the signature, which is empty if
+	// there is none, for example "- Title\nKind: captions" or
+	// "\nKind: captions".
+	Header string
+
+	// WebVTT NOTE blocks, in order.
+	Notes []Note
+
+	// The cues in display order.
+	Cues []Cue
+}
+
+// ParseSubtitles parses subtitles in the given format, for example the output
+// of [TranscriptService.GetSubtitles].
+func ParseSubtitles(data []byte, format SubtitleFormat) (Subtitles, error) {
+	switch format {
+	case SubtitleFormatSRT:
+		return ParseSRT(data)
+	case SubtitleFormatVTT:
+		return ParseVTT(data)
+	default:
+		return Subtitles{}, fmt.Errorf("unsupported subtitle format %q", format)
+	}
+}
+
+// ParseSRT parses SubRip subtitles.
+func ParseSRT(data []byte) (Subtitles, error) {
+	var subs Subtitles
+
+	for _, block := range subtitleBlocks(data) {
+		if len(block) < 2 {
+			return Subtitles{}, fmt.Errorf("%w: incomplete cue %q", ErrInvalidSubtitles, strings.Join(block, "\n"))
+		}
+
+		index, err := strconv.Atoi(strings.TrimSpace(block[0]))
+		if err != nil {
+			return Subtitles{}, fmt.Errorf("%w: invalid cue index %q", ErrInvalidSubtitles, block[0])
+		}
+
+		start, end, _, err := parseCueTiming(block[1])
+		if err != nil {
+			return Subtitles{}, err
+		}
+
+		subs.Cues = append(subs.Cues, Cue{
+			Index: index,
+			Start: start,
+			End:   end,
+			Text:  strings.Join(block[2:], "\n"),
+		})
+	}
+
+	return subs, nil
+}
+
+// ParseVTT parses WebVTT subtitles. STYLE and REGION blocks are ignored.
+func ParseVTT(data []byte) (Subtitles, error) {
+	blocks := subtitleBlocks(data)
+
+	if len(blocks) == 0 || !strings.HasPrefix(blocks[0][0], "WEBVTT") {
+		return Subtitles{}, fmt.Errorf("%w: missing WEBVTT signature", ErrInvalidSubtitles)
+	}
+
+	var subs Subtitles
+
+	header := blocks[0]
+	header[0] = strings.TrimSpace(strings.TrimPrefix(header[0], "WEBVTT"))
+	subs.Header = strings.TrimRightFunc(strings.Join(header, "\n"), unicode.IsSpace)
+
+	for _, block := range blocks[1:] {
+		switch {
+		case block[0] == "NOTE" || strings.HasPrefix(block[0], "NOTE ") || strings.HasPrefix(block[0], "NOTE\t"):
+			note := strings.TrimSpace(strings.TrimPrefix(block[0], "NOTE"))
+			lines := append([]string{note}, block[1:]...)
+			subs.Notes = append(subs.Notes, Note{
+				Text:     strings.TrimSpace(strings.Join(lines, "\n")),
+				Position: len(subs.Cues),
+			})
+			continue
+		case block[0] == "STYLE" || block[0] == "REGION":
+			continue
+		}
+
+		var id string
+
+		if !strings.Contains(block[0], "-->") {
+			id = block[0]
+			block = block[1:]
+		}
+
+		if len(block) == 0 {
+			return Subtitles{}, fmt.Errorf("%w: cue %q has no timing", ErrInvalidSubtitles, id)
+		}
+
+		start, end, settings, err := parseCueTiming(block[0])
+		if err != nil {
+			return Subtitles{}, err
+		}
+
+		speaker, text := parseVoiceTag(strings.Join(block[1:], "\n"))
+
+		subs.Cues = append(subs.Cues, Cue{
+			Index:    len(subs.Cues) + 1,
+			ID:       id,
+			Start:    start,
+			End:      end,
+			Text:     text,
+			Speaker:  speaker,
+			Settings: settings,
+		})
+	}
+
+	return subs, nil
+}
+
+// SRT serializes the subtitles to SubRip. Speakers and cue settings aren't
+// supported by the format and are left out.
+func (s Subtitles) SRT() []byte {
+	var buf bytes.Buffer
+
+	for i, cue := range s.Cues {
+		if i > 0 {
+			buf.WriteString("\n")
+		}
+
+		fmt.Fprintf(&buf, "%d\n%s --> %s\n%s\n", i+1, formatCueTime(cue.Start, ','), formatCueTime(cue.End, ','), cue.Text)
+	}
+
+	return buf.Bytes()
+}
+
+// VTT serializes the subtitles to WebVTT.
+func (s Subtitles) VTT() []byte {
+	var buf bytes.Buffer
+
+	buf.WriteString("WEBVTT")
+
+	first, rest, multiline := strings.Cut(s.Header, "\n")
+
+	if first != "" {
+		buf.WriteString(" ")
+		buf.WriteString(first)
+	}
+
+	if multiline {
+		buf.WriteString("\n")
+		buf.WriteString(rest)
+	}
+
+	buf.WriteString("\n")
+
+	notes := s.Notes
+
+	writeNotes := func(position int) {
+		for len(notes) > 0 && notes[0].Position <= position {
+			if strings.Contains(notes[0].Text, "\n") {
+				fmt.Fprintf(&buf, "\nNOTE\n%s\n", notes[0].Text)
+			} else {
+				fmt.Fprintf(&buf, "\nNOTE %s\n", notes[0].Text)
+			}
+			notes = notes[1:]
+		}
+	}
+
+	for i, cue := range s.Cues {
+		writeNotes(i)
+
+		buf.WriteString("\n")
+
+		if cue.ID != "" {
+			fmt.Fprintf(&buf, "%s\n", cue.ID)
+		}
+
+		fmt.Fprintf(&buf, "%s --> %s", formatCueTime(cue.Start, '.'), formatCueTime(cue.End, '.'))
+
+		if cue.Settings != "" {
+			fmt.Fprintf(&buf, " %s", cue.Settings)
+		}
+
+		buf.WriteString("\n")
+
+		if cue.Speaker != "" {
+			fmt.Fprintf(&buf, "<v %s>", cue.Speaker)
+		}
+
+		fmt.Fprintf(&buf, "%s\n", cue.Text)
+	}
+
+	writeNotes(len(s.Cues))
+
+	return buf.Bytes()
+}
+
+// Offset returns a copy of the subtitles with every cue shifted by d. Cues are
+// clamped to start no earlier than zero, and cues that would end before zero
+// are removed.
+func (s Subtitles) Offset(d time.Duration) Subtitles {
+	return s.transform(func(cue Cue) (Cue, bool) {
+		cue.Start += d
+		cue.End += d
+
+		if cue.End <= 0 {
+			return cue, false
+		}
+
+		if cue.Start < 0 {
+			cue.Start = 0
+		}
+
+		return cue, true
+	})
+}
+
+// Scale returns a copy of the subtitles with every timestamp multiplied by
+// factor, for example to convert between frame rates.
+func (s Subtitles) Scale(factor float64) Subtitles {
+	return s.transform(func(cue Cue) (Cue, bool) {
+		cue.Start = time.Duration(float64(cue.Start) * factor)
+		cue.End = time.Duration(float64(cue.End) * factor)
+		return cue, true
+	})
+}
+
+// Filter returns a copy of the subtitles containing only the cues for which
+// keep returns true.
+func (s Subtitles) Filter(keep func(Cue) bool) Subtitles {
+	return s.transform(func(cue Cue) (Cue, bool) {
+		return cue, keep(cue)
+	})
+}
+
+// Merge returns a copy of the subtitles where consecutive cues by the same
+// speaker are joined if the gap between them is at most maxGap and the joined
+// text is at most maxChars long. A maxChars of 0 means no limit.
+func (s Subtitles) Merge(maxGap time.Duration, maxChars int) Subtitles {
+	out := s.withCues(nil)
+	positions := make([]int, len(s.Cues))
+
+	for i, cue := range s.Cues {
+		positions[i] = len(out.Cues)
+
+		if n := len(out.Cues); n > 0 {
+			prev := &out.Cues[n-1]
+
+			text := prev.Text + " " + cue.Text
+			fits := maxChars <= 0 || len([]rune(text)) <= maxChars
+
+			if prev.Speaker == cue.Speaker && cue.Start-prev.End <= maxGap && fits {
+				prev.Text = text
+				prev.End = cue.End
+				continue
+			}
+		}
+
+		out.Cues = append(out.Cues, cue)
+	}
+
+	out.Notes = s.moveNotes(positions, len(out.Cues))
+	out.reindex()
+
+	return out
+}
+
+// Split returns a copy of the subtitles where cues longer than maxChars are
+// split on word boundaries. The display time of a split cue is divided between
+// the new cues in proportion to their length.
+func (s Subtitles) Split(maxChars int) Subtitles {
+	out := s.withCues(nil)
+	positions := make([]int, len(s.Cues))
+
+	for i, cue := range s.Cues {
+		positions[i] = len(out.Cues)
+
+		parts := splitCueText(cue.Text, maxChars)
+
+		if len(parts) < 2 {
+			out.Cues = append(out.Cues, cue)
+			continue
+		}
+
+		var total int
+		for _, part := range parts {
+			total += len([]rune(part))
+		}
+
+		start := cue.Start
+		var written int
+
+		for i, part := range parts {
+			written += len([]rune(part))
+
+			next := cue
+			next.ID = ""
+			next.Start = start
+			next.Text = part
+
+			if i == len(parts)-1 {
+				next.End = cue.End
+			} else {
+				next.End = cue.Start + time.Duration(float64(cue.Duration())*float64(written)/float64(total))
+			}
+
+			out.Cues = append(out.Cues, next)
+
+			start = next.End
+		}
+	}
+
+	out.Notes = s.moveNotes(positions, len(out.Cues))
+	out.reindex()
+
+	return out
+}
+
+func (s Subtitles) transform(fn func(Cue) (Cue, bool)) Subtitles {
+	out := s.withCues(nil)
+	positions := make([]int, len(s.Cues))
+
+	for i, cue := range s.Cues {
+		positions[i] = len(out.Cues)
+
+		if cue, ok := fn(cue); ok {
+			out.Cues = append(out.Cues, cue)
+		}
+	}
+
+	out.Notes = s.moveNotes(positions, len(out.Cues))
+	out.reindex()
+
+	return out
+}
+
+func (s Subtitles) withCues(cues []Cue) Subtitles {
+	return Subtitles{
+		Header: s.Header,
+		Cues:   cues,
+	}
+}
+
+// moveNotes returns the notes placed in a new set of cues, where positions
+// holds the number of new cues that precede each of the original cues.
+func (s Subtitles) moveNotes(positions []int, total int) []Note {
+	var notes []Note
+
+	for _, note := range s.Notes {
+		if note.Position < len(positions) {
+			note.Position = positions[note.Position]
+		} else {
+			note.Position = total
+		}
+
+		notes = append(notes, note)
+	}
+
+	return notes
+}
+
+func (s *Subtitles) reindex() {
+	for i := range s.Cues {
+		s.Cues[i].Index = i + 1
+	}
+}
+
+// subtitleBlocks splits data into blocks of non-empty lines separated by blank
+// lines.
+func subtitleBlocks(data []byte) [][]string {
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+
+	var (
+		blocks [][]string
+		block  []string
+	)
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+
+		if strings.TrimSpace(line) == "" {
+			if len(block) > 0 {
+				blocks = append(blocks, block)
+				block = nil
+			}
+			continue
+		}
+
+		block = append(block, line)
+	}
+
+	if len(block) > 0 {
+		blocks = append(blocks, block)
+	}
+
+	return blocks
+}
+
+func parseCueTiming(line string) (start, end time.Duration, settings string, err error) {
+	from, rest, ok := strings.Cut(line, "-->")
+	if !ok {
+		return 0, 0, "", fmt.Errorf("%w: invalid cue timing %q", ErrInvalidSubtitles, line)
+	}
+
+	fields := strings.Fields(rest)
+	if len(fields) == 0 {
+		return 0, 0, "", fmt.Errorf("%w: invalid cue timing %q", ErrInvalidSubtitles, line)
+	}
+
+	if start, err = parseCueTime(strings.TrimSpace(from)); err != nil {
+		return 0, 0, "", err
+	}
+
+	if end, err = parseCueTime(fields[0]); err != nil {
+		return 0, 0, "", err
+	}
+
+	return start, end, strings.Join(fields[1:], " "), nil
+}
+
+// parseCueTime parses timestamps such as 00:01:02,500 (SRT), 00:01:02.500 and
+// 01:02.500 (WebVTT).
+func parseCueTime(s string) (time.Duration, error) {
+	clock, frac, _ := strings.Cut(strings.Replace(s, ",", ".", 1), ".")
+
+	parts := strings.Split(clock, ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return 0, fmt.Errorf("%w: invalid timestamp %q", ErrInvalidSubtitles, s)
+	}
+
+	var d time.Duration
+
+	for _, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("%w: invalid timestamp %q", ErrInvalidSubtitles, s)
+		}
+		d = d*60 + time.Duration(n)*time.Second
+	}
+
+	if frac != "" {
+		ms, err := strconv.Atoi((frac + "00")[:3])
+		if err != nil || ms < 0 {
+			return 0, fmt.Errorf("%w: invalid timestamp %q", ErrInvalidSubtitles, s)
+		}
+		d += time.Duration(ms) * time.Millisecond
+	}
+
+	return d, nil
+}
+
+func formatCueTime(d time.Duration, sep byte) string {
+	if d < 0 {
+		d = 0
+	}
+
+	ms := d.Milliseconds()
+
+	return fmt.Sprintf("%02d:%02d:%02d%c%03d", ms/3_600_000, ms/60_000%60, ms/1000%60, sep, ms%1000)
+}
+
+var voiceTagRegexp = regexp.MustCompile(`^<v(?:\.[^\s>]+)*[ \t]+([^>]+)>`)
+
+// parseVoiceTag extracts the speaker from a WebVTT voice tag wrapping the
+// text.
+func parseVoiceTag(text string) (speaker, rest string) {
+	m := voiceTagRegexp.FindStringSubmatch(text)
+	if m == nil {
+		return "", text
+	}
+
+	rest = strings.TrimPrefix(text, m[0])
+	rest = strings.TrimSuffix(rest, "</v>")
+
+	return strings.TrimSpace(m[1]), rest
+}
+
+func splitCueText(text string, maxChars int) []string {
+	if maxChars <= 0 || len([]rune(text)) <= maxChars {
+		return []string{text}
+	}
+
+	var (
+		parts []string
+		line  string
+	)
+
+	for _, word := range strings.Fields(text) {
+		if line != "" && len([]rune(line))+1+len([]rune(word)) > maxChars {
+			parts = append(parts, line)
+			line = ""
+		}
+
+		if line == "" {
+			line = word
+		} else {
+			line += " " + word
+		}
+	}
+
+	if line != "" {
+		parts = append(parts, line)
+	}
+
+	return parts
+}
@@ -0,0 +1,140 @@
+package assemblyai
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubtitles_ParseSRT(t *testing.T) {
+	t.Parallel()
+
+	b, err := os.ReadFile("testdata/transcript/subtitles.srt")
+	require.NoError(t, err)
+
+	subs, err := ParseSubtitles(b, SubtitleFormatSRT)
+	require.NoError(t, err)
+
+	require.NotEmpty(t, subs.Cues)
+	require.Equal(t, Cue{
+		Index: 1,
+		Start: 5400 * time.Millisecond,
+		End:   12550 * time.Millisecond,
+		Text:  "Runner's knee runner's",
+	}, subs.Cues[0])
+
+	// Round-trip back to SRT.
+	again, err := ParseSRT(subs.SRT())
+	require.NoError(t, err)
+	require.Equal(t, subs, again)
+}
+
+func TestSubtitles_ParseVTT(t *testing.T) {
+	t.Parallel()
+
+	vtt := "\xef\xbb\xbfWEBVTT - Wildfires\r\nKind: captions\r\n\r\n" +
+		"NOTE This is a comment\r\n\r\n" +
+		"STYLE\r\n::cue { color: yellow }\r\n\r\n" +
+		"intro\r\n00:01.000 --> 00:02.500 align:start position:10%\r\n<v.loud Speaker A>Smoke from hundreds\r\nof wildfires</v>\r\n\r\n" +
+		"NOTE Second line\r\n\r\n" +
+		"00:00:02.500 --> 00:00:04.000\r\nin Canada.\r\n"
+
+	subs, err := ParseVTT([]byte(vtt))
+	require.NoError(t, err)
+
+	require.Equal(t, "- Wildfires\nKind: captions", subs.Header)
+	require.Equal(t, []Note{{Text: "This is a comment"}, {Text: "Second line", Position: 1}}, subs.Notes)
+	require.Equal(t, []Cue{
+		{
+			Index:    1,
+			ID:       "intro",
+			Start:    time.Second,
+			End:      2500 * time.Millisecond,
+			Text:     "Smoke from hundreds\nof wildfires",
+			Speaker:  "Speaker A",
+			Settings: "align:start position:10%",
+		},
+		{
+			Index: 2,
+			Start: 2500 * time.Millisecond,
+			End:   4 * time.Second,
+			Text:  "in Canada.",
+		},
+	}, subs.Cues)
+
+	require.True(t, strings.HasPrefix(string(subs.VTT()), "WEBVTT - Wildfires\nKind: captions\n\n"))
+
+	again, err := ParseVTT(subs.VTT())
+	require.NoError(t, err)
+	require.Equal(t, subs, again)
+
+	// Notes stay next to the cues they precede.
+	filtered := subs.Filter(func(c Cue) bool { return c.ID != "intro" })
+	require.Equal(t, []Note{{Text: "This is a comment"}, {Text: "Second line"}}, filtered.Notes)
+
+	merged := subs.Merge(time.Second, 0)
+	require.Len(t, merged.Cues, 2)
+	require.Equal(t, subs.Notes, merged.Notes)
+
+	_, err = ParseVTT([]byte("1\n00:00:01,000 --> 00:00:02,000\nfoo\n"))
+	require.ErrorIs(t, err, ErrInvalidSubtitles)
+}
+
+func TestSubtitles_VTTHeader(t *testing.T) {
+	t.Parallel()
+
+	for _, vtt := range []string{
+		"WEBVTT\n\n00:00.000 --> 00:01.000\nHello.\n",
+		"WEBVTT - Title\n\n00:00.000 --> 00:01.000\nHello.\n",
+		"WEBVTT\nKind: captions\n\n00:00.000 --> 00:01.000\nHello.\n",
+	} {
+		subs, err := ParseVTT([]byte(vtt))
+		require.NoError(t, err)
+
+		header := strings.SplitN(vtt, "\n\n", 2)[0]
+		require.True(t, strings.HasPrefix(string(subs.VTT()), header+"\n\n"), vtt)
+	}
+}
+
+func TestSubtitles_Operations(t *testing.T) {
+	t.Parallel()
+
+	subs := Subtitles{
+		Cues: []Cue{
+			{Index: 1, Start: 0, End: time.Second, Text: "Good"},
+			{Index: 2, Start: 1100 * time.Millisecond, End: 2 * time.Second, Text: "morning,"},
+			{Index: 3, Start: 2 * time.Second, End: 4 * time.Second, Text: "professor.", Speaker: "B"},
+		},
+	}
+
+	merged := subs.Merge(200*time.Millisecond, 0)
+	require.Len(t, merged.Cues, 2)
+	require.Equal(t, "Good morning,", merged.Cues[0].Text)
+	require.Equal(t, 2*time.Second, merged.Cues[0].End)
+	require.Equal(t, 2, merged.Cues[1].Index)
+
+	split := merged.Split(5)
+	require.Len(t, split.Cues, 3)
+	require.Equal(t, "Good", split.Cues[0].Text)
+	require.Equal(t, "morning,", split.Cues[1].Text)
+	require.Equal(t, split.Cues[0].End, split.Cues[1].Start)
+	require.Equal(t, 2*time.Second, split.Cues[1].End)
+
+	shifted := subs.Offset(-1500 * time.Millisecond)
+	require.Len(t, shifted.Cues, 2)
+	require.Equal(t, time.Duration(0), shifted.Cues[0].Start)
+	require.Equal(t, 500*time.Millisecond, shifted.Cues[0].End)
+
+	scaled := subs.Scale(0.5)
+	require.Equal(t, 2*time.Second, scaled.Cues[2].End)
+
+	filtered := subs.Filter(func(c Cue) bool { return c.Speaker == "B" })
+	require.Len(t, filtered.Cues, 1)
+	require.Equal(t, 1, filtered.Cues[0].Index)
+
+	// Operations don't modify the original subtitles.
+	require.Len(t, subs.Cues, 3)
+}
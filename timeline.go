@@ -0,0 +1,205 @@
+package assemblyai
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// TimelineEventType describes the transcript result a [TimelineEvent] is
+// derived from.
+type TimelineEventType string
+
+const (
+	TimelineEventWord          TimelineEventType = "word"
+	TimelineEventUtterance     TimelineEventType = "utterance"
+	TimelineEventChapter       TimelineEventType = "chapter"
+	TimelineEventEntity        TimelineEventType = "entity"
+	TimelineEventSentiment     TimelineEventType = "sentiment"
+	TimelineEventContentSafety TimelineEventType = "content_safety"
+	TimelineEventTopic         TimelineEventType = "topic"
+	TimelineEventHighlight     TimelineEventType = "highlight"
+)
+
+// TimelineEvent is a single timed result of a transcript.
+type TimelineEvent struct {
+	TimeRange
+
+	Type TimelineEventType
+
+	// Text of the result. Holds the gist for chapters.
+	Text string
+
+	// Speaker of the result, if known.
+	Speaker string
+
+	// The result the event is derived from. One of TranscriptWord,
+	// TranscriptUtterance, Chapter, Entity, SentimentAnalysisResult,
+	// ContentSafetyLabelResult, TopicDetectionResult or AutoHighlightResult.
+	Value interface{}
+}
+
+// Moment describes what is happening at a given instant of a transcript.
+type Moment struct {
+	// Speaker talking at the instant, if known.
+	Speaker string
+
+	// Chapter containing the instant, if Auto Chapters was enabled.
+	Chapter *Chapter
+
+	// Sentiment of the sentence being spoken, if Sentiment Analysis was
+	// enabled.
+	Sentiment *SentimentAnalysisResult
+
+	// Utterance being spoken, if speaker diarization was enabled.
+	Utterance *TranscriptUtterance
+
+	// Word being spoken.
+	Word *TranscriptWord
+}
+
+// Timeline is a time-ordered view of the results of a transcript.
+type Timeline struct {
+	events []TimelineEvent
+
+	// The latest end of the events up to each index, which never decreases,
+	// so that the first event that may overlap a range can be searched for.
+	maxEnds []time.Duration
+}
+
+// Timeline returns the words, utterances and audio intelligence results of the
+// transcript as a single stream of events, ordered by start time.
+func (t Transcript) Timeline() Timeline {
+	var events []TimelineEvent
+
+	add := func(typ TimelineEventType, start, end *int64, text, speaker *string, value interface{}) {
+		events = append(events, TimelineEvent{
+			TimeRange: TimeRange{Start: toDuration(start), End: toDuration(end)},
+			Type:      typ,
+			Text:      ToString(text),
+			Speaker:   ToString(speaker),
+			Value:     value,
+		})
+	}
+
+	for _, w := range t.Words {
+		add(TimelineEventWord, w.Start, w.End, w.Text, w.Speaker, w)
+	}
+
+	for _, u := range t.Utterances {
+		add(TimelineEventUtterance, u.Start, u.End, u.Text, u.Speaker, u)
+	}
+
+	for _, c := range t.Chapters {
+		add(TimelineEventChapter, c.Start, c.End, c.Gist, nil, c)
+	}
+
+	for _, e := range t.Entities {
+		add(TimelineEventEntity, e.Start, e.End, e.Text, nil, e)
+	}
+
+	for _, s := range t.SentimentAnalysisResults {
+		add(TimelineEventSentiment, s.Start, s.End, s.Text, s.Speaker, s)
+	}
+
+	for _, r := range t.ContentSafetyLabels.Results {
+		add(TimelineEventContentSafety, r.Timestamp.Start, r.Timestamp.End, r.Text, nil, r)
+	}
+
+	for _, r := range t.IABCategoriesResult.Results {
+		add(TimelineEventTopic, r.Timestamp.Start, r.Timestamp.End, r.Text, nil, r)
+	}
+
+	for _, h := range t.AutoHighlightsResult.Results {
+		for _, ts := range h.Timestamps {
+			add(TimelineEventHighlight, ts.Start, ts.End, h.Text, nil, h)
+		}
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		if events[i].Start != events[j].Start {
+			return events[i].Start < events[j].Start
+		}
+		return events[i].End < events[j].End
+	})
+
+	maxEnds := make([]time.Duration, len(events))
+	for i, ev := range events {
+		maxEnds[i] = ev.End
+		if i > 0 && maxEnds[i-1] > ev.End {
+			maxEnds[i] = maxEnds[i-1]
+		}
+	}
+
+	return Timeline{events: events, maxEnds: maxEnds}
+}
+
+// Events returns every event of the timeline, optionally limited to the given
+// types.
+func (tl Timeline) Events(types ...TimelineEventType) []TimelineEvent {
+	return tl.Between(0, math.MaxInt64, types...)
+}
+
+// Between returns the events that overlap the range from start to end,
+// optionally limited to the given types.
+func (tl Timeline) Between(start, end time.Duration, types ...TimelineEventType) []TimelineEvent {
+	// Events are sorted by start time, so anything from n on starts after the
+	// range, and everything before first ends before it.
+	n := sort.Search(len(tl.events), func(i int) bool {
+		return tl.events[i].Start >= end
+	})
+
+	first := sort.Search(n, func(i int) bool {
+		return tl.maxEnds[i] >= start
+	})
+
+	var result []TimelineEvent
+
+	for _, ev := range tl.events[first:n] {
+		// Instantaneous events overlap the range if they fall inside it.
+		if ev.End <= start && !(ev.End == ev.Start && ev.Start == start) {
+			continue
+		}
+
+		if len(types) > 0 && !hasEventType(types, ev.Type) {
+			continue
+		}
+
+		result = append(result, ev)
+	}
+
+	return result
+}
+
+// At returns the chapter, speaker and sentiment at the given instant.
+func (tl Timeline) At(instant time.Duration) Moment {
+	var m Moment
+
+	for _, ev := range tl.Between(instant, instant+1) {
+		switch v := ev.Value.(type) {
+		case TranscriptWord:
+			m.Word = &v
+			if m.Speaker == "" {
+				m.Speaker = ev.Speaker
+			}
+		case TranscriptUtterance:
+			m.Utterance = &v
+			m.Speaker = ev.Speaker
+		case Chapter:
+			m.Chapter = &v
+		case SentimentAnalysisResult:
+			m.Sentiment = &v
+		}
+	}
+
+	return m
+}
+
+func hasEventType(types []TimelineEventType, typ TimelineEventType) bool {
+	for _, t := range types {
+		if t == typ {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,80 @@
+package assemblyai
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTranscript_Timeline(t *testing.T) {
+	t.Parallel()
+
+	transcript := Transcript{
+		Words: []TranscriptWord{
+			{Text: String("Smoke"), Start: Int64(250), End: Int64(650), Speaker: String("A")},
+			{Text: String("from"), Start: Int64(730), End: Int64(1022), Speaker: String("A")},
+			{Text: String("Canada."), Start: Int64(61000), End: Int64(61500), Speaker: String("B")},
+		},
+		Utterances: []TranscriptUtterance{
+			{Speaker: String("A"), Start: Int64(250), End: Int64(1022), Text: String("Smoke from")},
+			{Speaker: String("B"), Start: Int64(61000), End: Int64(61500), Text: String("Canada.")},
+		},
+		Chapters: []Chapter{
+			{Start: Int64(0), End: Int64(60000), Gist: String("Wildfire smoke")},
+			{Start: Int64(60000), End: Int64(120000), Gist: String("Health effects")},
+		},
+		Entities: []Entity{
+			{Start: Int64(61000), End: Int64(61500), Text: String("Canada"), EntityType: "location"},
+		},
+		SentimentAnalysisResults: []SentimentAnalysisResult{
+			{Start: Int64(250), End: Int64(1022), Text: String("Smoke from"), Sentiment: "NEGATIVE", Speaker: String("A")},
+		},
+		AutoHighlightsResult: AutoHighlightsResult{
+			Results: []AutoHighlightResult{
+				{
+					Text: String("smoke"),
+					Timestamps: []Timestamp{
+						{Start: Int64(250), End: Int64(650)},
+						{Start: Int64(90000), End: Int64(90500)},
+					},
+				},
+			},
+		},
+	}
+
+	tl := transcript.Timeline()
+
+	events := tl.Events()
+	require.Len(t, events, 11)
+
+	for i := 1; i < len(events); i++ {
+		require.LessOrEqual(t, events[i-1].Start, events[i].Start)
+	}
+
+	highlights := tl.Events(TimelineEventHighlight)
+	require.Len(t, highlights, 2)
+	require.Equal(t, 90*time.Second, highlights[1].Start)
+
+	between := tl.Between(time.Minute, time.Minute+30*time.Second, TimelineEventChapter, TimelineEventEntity)
+	require.Len(t, between, 2)
+	require.Equal(t, "Health effects", between[0].Text)
+	require.Equal(t, "Canada", between[1].Text)
+
+	m := tl.At(500 * time.Millisecond)
+	require.Equal(t, "A", m.Speaker)
+	require.Equal(t, "Wildfire smoke", *m.Chapter.Gist)
+	require.Equal(t, Sentiment("NEGATIVE"), m.Sentiment.Sentiment)
+	require.Equal(t, "Smoke", *m.Word.Text)
+
+	// The chapter is found even though later, shorter events end before it.
+	m = tl.At(30 * time.Second)
+	require.Equal(t, "Wildfire smoke", *m.Chapter.Gist)
+	require.Nil(t, m.Word)
+
+	m = tl.At(70 * time.Second)
+	require.Equal(t, "", m.Speaker)
+	require.Equal(t, "Health effects", *m.Chapter.Gist)
+	require.Nil(t, m.Sentiment)
+	require.Nil(t, m.Word)
+}
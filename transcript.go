@@ -39,8 +39,22 @@ type TranscriptService struct {
 // SubmitFromURL submits an audio file for transcription without waiting for it
 // to finish.
 //
+// The parameters are merged over the client defaults set with
+// [WithDefaultTranscriptParams], and validated before they're submitted, unless
+// the client was created with [WithoutValidation].
+//
 // https://www.assemblyai.com/docs/API%20reference/transcript#create-a-transcript
 func (s *TranscriptService) SubmitFromURL(ctx context.Context, audioURL string, opts *TranscriptOptionalParams) (Transcript, error) {
+	opts = s.client.withDefaults(opts)
+
+	if err := s.client.validateParams(opts); err != nil {
+		return Transcript{}, err
+	}
+
+	return s.submit(ctx, audioURL, opts)
+}
+
+func (s *TranscriptService) submit(ctx context.Context, audioURL string, opts *TranscriptOptionalParams) (Transcript, error) {
 	var transcript Transcript
 
 	params := TranscriptParams{
@@ -66,11 +80,17 @@ func (s *TranscriptService) SubmitFromURL(ctx context.Context, audioURL string,
 // SubmitFromReader submits audio for transcription without waiting for it to
 // finish.
 func (s *TranscriptService) SubmitFromReader(ctx context.Context, reader io.Reader, params *TranscriptOptionalParams) (Transcript, error) {
+	params = s.client.withDefaults(params)
+
+	if err := s.client.validateParams(params); err != nil {
+		return Transcript{}, err
+	}
+
 	u, err := s.client.Upload(ctx, reader)
 	if err != nil {
 		return Transcript{}, err
 	}
-	return s.SubmitFromURL(ctx, u, params)
+	return s.submit(ctx, u, params)
 }
 
 // Delete permanently deletes a transcript.
@@ -163,6 +183,8 @@ type TranscriptGetSubtitlesOptions struct {
 	CharsPerCaption int64 `json:"chars_per_caption"`
 }
 
+// GetSubtitles returns the subtitles for a transcript in the given format. Use
+// [ParseSubtitles] to work with the individual cues.
 func (s *TranscriptService) GetSubtitles(ctx context.Context, transcriptID string, format SubtitleFormat, opts *TranscriptGetSubtitlesOptions) ([]byte, error) {
 	req, err := s.client.newRequest(ctx, "GET", fmt.Sprintf("/v2/transcript/%s/%s", transcriptID, format), nil)
 	if err != nil {
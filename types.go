@@ -1,5 +1,7 @@
 package assemblyai
 
+import "encoding/json"
+
 // Either success, or unavailable in the rare case that the model failed
 type AudioIntelligenceModelStatus string
 
@@ -130,6 +132,9 @@ type LeMURActionItemsResponse struct {
 	LeMURBaseResponse
 	// The response generated by LeMUR
 	Response *string `json:"response,omitempty"`
+
+	// Unknown fields, like [Transcript.Extra].
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 type LeMURBaseParams struct {
@@ -200,6 +205,9 @@ type LeMURQuestionAnswerResponse struct {
 	LeMURBaseResponse
 	// The answers generated by LeMUR and their questions
 	Response []LeMURQuestionAnswer `json:"response,omitempty"`
+
+	// Unknown fields, like [Transcript.Extra].
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 type LeMURSummaryParams struct {
@@ -212,6 +220,9 @@ type LeMURSummaryResponse struct {
 	LeMURBaseResponse
 	// The response generated by LeMUR
 	Response *string `json:"response,omitempty"`
+
+	// Unknown fields, like [Transcript.Extra].
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 type LeMURTaskParams struct {
@@ -226,6 +237,9 @@ type LeMURTaskResponse struct {
 	Response *string `json:"response,omitempty"`
 
 	LeMURBaseResponse
+
+	// Unknown fields, like [Transcript.Extra].
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // The usage numbers for the LeMUR request
@@ -624,6 +638,10 @@ type Transcript struct {
 	// An array of temporally-sequential word objects, one for each word in the transcript.
 	// See [Speech recognition](https://www.assemblyai.com/docs/models/speech-recognition) for more information.
 	Words []TranscriptWord `json:"words,omitempty"`
+
+	// Fields returned by the API that the SDK doesn't know about yet. They're
+	// preserved when the value is encoded to JSON. See [DecodeExtra].
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // How much to boost specified words
@@ -672,6 +690,9 @@ type TranscriptListItem struct {
 
 	// The status of the transcript
 	Status TranscriptStatus `json:"status,omitempty"`
+
+	// Unknown fields, like [Transcript.Extra].
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // The parameters for creating a transcript
@@ -0,0 +1,220 @@
+package assemblyai
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidParams is matched by [errors.Is] for every [ValidationError].
+var ErrInvalidParams = errors.New("invalid transcript parameters")
+
+// ParamError describes a single problem with a transcript parameter.
+type ParamError struct {
+	// The JSON name of the offending parameter.
+	Param string
+
+	// A description of the problem.
+	Message string
+}
+
+// Error returns the parameter name followed by the problem.
+func (e ParamError) Error() string {
+	return e.Param + ": " + e.Message
+}
+
+// ValidationError lists every problem found by
+// [TranscriptOptionalParams.Validate] or
+// [TranscriptOptionalParams.ValidateStrict].
+type ValidationError struct {
+	Errors []ParamError
+}
+
+// Error returns all problems separated by semicolons.
+func (e *ValidationError) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		msgs[i] = err.Error()
+	}
+	return fmt.Sprintf("%s: %s", ErrInvalidParams, strings.Join(msgs, "; "))
+}
+
+// Is reports whether target is [ErrInvalidParams].
+func (e *ValidationError) Is(target error) bool {
+	return target == ErrInvalidParams
+}
+
+// Validate checks the parameters for combinations that would otherwise be
+// rejected by the API, or result in a failed transcript, such as a parameter
+// that requires a feature that isn't enabled. It returns a [*ValidationError]
+// listing every problem, or nil if there are none.
+//
+// Values aren't checked against ranges or the supported languages and
+// summary types, which may change on the API before they change here. Use
+// [TranscriptOptionalParams.ValidateStrict] to check them as well.
+func (p TranscriptOptionalParams) Validate() error {
+	return validationError(p.combinationErrors(nil))
+}
+
+// ValidateStrict checks the parameters like
+// [TranscriptOptionalParams.Validate], and also checks that values are within
+// their documented ranges, and that the speech model and summary model
+// support the language code and summary type. Models that aren't set aren't
+// checked, since the API may choose them.
+func (p TranscriptOptionalParams) ValidateStrict() error {
+	return validationError(p.valueErrors(p.combinationErrors(nil)))
+}
+
+func validationError(errs []ParamError) error {
+	if len(errs) > 0 {
+		return &ValidationError{Errors: errs}
+	}
+	return nil
+}
+
+// combinationErrors appends the problems with combinations of parameters to
+// errs.
+func (p TranscriptOptionalParams) combinationErrors(errs []ParamError) []ParamError {
+	add := func(param, format string, args ...interface{}) {
+		errs = append(errs, ParamError{Param: param, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if p.AudioStartFrom != nil && p.AudioEndAt != nil && *p.AudioEndAt <= *p.AudioStartFrom {
+		add("audio_end_at", "must be after audio_start_from")
+	}
+
+	if ToBool(p.DualChannel) && ToBool(p.Multichannel) {
+		add("dual_channel", "can't be combined with multichannel")
+	}
+
+	if p.SpeakersExpected != nil && !ToBool(p.SpeakerLabels) {
+		add("speakers_expected", "requires speaker_labels")
+	}
+
+	if p.LanguageCode != "" && ToBool(p.LanguageDetection) {
+		add("language_code", "can't be combined with language_detection")
+	}
+
+	if !ToBool(p.Summarization) {
+		if p.SummaryType != "" {
+			add("summary_type", "requires summarization")
+		}
+
+		if p.SummaryModel != "" {
+			add("summary_model", "requires summarization")
+		}
+	} else if ToBool(p.AutoChapters) {
+		add("summarization", "can't be combined with auto_chapters")
+	}
+
+	if !ToBool(p.RedactPII) {
+		if len(p.RedactPIIPolicies) > 0 {
+			add("redact_pii_policies", "requires redact_pii")
+		}
+
+		if p.RedactPIISub != "" {
+			add("redact_pii_sub", "requires redact_pii")
+		}
+
+		if ToBool(p.RedactPIIAudio) {
+			add("redact_pii_audio", "requires redact_pii")
+		}
+	}
+
+	if p.RedactPIIAudioQuality != "" && !ToBool(p.RedactPIIAudio) {
+		add("redact_pii_audio_quality", "requires redact_pii_audio")
+	}
+
+	if p.ContentSafetyConfidence != nil && !ToBool(p.ContentSafety) {
+		add("content_safety_confidence", "requires content_safety")
+	}
+
+	if p.BoostParam != "" && len(p.WordBoost) == 0 {
+		add("boost_param", "requires word_boost")
+	}
+
+	if (p.WebhookAuthHeaderName == nil) != (p.WebhookAuthHeaderValue == nil) {
+		add("webhook_auth_header_name", "must be set together with webhook_auth_header_value")
+	}
+
+	if p.WebhookAuthHeaderName != nil && p.WebhookURL == nil {
+		add("webhook_auth_header_name", "requires webhook_url")
+	}
+
+	return errs
+}
+
+// valueErrors appends the problems with the values of parameters to errs.
+func (p TranscriptOptionalParams) valueErrors(errs []ParamError) []ParamError {
+	add := func(param, format string, args ...interface{}) {
+		errs = append(errs, ParamError{Param: param, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if p.AudioStartFrom != nil && *p.AudioStartFrom < 0 {
+		add("audio_start_from", "must not be negative")
+	}
+
+	if p.AudioEndAt != nil && *p.AudioEndAt < 0 {
+		add("audio_end_at", "must not be negative")
+	}
+
+	if n := p.SpeakersExpected; n != nil && (*n < 1 || *n > 10) {
+		add("speakers_expected", "must be between 1 and 10")
+	}
+
+	if t := p.LanguageConfidenceThreshold; t != nil && (*t < 0 || *t > 1) {
+		add("language_confidence_threshold", "must be between 0 and 1")
+	}
+
+	if t := p.SpeechThreshold; t != nil && (*t < 0 || *t > 1) {
+		add("speech_threshold", "must be between 0 and 1")
+	}
+
+	if p.SpeechModel.Valid() && p.LanguageCode.Valid() && !p.SpeechModel.SupportsLanguage(p.LanguageCode) {
+		add("language_code", "%s isn't supported by the %s speech model", p.LanguageCode.Name(), p.SpeechModel.Name())
+	}
+
+	if p.SummaryModel.Valid() && p.SummaryType.Valid() && !p.SummaryModel.SupportsSummaryType(p.SummaryType) {
+		add("summary_type", "%s isn't supported by the %s summary model", p.SummaryType.Name(), p.SummaryModel.Name())
+	}
+
+	if ToBool(p.RedactPII) && len(p.RedactPIIPolicies) == 0 {
+		add("redact_pii_policies", "must not be empty when redact_pii is enabled")
+	}
+
+	if c := p.ContentSafetyConfidence; c != nil && (*c < 25 || *c > 100) {
+		add("content_safety_confidence", "must be between 25 and 100")
+	}
+
+	return errs
+}
+
+// WithoutValidation disables the validation of transcript parameters before
+// they're submitted. Use it if the client rejects parameters that the API
+// accepts.
+func WithoutValidation() ClientOption {
+	return func(c *Client) {
+		c.skipValidation = true
+	}
+}
+
+// WithStrictValidation validates transcript parameters before they're
+// submitted with [TranscriptOptionalParams.ValidateStrict] rather than
+// [TranscriptOptionalParams.Validate].
+func WithStrictValidation() ClientOption {
+	return func(c *Client) {
+		c.strictValidation = true
+	}
+}
+
+// validateParams validates the parameters unless validation has been disabled
+// for the client.
+func (c *Client) validateParams(params *TranscriptOptionalParams) error {
+	if params == nil || c.skipValidation {
+		return nil
+	}
+	if c.strictValidation {
+		return params.ValidateStrict()
+	}
+	return params.Validate()
+}
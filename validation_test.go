@@ -0,0 +1,134 @@
+package assemblyai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTranscriptOptionalParams_Validate(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, TranscriptOptionalParams{}.Validate())
+
+	require.NoError(t, TranscriptOptionalParams{
+		SpeakerLabels:    Bool(true),
+		SpeakersExpected: Int64(2),
+		Summarization:    Bool(true),
+		SummaryType:      "bullets",
+		AudioStartFrom:   Int64(1000),
+		AudioEndAt:       Int64(5000),
+		RedactPII:        Bool(true),
+		RedactPIIPolicies: []PIIPolicy{
+			"person_name",
+		},
+		RedactPIIAudio:        Bool(true),
+		RedactPIIAudioQuality: "wav",
+	}.Validate())
+
+	err := TranscriptOptionalParams{
+		SpeakersExpected:      Int64(2),
+		DualChannel:           Bool(true),
+		Multichannel:          Bool(true),
+		SummaryType:           "bullets",
+		AudioStartFrom:        Int64(5000),
+		AudioEndAt:            Int64(1000),
+		LanguageCode:          "en_us",
+		LanguageDetection:     Bool(true),
+		RedactPIIAudioQuality: "wav",
+	}.Validate()
+	require.ErrorIs(t, err, ErrInvalidParams)
+
+	var verr *ValidationError
+	require.True(t, errors.As(err, &verr))
+
+	var params []string
+	for _, e := range verr.Errors {
+		params = append(params, e.Param)
+	}
+
+	require.Equal(t, []string{
+		"audio_end_at",
+		"dual_channel",
+		"speakers_expected",
+		"language_code",
+		"summary_type",
+		"redact_pii_audio_quality",
+	}, params)
+
+	require.True(t, strings.HasPrefix(err.Error(), "invalid transcript parameters: audio_end_at: must be after audio_start_from; "))
+
+	// Ranges are only checked by strict validation.
+	values := TranscriptOptionalParams{
+		SpeakerLabels:    Bool(true),
+		SpeakersExpected: Int64(20),
+		RedactPII:        Bool(true),
+	}
+
+	require.NoError(t, values.Validate())
+	require.EqualError(t, values.ValidateStrict(), "invalid transcript parameters: "+
+		"speakers_expected: must be between 1 and 10; "+
+		"redact_pii_policies: must not be empty when redact_pii is enabled")
+}
+
+func TestTranscripts_SubmitValidation(t *testing.T) {
+	t.Parallel()
+
+	client, handler, teardown := setup()
+	defer teardown()
+
+	var requests int32
+
+	handler.HandleFunc("/v2/upload", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"upload_url": %q}`, fakeAudioURL)
+	})
+
+	handler.HandleFunc("/v2/transcript", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"id": %q, "status": "queued"}`, fakeTranscriptID)
+	})
+
+	ctx := context.Background()
+
+	invalid := &TranscriptOptionalParams{SpeakersExpected: Int64(2)}
+
+	_, err := client.Transcripts.SubmitFromURL(ctx, fakeAudioURL, invalid)
+	require.ErrorIs(t, err, ErrInvalidParams)
+
+	_, err = client.Transcripts.SubmitFromReader(ctx, strings.NewReader("audio"), invalid)
+	require.ErrorIs(t, err, ErrInvalidParams)
+
+	_, err = client.Transcripts.TranscribeFromURL(ctx, fakeAudioURL, invalid)
+	require.ErrorIs(t, err, ErrInvalidParams)
+
+	require.Zero(t, atomic.LoadInt32(&requests))
+
+	// Strict validation can be turned on for the client.
+	strict := &TranscriptOptionalParams{SpeakerLabels: Bool(true), SpeakersExpected: Int64(20)}
+
+	WithStrictValidation()(client)
+
+	_, err = client.Transcripts.SubmitFromURL(ctx, fakeAudioURL, strict)
+	require.ErrorIs(t, err, ErrInvalidParams)
+
+	require.Zero(t, atomic.LoadInt32(&requests))
+
+	// Validation can be turned off for the client.
+	WithoutValidation()(client)
+
+	transcript, err := client.Transcripts.SubmitFromReader(ctx, strings.NewReader("audio"), invalid)
+	require.NoError(t, err)
+	require.Equal(t, fakeTranscriptID, ToString(transcript.ID))
+	require.Equal(t, int32(2), atomic.LoadInt32(&requests))
+}